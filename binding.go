@@ -1,32 +1,84 @@
 package rigging
 
 import (
+	"encoding"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
+// textUnmarshalerType is used to detect field types that decode themselves from strings.
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// basicTypes maps each basic kind to its predeclared type.
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
+
 // tagConfig holds parsed directives from a struct field's `conf` tag.
 type tagConfig struct {
 	env        string   // Environment variable name (env:VAR_NAME)
 	name       string   // Custom key path (name:custom.path)
 	prefix     string   // Prefix for nested structs (prefix:foo)
 	defValue   string   // Default value (default:value)
-	min        string   // Minimum constraint (min:N)
-	max        string   // Maximum constraint (max:M)
+	min        string   // Inclusive minimum constraint (min:N or gte:N)
+	max        string   // Inclusive maximum constraint (max:M or lte:M)
+	gt         string   // Exclusive minimum constraint (gt:N)
+	lt         string   // Exclusive maximum constraint (lt:M)
 	oneof      []string // Allowed values (oneof:a,b,c)
+	reqKeys    []string // Keys that must be present in a map field (requiredkeys:a,b)
 	required   bool     // Field is required (required or required:true)
 	secret     bool     // Field is secret (secret or secret:true)
+	mask       string   // How secret values are shown: "full" (default) or "partial" (mask:partial)
 	hasDefault bool     // Whether a default directive was present
+	format     string   // Value format (format:bytes, format:percent, format:grouped, format:json)
+	timeFormat string   // Layout of a time.Time field (timeformat:02/01/2006), or timeformat:unix / timeformat:unixmilli
+	deprecated bool     // Setting this field triggers a deprecation warning (deprecated or deprecated:message)
+	deprecMsg  string   // Optional hint shown in the deprecation warning
+	group      string   // At most one field of the group may be set (group:name)
+	reqGroup   string   // At least one field of the group must be set (required-group:name)
+	trim       bool     // Trim surrounding whitespace from string values (trim)
+	caseMode   string   // Case normalization of string values: "lower", "upper", or "title"
 }
 
 // parseTag parses a `conf` struct tag into a structured tagConfig.
 // Tag format: "directive1:value1,directive2:value2,..."
 // Boolean directives can omit `:true` (e.g., "required" == "required:true")
+// Results are cached per tag; callers must not modify the returned slices.
 func parseTag(tag string) tagConfig {
+	if tag == "" {
+		return tagConfig{}
+	}
+	if cached, ok := tagCache.Load(tag); ok {
+		return cached.(tagConfig)
+	}
+	cfg := parseTagDirectives(tag)
+	tagCache.Store(tag, cfg)
+	return cfg
+}
+
+// parseTagDirectives parses a tag without consulting the cache.
+func parseTagDirectives(tag string) tagConfig {
 	cfg := tagConfig{}
 
 	if tag == "" {
@@ -61,28 +113,22 @@ func parseTag(tag string) tagConfig {
 		case "default":
 			cfg.defValue = value
 			cfg.hasDefault = true
-		case "min":
+		case "min", "gte":
 			cfg.min = value
-		case "max":
+		case "max", "lte":
 			cfg.max = value
+		case "gt":
+			cfg.gt = value
+		case "lt":
+			cfg.lt = value
+		case "format":
+			cfg.format = strings.ToLower(strings.TrimSpace(value))
+		case "timeformat":
+			cfg.timeFormat = strings.TrimSpace(value) // Layouts are case-sensitive
 		case "oneof":
-			// Empty or duplicated values are ignored.
-			// The final result is sorted.
-			if value != "" {
-				parts := strings.Split(value, ",")
-				seen := make(map[string]bool)
-				for _, v := range parts {
-					trimmed := strings.TrimSpace(v)
-					if trimmed == "" || seen[trimmed] {
-						continue
-					}
-
-					cfg.oneof = append(cfg.oneof, trimmed)
-					seen[trimmed] = true
-				}
-
-				sort.Strings(cfg.oneof)
-			}
+			cfg.oneof = parseListValue(value)
+		case "requiredkeys":
+			cfg.reqKeys = parseListValue(value)
 		case "required":
 			// No value or explicit "true" means true
 			if value == "" || value == "true" {
@@ -93,6 +139,17 @@ func parseTag(tag string) tagConfig {
 				// Invalid value, default to true for safety
 				cfg.required = true
 			}
+		case "group":
+			cfg.group = strings.TrimSpace(value)
+		case "required-group":
+			cfg.reqGroup = strings.TrimSpace(value)
+		case "deprecated":
+			cfg.deprecated = true
+			cfg.deprecMsg = strings.TrimSpace(value)
+		case "trim":
+			cfg.trim = true
+		case "lower", "upper", "title":
+			cfg.caseMode = name
 		case "secret":
 			// No value or explicit "true" means true
 			if value == "" || value == "true" {
@@ -103,12 +160,42 @@ func parseTag(tag string) tagConfig {
 				// Invalid value, default to true for safety
 				cfg.secret = true
 			}
+		case "mask":
+			cfg.mask = strings.ToLower(strings.TrimSpace(value))
 		}
 	}
 
+	// A masked field is secret everywhere partial masking doesn't apply (e.g. error messages)
+	if cfg.mask == "partial" {
+		cfg.secret = true
+	}
+
 	return cfg
 }
 
+// parseListValue splits a comma-separated directive value.
+// Empty or duplicated values are ignored. The final result is sorted.
+func parseListValue(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, v := range strings.Split(value, ",") {
+		trimmed := strings.TrimSpace(v)
+		if trimmed == "" || seen[trimmed] {
+			continue
+		}
+
+		result = append(result, trimmed)
+		seen[trimmed] = true
+	}
+
+	sort.Strings(result)
+	return result
+}
+
 // extractTagDirectives extracts individual directives from a tag string.
 // It handles the special case where oneof values contain commas.
 // It doesn't validate the tags, validation happens in parseTag().
@@ -120,12 +207,14 @@ func extractTagDirectives(tag string) []string {
 	for i := 0; i < len(tag); i++ {
 		ch := tag[i]
 
-		// Check if we're entering an oneof directive
-		if !inOneof && i+6 <= len(tag) && tag[i:i+6] == "oneof:" {
-			inOneof = true
-			current.WriteString("oneof:")
-			i += 5 // Skip past "oneof:"
-			continue
+		// Check if we're entering a list directive (oneof, requiredkeys)
+		if !inOneof {
+			if listDirective := listDirectiveAt(tag, i); listDirective != "" {
+				inOneof = true
+				current.WriteString(listDirective)
+				i += len(listDirective) - 1 // Skip past the directive name
+				continue
+			}
 		}
 
 		if ch == ',' {
@@ -161,18 +250,400 @@ func extractTagDirectives(tag string) []string {
 	return directives
 }
 
+// listDirectives are directives whose values are comma-separated lists.
+var listDirectives = []string{"oneof:", "requiredkeys:"}
+
+// listDirectiveAt returns the list directive starting at position i of tag, or "".
+func listDirectiveAt(tag string, i int) string {
+	for _, d := range listDirectives {
+		if strings.HasPrefix(tag[i:], d) {
+			return d
+		}
+	}
+	return ""
+}
+
+// tagDirectiveNames lists the directives recognized after a comma inside a list directive.
+// Names ending in ':' take a value; the others are flags.
+var tagDirectiveNames = []string{"env:", "name:", "prefix:", "default:", "min:", "max:", "gt:", "lt:", "gte:", "lte:", "oneof:", "requiredkeys:", "required-group:", "required", "secret", "mask:", "format:", "timeformat:", "deprecated", "group:", "trim", "lower", "upper", "title"}
+
 // startsWithDirective checks if a string starts with a known directive name.
+// A flag must be a whole token, followed by ':', ',' or the end of the string,
+// so list values such as "lowercase" or "trimmed" are not mistaken for directives.
 func startsWithDirective(s string) bool {
 	s = strings.TrimSpace(s)
-	directives := []string{"env:", "name:", "prefix:", "default:", "min:", "max:", "oneof:", "required", "secret"}
-	for _, d := range directives {
-		if strings.HasPrefix(s, d) {
+	for _, d := range tagDirectiveNames {
+		if !strings.HasPrefix(s, d) {
+			continue
+		}
+		if strings.HasSuffix(d, ":") || len(s) == len(d) || s[len(d)] == ':' || s[len(d)] == ',' {
 			return true
 		}
 	}
 	return false
 }
 
+// normalizeStrings applies the trim and case directives to a string or []string field in place.
+// Trimming happens before the case change.
+func normalizeStrings(v reflect.Value, tags tagConfig) {
+	if !tags.trim && tags.caseMode == "" {
+		return
+	}
+
+	switch {
+	case v.Kind() == reflect.String:
+		v.SetString(normalizeString(v.String(), tags))
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		// Copy so the source's slice is not modified
+		normalized := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			normalized.Index(i).SetString(normalizeString(v.Index(i).String(), tags))
+		}
+		v.Set(normalized)
+	}
+}
+
+// normalizeString applies the trim and case directives to s.
+func normalizeString(s string, tags tagConfig) string {
+	if tags.trim {
+		s = strings.TrimSpace(s)
+	}
+
+	switch tags.caseMode {
+	case "lower":
+		s = strings.ToLower(s)
+	case "upper":
+		s = strings.ToUpper(s)
+	case "title":
+		s = titleCase(s)
+	}
+	return s
+}
+
+// titleCase upper-cases the first letter of each whitespace-separated word and lower-cases the rest.
+func titleCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	startOfWord := true
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			startOfWord = true
+		case startOfWord:
+			r = unicode.ToUpper(r)
+			startOfWord = false
+		default:
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// convertFieldValue converts a raw value for a field, applying format directives from its tag
+// (e.g., format:bytes, timeformat:2006-01-02) before falling back to convertValue.
+func convertFieldValue(rawValue any, targetType reflect.Type, tags tagConfig) (any, error) {
+	if (tags.format == "" && tags.timeFormat == "") || rawValue == nil {
+		return convertValue(rawValue, targetType)
+	}
+
+	// Apply the format to the inner type of Optional[T]
+	if isOptionalType(targetType) {
+		innerValue, err := convertFieldValue(rawValue, targetType.Field(0).Type, tags)
+		if err != nil {
+			return nil, err
+		}
+
+		optionalVal := reflect.New(targetType).Elem()
+		optionalVal.Field(0).Set(reflect.ValueOf(innerValue))
+		optionalVal.Field(1).SetBool(true)
+		return optionalVal.Interface(), nil
+	}
+
+	if tags.timeFormat != "" {
+		if layout := strings.ToLower(tags.timeFormat); layout == "unix" || layout == "unixmilli" {
+			return convertUnixTime(rawValue, targetType, layout)
+		}
+		return convertTimeLayout(rawValue, targetType, tags.timeFormat)
+	}
+
+	switch tags.format {
+	case "bytes":
+		return convertByteSize(rawValue, targetType)
+	case "percent":
+		return convertPercent(rawValue, targetType)
+	case "grouped":
+		return convertGrouped(rawValue, targetType)
+	case "json":
+		return convertJSON(rawValue, targetType)
+	default:
+		return nil, fmt.Errorf("unknown format %q", tags.format)
+	}
+}
+
+// convertTimeLayout parses a string into a time.Time using a Go reference layout (e.g., "02/01/2006").
+// Unlike convertValue, it does not fall back to the default layouts.
+func convertTimeLayout(rawValue any, targetType reflect.Type, layout string) (any, error) {
+	if targetType != reflect.TypeOf(time.Time{}) {
+		return nil, fmt.Errorf("timeformat:%s requires a time.Time field, got %s", layout, targetType)
+	}
+
+	switch v := rawValue.(type) {
+	case string:
+		t, err := time.Parse(layout, v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %q as time.Time (expected layout %q)", v, layout)
+		}
+		return t, nil
+	case time.Time:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("cannot convert %T to time.Time", rawValue)
+	}
+}
+
+// byteUnits maps byte size units (lowercase) to their multipliers.
+// SI units use powers of 1000, binary (IEC) units use powers of 1024.
+var byteUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"kb":  1000,
+	"mb":  1000 * 1000,
+	"gb":  1000 * 1000 * 1000,
+	"tb":  1000 * 1000 * 1000 * 1000,
+	"pb":  1000 * 1000 * 1000 * 1000 * 1000,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+// convertByteSize converts a human-readable byte size (e.g., "10KB", "512MiB") to an integer type.
+// Bare integers are passed through unchanged.
+func convertByteSize(rawValue any, targetType reflect.Type) (any, error) {
+	if !isIntegerKind(targetType.Kind()) {
+		return nil, fmt.Errorf("format:bytes requires an integer field, got %s", targetType)
+	}
+
+	str, ok := rawValue.(string)
+	if !ok {
+		return convertValue(rawValue, targetType)
+	}
+
+	size, err := parseByteSize(str)
+	if err != nil {
+		return nil, err
+	}
+	return convertValue(strconv.FormatUint(size, 10), targetType)
+}
+
+// parseByteSize parses a byte size such as "10KB", "1.5GiB", or "2048".
+func parseByteSize(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+
+	// Split the numeric part from the unit
+	i := 0
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+		i++
+	}
+	number, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	if number == "" {
+		return 0, fmt.Errorf("cannot parse %q as byte size", s)
+	}
+
+	multiplier, ok := byteUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("cannot parse %q as byte size: unknown unit %q (supported: B, KB, MB, GB, TB, PB, KiB, MiB, GiB, TiB, PiB)", s, s[i:])
+	}
+
+	if !strings.Contains(number, ".") {
+		n, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse %q as byte size: %w", s, err)
+		}
+		if n > math.MaxUint64/multiplier {
+			return 0, fmt.Errorf("byte size %q overflows uint64", s)
+		}
+		return n * multiplier, nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse %q as byte size: %w", s, err)
+	}
+	size := f * float64(multiplier)
+	if size >= math.MaxUint64 {
+		return 0, fmt.Errorf("byte size %q overflows uint64", s)
+	}
+	return uint64(size), nil
+}
+
+// convertPercent converts a percentage (e.g., "25%") or a bare ratio (e.g., "0.25") to a float type.
+// Percentages are divided by 100, so "25%" and "0.25" yield the same value.
+func convertPercent(rawValue any, targetType reflect.Type) (any, error) {
+	if targetType.Kind() != reflect.Float32 && targetType.Kind() != reflect.Float64 {
+		return nil, fmt.Errorf("format:percent requires a float field, got %s", targetType)
+	}
+
+	str, ok := rawValue.(string)
+	if !ok {
+		return convertValue(rawValue, targetType)
+	}
+
+	str = strings.TrimSpace(str)
+	number, isPercent := strings.CutSuffix(str, "%")
+	if !isPercent {
+		return convertValue(str, targetType)
+	}
+
+	f, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %q as percentage (expected e.g. \"25%%\" or \"0.25\")", str)
+	}
+	return convertValue(strconv.FormatFloat(f/100, 'g', -1, 64), targetType)
+}
+
+// convertGrouped converts an integer written with thousands separators (e.g., "1,000,000").
+func convertGrouped(rawValue any, targetType reflect.Type) (any, error) {
+	if !isIntegerKind(targetType.Kind()) {
+		return nil, fmt.Errorf("format:grouped requires an integer field, got %s", targetType)
+	}
+
+	str, ok := rawValue.(string)
+	if !ok || !strings.Contains(str, ",") {
+		return convertValue(rawValue, targetType)
+	}
+
+	cleaned, err := removeDigitSeparators(strings.TrimSpace(str), ',')
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %q to %s: %w", str, targetType.Kind(), err)
+	}
+	return convertValue(cleaned, targetType)
+}
+
+// convertUnixTime converts an integer Unix timestamp (e.g., 1700000000) to a time.Time in UTC.
+// The layout is "unix" for seconds or "unixmilli" for milliseconds. Numeric strings are accepted.
+func convertUnixTime(rawValue any, targetType reflect.Type, layout string) (any, error) {
+	if targetType != reflect.TypeOf(time.Time{}) {
+		return nil, fmt.Errorf("timeformat:%s requires a time.Time field, got %s", layout, targetType)
+	}
+	var n int64
+	switch v := rawValue.(type) {
+	case time.Time:
+		return v, nil
+	case float64:
+		// JSON numbers; printing them would yield exponent notation (1.7e+09)
+		if v != math.Trunc(v) || math.Abs(v) > math.MaxInt64 {
+			return nil, fmt.Errorf("cannot parse %v as a Unix timestamp (timeformat:%s expects an integer)", rawValue, layout)
+		}
+		n = int64(v)
+	default:
+		converted, err := convertValue(rawValue, reflect.TypeOf(int64(0)))
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %v as a Unix timestamp (timeformat:%s expects an integer)", rawValue, layout)
+		}
+		n = converted.(int64)
+	}
+
+	if layout == "unixmilli" {
+		return time.UnixMilli(n).UTC(), nil
+	}
+	return time.Unix(n, 0).UTC(), nil
+}
+
+// convertJSON decodes a JSON-encoded string (e.g., `["a","b"]` or `{"k":"v"}`) into a slice, map, or struct type.
+// Values that are not strings, such as arrays from file sources, are converted as usual.
+func convertJSON(rawValue any, targetType reflect.Type) (any, error) {
+	switch targetType.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct:
+	default:
+		return nil, fmt.Errorf("format:json requires a slice, map, or struct field, got %s", targetType)
+	}
+
+	str, ok := rawValue.(string)
+	if !ok {
+		return convertValue(rawValue, targetType)
+	}
+
+	target := reflect.New(targetType)
+	if err := json.Unmarshal([]byte(str), target.Interface()); err != nil {
+		return nil, fmt.Errorf("cannot parse value as JSON %s: %w", targetType, err)
+	}
+	return target.Elem().Interface(), nil
+}
+
+// jsonStructData decodes a JSON object for a nested struct field tagged format:json into
+// flattened, lowercased entries relative to the struct, attributed to the entry's source.
+func jsonStructData(entry mergedEntry) (map[string]mergedEntry, error) {
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(entry.value.(string)), &raw); err != nil {
+		return nil, fmt.Errorf("cannot parse value as JSON object: %w", err)
+	}
+
+	nestedData := make(map[string]mergedEntry)
+	var flatten func(prefix string, m map[string]any)
+	flatten = func(prefix string, m map[string]any) {
+		for k, v := range m {
+			key := prefix + strings.ToLower(k)
+			if nested, ok := v.(map[string]any); ok {
+				flatten(key+".", nested)
+				continue
+			}
+			nestedData[key] = mergedEntry{value: v, sourceName: entry.sourceName, sourceKey: entry.sourceKey, layer: entry.layer, line: entry.line}
+		}
+	}
+	flatten("", raw)
+	return nestedData, nil
+}
+
+// removeDigitSeparators removes sep from a decimal integer string.
+// Separators must sit between two digits: leading, trailing, or doubled separators are rejected.
+func removeDigitSeparators(s string, sep byte) (string, error) {
+	digits := s
+	sign := ""
+	if len(digits) > 0 && (digits[0] == '+' || digits[0] == '-') {
+		sign, digits = digits[:1], digits[1:]
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i := 0; i < len(digits); i++ {
+		if digits[i] != sep {
+			b.WriteByte(digits[i])
+			continue
+		}
+		if i == 0 || i == len(digits)-1 || !isDigit(digits[i-1]) || !isDigit(digits[i+1]) {
+			return "", fmt.Errorf("invalid placement of digit separator %q", sep)
+		}
+	}
+	return b.String(), nil
+}
+
+// isDigit reports whether c is an ASCII decimal digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isUnsignedKind reports whether k is an unsigned integer kind.
+func isUnsignedKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// isIntegerKind reports whether k is a signed or unsigned integer kind.
+func isIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
 // convertValue converts a raw value to the target type using reflection.
 // It supports:
 // - string, bool
@@ -182,6 +653,8 @@ func startsWithDirective(s string) bool {
 // - time.Duration (parsed from strings like "5s", "10m", "1h")
 // - time.Time (parsed from RFC3339, RFC3339Nano, and common date formats)
 // - []string (from comma-separated strings or arrays)
+// - []T for the types above (each element converted, see convertSlice)
+// - map[string]T (from maps, each value converted to T)
 // - nested structs (returned as-is for recursive binding)
 // - Optional[T] types
 //
@@ -239,6 +712,25 @@ func convertValue(rawValue any, targetType reflect.Type) (any, error) {
 		}
 	}
 
+	// Types that parse themselves from text, such as enums with an UnmarshalText method
+	if str, ok := rawValue.(string); ok && reflect.PointerTo(targetType).Implements(textUnmarshalerType) {
+		target := reflect.New(targetType)
+		if err := target.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(str)); err != nil {
+			return nil, fmt.Errorf("cannot convert %q to %s: %w", str, targetType, err)
+		}
+		return target.Elem().Interface(), nil
+	}
+
+	// Named basic types without their own parsing (e.g. type Region string) convert
+	// through their underlying type; time.Duration has its own handling below
+	if underlying, ok := basicTypes[targetType.Kind()]; ok && targetType != underlying && targetType != reflect.TypeOf(time.Duration(0)) {
+		value, err := convertValue(rawValue, underlying)
+		if err != nil {
+			return nil, err
+		}
+		return reflect.ValueOf(value).Convert(targetType).Interface(), nil
+	}
+
 	// Handle nested structs - return as-is for recursive binding
 	if targetType.Kind() == reflect.Struct {
 		// If rawValue is a map, it will be handled by recursive binding
@@ -251,6 +743,11 @@ func convertValue(rawValue any, targetType reflect.Type) (any, error) {
 		}
 	}
 
+	// Handle maps with string keys - convert each value to the element type
+	if targetType.Kind() == reflect.Map {
+		return convertMap(rawValue, targetType)
+	}
+
 	// Convert to string first for easier parsing
 	var strValue string
 	switch v := rawValue.(type) {
@@ -263,6 +760,22 @@ func convertValue(rawValue any, targetType reflect.Type) (any, error) {
 		strValue = fmt.Sprint(rawValue)
 	}
 
+	// Allow Go-style digit separators in integers (e.g., "1_000_000")
+	if isIntegerKind(targetType.Kind()) && targetType != reflect.TypeOf(time.Duration(0)) && strings.Contains(strValue, "_") {
+		cleaned, err := removeDigitSeparators(strValue, '_')
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert %q to %s: %w", strValue, targetType.Kind(), err)
+		}
+		strValue = cleaned
+	}
+
+	// Negative numbers would otherwise fail with a strconv syntax error
+	if isUnsignedKind(targetType.Kind()) && strings.HasPrefix(strValue, "-") {
+		if _, err := strconv.ParseFloat(strValue, 64); err == nil {
+			return nil, fmt.Errorf("cannot convert %q to %s: value must be non-negative", strValue, targetType.Kind())
+		}
+	}
+
 	// Handle target type conversion
 	switch targetType.Kind() {
 	case reflect.String:
@@ -369,7 +882,7 @@ func convertValue(rawValue any, targetType reflect.Type) (any, error) {
 		if targetType.Elem().Kind() == reflect.String {
 			return parseStringSlice(rawValue)
 		}
-		return nil, fmt.Errorf("unsupported slice type: %s", targetType)
+		return convertSlice(rawValue, targetType)
 
 	default:
 		return nil, fmt.Errorf("unsupported target type: %s", targetType)
@@ -421,18 +934,187 @@ func parseStringSlice(rawValue any) ([]string, error) {
 	}
 }
 
+// secretConversionMessage is the conversion error message of secret fields.
+const secretConversionMessage = "type conversion failed: invalid value ***redacted***"
+
+// sliceElementError reports the conversion failures of slice elements, in element order.
+// bindStruct reports each one on the element's path (e.g., "Ports[1]").
+type sliceElementError struct {
+	indexes []int   // Indexes of the invalid elements
+	errs    []error // Conversion error of each invalid element
+}
+
+func (e *sliceElementError) Error() string {
+	msg := fmt.Sprintf("element %d: %v", e.indexes[0], e.errs[0])
+	if len(e.errs) > 1 {
+		msg += fmt.Sprintf(" (and %d more)", len(e.errs)-1)
+	}
+	return msg
+}
+
+func (e *sliceElementError) Unwrap() []error {
+	return e.errs
+}
+
+// convertSlice converts a value to a []T target type for primitive, time.Duration, or time.Time T.
+// Handles arrays (e.g. from YAML) and comma-separated strings ("1s,2s,4s"); each element
+// is converted using convertValue.
+func convertSlice(rawValue any, targetType reflect.Type) (any, error) {
+	elemType := targetType.Elem()
+	optionalElems := isOptionalType(elemType)
+	valueType := elemType
+	if optionalElems {
+		valueType = elemType.Field(0).Type
+	}
+	switch valueType.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.Ptr, reflect.Interface:
+		return nil, fmt.Errorf("unsupported slice type: %s", targetType)
+	case reflect.Struct:
+		if valueType != reflect.TypeOf(time.Time{}) {
+			return nil, fmt.Errorf("unsupported slice type: %s", targetType)
+		}
+	}
+
+	var items []any
+	switch v := rawValue.(type) {
+	case string:
+		if strings.TrimSpace(v) != "" {
+			for _, part := range strings.Split(v, ",") {
+				part = strings.TrimSpace(part)
+				if part == "" && optionalElems {
+					items = append(items, nil) // "1,,3" leaves the middle Optional unset
+					continue
+				}
+				items = append(items, part)
+			}
+		}
+	default:
+		rv := reflect.ValueOf(rawValue)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("cannot convert %T to %s", rawValue, targetType)
+		}
+		for i := 0; i < rv.Len(); i++ {
+			items = append(items, rv.Index(i).Interface())
+		}
+	}
+
+	result := reflect.MakeSlice(targetType, len(items), len(items))
+	elemErr := &sliceElementError{}
+	for i, item := range items {
+		elem, err := convertValue(item, elemType)
+		if err != nil {
+			elemErr.indexes = append(elemErr.indexes, i)
+			elemErr.errs = append(elemErr.errs, err)
+			continue
+		}
+		result.Index(i).Set(reflect.ValueOf(elem).Convert(elemType))
+	}
+	if len(elemErr.errs) > 0 {
+		return nil, elemErr
+	}
+	return result.Interface(), nil
+}
+
+// convertMap converts a map value to a map[string]T target type.
+// Values are converted individually using convertValue.
+func convertMap(rawValue any, targetType reflect.Type) (any, error) {
+	if targetType.Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("unsupported map key type: %s", targetType)
+	}
+
+	rv := reflect.ValueOf(rawValue)
+	if rv.Kind() != reflect.Map {
+		return nil, fmt.Errorf("cannot convert %T to %s", rawValue, targetType)
+	}
+
+	result := reflect.MakeMapWithSize(targetType, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		key := fmt.Sprint(iter.Key().Interface())
+		elem, err := convertValue(iter.Value().Interface(), targetType.Elem())
+		if err != nil {
+			return nil, fmt.Errorf("map key %q: %w", key, err)
+		}
+
+		elemValue := reflect.New(targetType.Elem()).Elem()
+		if elem != nil {
+			elemValue.Set(reflect.ValueOf(elem))
+		}
+		result.SetMapIndex(reflect.ValueOf(key).Convert(targetType.Key()), elemValue)
+	}
+
+	return result.Interface(), nil
+}
+
+// lookupMapEntry gathers the entries for a map field.
+// File sources flatten nested maps to dot keys, so values may arrive either as a
+// map under keyPath or as individual keys below it (keyPath + ".").
+// Keys nested deeper than one level keep their remaining dotted path.
+func lookupMapEntry(data map[string]mergedEntry, keyPath string) (mergedEntry, bool) {
+	entry, found := data[keyPath]
+	// A null map (e.g. an empty "features:" section in YAML) is ignored in favor of sub-keys
+	found = found && entry.value != nil
+
+	collected := make(map[string]any)
+	if found {
+		if rawMap, ok := entry.value.(map[string]any); ok {
+			for k, v := range rawMap {
+				collected[k] = v
+			}
+		} else {
+			// Not a map (e.g. a scalar); let conversion report the error
+			return entry, true
+		}
+	}
+
+	prefix := keyPath + "."
+	var subKeys []string
+	for key := range data {
+		if strings.HasPrefix(key, prefix) {
+			subKeys = append(subKeys, key)
+		}
+	}
+	if len(subKeys) == 0 {
+		return entry, found
+	}
+
+	sort.Strings(subKeys)
+	secret := entry.secret
+	for _, key := range subKeys {
+		collected[strings.TrimPrefix(key, prefix)] = data[key].value
+		secret = secret || data[key].secret
+	}
+
+	// Attribute the map to the source of the first sub-key when there is no direct entry
+	if !found {
+		first := data[subKeys[0]]
+		entry = mergedEntry{sourceName: first.sourceName, layer: first.layer}
+	}
+	entry.value = collected
+	entry.sourceKey = ""
+	entry.secret = secret
+
+	return entry, true
+}
+
 // mergedEntry represents a configuration value with its source information.
 type mergedEntry struct {
-	value      any
-	sourceName string
-	sourceKey  string // Original key from the source (e.g., "API_DATABASE__PASSWORD")
+	value        any
+	sourceName   string
+	sourceKey    string // Original key from the source (e.g., "API_DATABASE__PASSWORD")
+	layer        string // Tag of the source (see WithTag)
+	line         int    // Line in the source, 0 if unknown
+	secret       bool   // Source marked the value as secret (see SourceWithSecrets)
+	interpolated bool   // Value had ${key} references resolved (see WithInterpolation)
 }
 
 // bindStruct binds configuration data to a struct using reflection.
 // It walks struct fields recursively, parses tags, looks up values in the data map,
-// applies defaults, converts types, and records provenance.
-// All errors are collected and returned together rather than failing fast.
-func bindStruct(target reflect.Value, data map[string]mergedEntry, provenanceFields *[]FieldProvenance, parentPrefix string, parentFieldPath string) []FieldError {
+// applies defaults, converts types, and records provenance. *Struct fields are bound like
+// nested structs only with pointerStructs (see WithPointerStructs); otherwise they are leaves.
+// All errors are collected and returned together, unless mode is FailFast: then binding
+// stops after the first field with an error.
+func bindStruct(target reflect.Value, data map[string]mergedEntry, provenanceFields *[]FieldProvenance, parentPrefix string, parentFieldPath string, pointerStructs bool, mode ValidationMode) []FieldError {
 	var fieldErrors []FieldError
 
 	// Ensure the target is a struct
@@ -443,22 +1125,42 @@ func bindStruct(target reflect.Value, data map[string]mergedEntry, provenanceFie
 		return fieldErrors
 	}
 
+	// Load rejects deeper types up front (see WithMaxDepth); this guards direct callers
+	if fieldPathDepth(parentFieldPath) >= maxWalkDepth {
+		return []FieldError{{
+			FieldPath: parentFieldPath,
+			Code:      ErrCodeConfigSchema,
+			Message:   fmt.Sprintf("struct nesting exceeds the maximum depth of %d", maxWalkDepth),
+		}}
+	}
+
 	targetType := target.Type()
 
 	// Walk through all fields
 	for i := 0; i < target.NumField(); i++ {
+		if mode == FailFast && len(fieldErrors) > 0 {
+			break
+		}
+
 		field := targetType.Field(i)
 		fieldValue := target.Field(i)
 
+		// Parse struct tag
+		tag := field.Tag.Get("conf")
+		tagCfg := parseTag(tag)
+
+		// Embedded structs share the parent's key prefix and field path
+		if isPromotedStruct(field, tagCfg) {
+			nestedErrors := bindStruct(fieldValue, data, provenanceFields, parentPrefix, parentFieldPath, pointerStructs, mode)
+			fieldErrors = append(fieldErrors, nestedErrors...)
+			continue
+		}
+
 		// Skip unexported fields
 		if !field.IsExported() {
 			continue
 		}
 
-		// Parse struct tag
-		tag := field.Tag.Get("conf")
-		tagCfg := parseTag(tag)
-
 		// Determine the field path for provenance (e.g., "Database.Host")
 		fieldPath := field.Name
 		if parentFieldPath != "" {
@@ -471,7 +1173,7 @@ func bindStruct(target reflect.Value, data map[string]mergedEntry, provenanceFie
 		// Handle nested structs with prefix
 		if fieldValue.Kind() == reflect.Struct && tagCfg.prefix != "" {
 			// Recursively bind nested struct with new prefix
-			nestedErrors := bindStruct(fieldValue, data, provenanceFields, tagCfg.prefix, fieldPath)
+			nestedErrors := bindStruct(fieldValue, data, provenanceFields, tagCfg.prefix, fieldPath, pointerStructs, mode)
 			fieldErrors = append(fieldErrors, nestedErrors...)
 			continue
 		}
@@ -482,34 +1184,82 @@ func bindStruct(target reflect.Value, data map[string]mergedEntry, provenanceFie
 			// Look up value in data map to see if there's a direct map value
 			entry, found := data[keyPath]
 
+			// A JSON object string (format:json), e.g. from an environment variable
+			if _, isString := entry.value.(string); found && isString && tagCfg.format == "json" {
+				nestedData, err := jsonStructData(entry)
+				if err != nil {
+					message := fmt.Sprintf("type conversion failed: %v", err)
+					if tagCfg.secret || entry.secret {
+						message = secretConversionMessage
+					}
+					fieldErrors = append(fieldErrors, FieldError{
+						FieldPath: fieldPath,
+						Code:      ErrCodeInvalidType,
+						Message:   message,
+					})
+					continue
+				}
+				nestedErrors := bindStruct(fieldValue, nestedData, provenanceFields, "", fieldPath, pointerStructs, mode)
+				fieldErrors = append(fieldErrors, nestedErrors...)
+				continue
+			}
+
 			// Check if rawValue is a map (from file sources)
 			if found && entry.value != nil {
 				if rawMap, ok := entry.value.(map[string]any); ok {
 					// Convert map entries to mergedEntry format
 					nestedData := make(map[string]mergedEntry)
 					for k, v := range rawMap {
-						nestedData[k] = mergedEntry{value: v, sourceName: entry.sourceName}
+						nestedData[k] = mergedEntry{value: v, sourceName: entry.sourceName, layer: entry.layer}
 					}
-					nestedErrors := bindStruct(fieldValue, nestedData, provenanceFields, "", fieldPath)
+					nestedErrors := bindStruct(fieldValue, nestedData, provenanceFields, "", fieldPath, pointerStructs, mode)
 					fieldErrors = append(fieldErrors, nestedErrors...)
 					continue
 				}
 			}
 			// Otherwise, try recursive binding with current data and prefix
 			// This handles the case where nested fields are flattened with dot notation
-			nestedErrors := bindStruct(fieldValue, data, provenanceFields, keyPath, fieldPath)
+			nestedErrors := bindStruct(fieldValue, data, provenanceFields, keyPath, fieldPath, pointerStructs, mode)
+			fieldErrors = append(fieldErrors, nestedErrors...)
+			continue
+		}
+
+		// Handle *Struct (WithPointerStructs): allocated when any key below it is present, nil otherwise
+		if pointerStructs && isStructPointer(fieldValue.Type()) {
+			nestedPrefix := keyPath
+			if tagCfg.prefix != "" {
+				nestedPrefix = strings.ToLower(tagCfg.prefix)
+			}
+			nestedErrors := bindPointerStruct(fieldValue, data, provenanceFields, nestedPrefix, fieldPath, pointerStructs, mode)
+			fieldErrors = append(fieldErrors, nestedErrors...)
+			continue
+		}
+
+		// Handle Optional[Struct]: set when any key below it is present
+		if isOptionalStruct(fieldValue.Type()) {
+			nestedErrors := bindOptionalStruct(fieldValue, data, provenanceFields, keyPath, fieldPath, pointerStructs, mode)
 			fieldErrors = append(fieldErrors, nestedErrors...)
 			continue
 		}
 
 		// Look up value in data map
 		entry, found := data[keyPath]
+		if fieldValue.Kind() == reflect.Map {
+			entry, found = lookupMapEntry(data, keyPath)
+		}
+		// A null value (e.g. "port:" in YAML) is treated like a missing key, so it
+		// doesn't satisfy required and the default still applies
+		found = found && entry.value != nil
 		var rawValue any
 		var sourceName string
+		var layer string
+		var line int
 
 		if found {
 			rawValue = entry.value
 			sourceName = entry.sourceName
+			layer = entry.layer
+			line = entry.line
 		} else if tagCfg.hasDefault {
 			// Apply default value
 			rawValue = tagCfg.defValue
@@ -523,19 +1273,37 @@ func bindStruct(target reflect.Value, data map[string]mergedEntry, provenanceFie
 		}
 
 		// Convert value to target type
-		convertedValue, err := convertValue(rawValue, fieldValue.Type())
+		convertedValue, err := convertFieldValue(rawValue, fieldValue.Type(), tagCfg)
 		if err != nil {
-			fieldErrors = append(fieldErrors, FieldError{
-				FieldPath: fieldPath,
-				Code:      ErrCodeInvalidType,
-				Message:   fmt.Sprintf("type conversion failed: %v", err),
-			})
+			// A slice reports every invalid element on its own path
+			errorPaths, errs := []string{fieldPath}, []error{err}
+			var elemErr *sliceElementError
+			if errors.As(err, &elemErr) {
+				errorPaths, errs = nil, elemErr.errs
+				for _, index := range elemErr.indexes {
+					errorPaths = append(errorPaths, fmt.Sprintf("%s[%d]", fieldPath, index))
+				}
+			}
+			for i, err := range errs {
+				message := fmt.Sprintf("type conversion failed: %v", err)
+				if tagCfg.secret || entry.secret {
+					// Converters may echo a normalized form of the input (e.g. "98_76x" as "9876x"),
+					// so secret fields get a fixed message without the wrapped error.
+					message = secretConversionMessage
+				}
+				fieldErrors = append(fieldErrors, FieldError{
+					FieldPath: errorPaths[i],
+					Code:      ErrCodeInvalidType,
+					Message:   message,
+				})
+			}
 			continue
 		}
 
 		// Set field value
 		if fieldValue.CanSet() {
 			fieldValue.Set(reflect.ValueOf(convertedValue))
+			normalizeStrings(fieldValue, tagCfg)
 
 			// Record provenance
 			if provenanceFields != nil {
@@ -545,12 +1313,21 @@ func bindStruct(target reflect.Value, data map[string]mergedEntry, provenanceFie
 					sourceInfo = entry.sourceKey
 				}
 
-				*provenanceFields = append(*provenanceFields, FieldProvenance{
-					FieldPath:  fieldPath,
-					KeyPath:    keyPath,
-					SourceName: sourceInfo,
-					Secret:     tagCfg.secret,
-				})
+				fieldProv := FieldProvenance{
+					FieldPath:    fieldPath,
+					KeyPath:      keyPath,
+					SourceName:   sourceInfo,
+					Secret:       tagCfg.secret || (found && entry.secret),
+					Layer:        layer,
+					Line:         line,
+					Explicit:     found && !isDefaultSource(entry.sourceName),
+					Interpolated: found && entry.interpolated,
+				}
+				if tagCfg.mask == "partial" {
+					rule := defaultMaskRule
+					fieldProv.mask = &rule
+				}
+				*provenanceFields = append(*provenanceFields, fieldProv)
 			}
 		}
 	}
@@ -558,6 +1335,85 @@ func bindStruct(target reflect.Value, data map[string]mergedEntry, provenanceFie
 	return fieldErrors
 }
 
+// bindOptionalStruct binds an Optional[Struct] field. If a source provides the field as a map or
+// provides any key below keyPath, the inner struct is bound (with defaults for missing inner fields)
+// and Set is true. Otherwise the field stays unset and inner defaults are not applied.
+func bindOptionalStruct(fieldValue reflect.Value, data map[string]mergedEntry, provenanceFields *[]FieldProvenance, keyPath string, fieldPath string, pointerStructs bool, mode ValidationMode) []FieldError {
+	nestedData, nestedPrefix, present := nestedStructData(data, keyPath)
+	if !present {
+		return nil
+	}
+
+	fieldValue.Field(1).SetBool(true)
+	return bindStruct(fieldValue.Field(0), nestedData, provenanceFields, nestedPrefix, fieldPath, pointerStructs, mode)
+}
+
+// bindPointerStruct binds a *Struct field. Like bindOptionalStruct, the struct is only allocated
+// when a source provides the field as a map or provides any key below keyPath; otherwise it stays nil.
+// A non-nil pointer is bound in place.
+func bindPointerStruct(fieldValue reflect.Value, data map[string]mergedEntry, provenanceFields *[]FieldProvenance, keyPath string, fieldPath string, pointerStructs bool, mode ValidationMode) []FieldError {
+	nestedData, nestedPrefix, present := nestedStructData(data, keyPath)
+	if !present {
+		return nil
+	}
+
+	if fieldValue.IsNil() {
+		fieldValue.Set(reflect.New(fieldValue.Type().Elem()))
+	}
+	return bindStruct(fieldValue.Elem(), nestedData, provenanceFields, nestedPrefix, fieldPath, pointerStructs, mode)
+}
+
+// nestedStructData returns the data and key prefix to bind a nested struct at keyPath with,
+// and whether any value for it is present. A direct map value (e.g., from file sources)
+// is returned with keys relative to the struct.
+func nestedStructData(data map[string]mergedEntry, keyPath string) (map[string]mergedEntry, string, bool) {
+	if entry, found := data[keyPath]; found {
+		if rawMap, ok := entry.value.(map[string]any); ok {
+			nestedData := make(map[string]mergedEntry)
+			for k, v := range rawMap {
+				nestedData[k] = mergedEntry{value: v, sourceName: entry.sourceName, layer: entry.layer}
+			}
+			return nestedData, "", true
+		}
+	}
+
+	prefix := keyPath + "."
+	for key := range data {
+		if strings.HasPrefix(key, prefix) {
+			return data, keyPath, true
+		}
+	}
+
+	return nil, "", false
+}
+
+// fieldPathDepth returns the number of struct levels above the fields of the struct at fieldPath,
+// e.g. 0 for "" (the root) and 2 for "Database.Primary".
+func fieldPathDepth(fieldPath string) int {
+	if fieldPath == "" {
+		return 0
+	}
+	return strings.Count(fieldPath, ".") + 1
+}
+
+// isStructPointer reports whether t is *S for a struct type S other than time and Optional types.
+func isStructPointer(t reflect.Type) bool {
+	if t.Kind() != reflect.Ptr {
+		return false
+	}
+	elem := t.Elem()
+	return elem.Kind() == reflect.Struct && elem.PkgPath() != "time" && !isOptionalType(elem)
+}
+
+// isOptionalStruct reports whether t is Optional[S] for a struct type S other than time types.
+func isOptionalStruct(t reflect.Type) bool {
+	if !isOptionalType(t) {
+		return false
+	}
+	inner := t.Field(0).Type
+	return inner.Kind() == reflect.Struct && inner.PkgPath() != "time"
+}
+
 // determineKeyPath determines the configuration key path for a field.
 // Priority: name tag > prefix + derived > derived
 // All keys are normalized to lowercase for consistent matching.
@@ -588,6 +1444,21 @@ func deriveFieldKey(fieldName string) string {
 	return strings.ToLower(fieldName)
 }
 
+// isPromotedStruct reports whether an embedded (anonymous) struct field is flattened into its parent:
+// its fields use the parent's key prefix and field path, matching Go's field promotion.
+// An embedded struct with a prefix or name tag is treated like a regular nested struct.
+// Like in encoding/json, an unexported embedded struct is promoted too, so callers check
+// this before skipping unexported fields.
+func isPromotedStruct(field reflect.StructField, tagCfg tagConfig) bool {
+	if !field.Anonymous || field.Type.Kind() != reflect.Struct {
+		return false
+	}
+	if isOptionalType(field.Type) || field.Type.PkgPath() == "time" {
+		return false
+	}
+	return tagCfg.prefix == "" && tagCfg.name == ""
+}
+
 // isOptionalType checks if a type is an Optional[T] type.
 func isOptionalType(t reflect.Type) bool {
 	if t.Kind() != reflect.Struct {
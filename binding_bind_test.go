@@ -1,7 +1,9 @@
 package rigging
 
 import (
+	"context"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -19,7 +21,7 @@ func TestBindStruct_SimpleFields(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) > 0 {
 		t.Fatalf("unexpected errors: %v", errors)
@@ -51,7 +53,7 @@ func TestBindStruct_WithDefaults(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) > 0 {
 		t.Fatalf("unexpected errors: %v", errors)
@@ -87,7 +89,7 @@ func TestBindStruct_RequiredField(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	// Binding phase should not check for required fields - that's validation's job
 	// So we expect 0 errors from binding
@@ -117,7 +119,7 @@ func TestBindStruct_TypeConversionError(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) != 1 {
 		t.Fatalf("errors = %d, want 1", len(errors))
@@ -131,6 +133,120 @@ func TestBindStruct_TypeConversionError(t *testing.T) {
 	}
 }
 
+func TestBindStruct_TypeConversionErrorRedactsSecrets(t *testing.T) {
+	type Config struct {
+		PIN      int              `conf:"secret"`
+		Enabled  bool             `conf:"secret"`
+		Timeout  time.Duration    `conf:"secret"`
+		Optional Optional[uint16] `conf:"secret"`
+		Grouped  int              `conf:"secret,format:grouped"`
+		Size     int64            `conf:"secret,format:bytes"`
+		Port     int
+	}
+
+	tests := []struct {
+		name      string
+		key       string
+		value     any
+		wantValue bool     // Whether the raw value should appear in the message
+		leaked    []string // Normalized forms of the value that must not appear either
+	}{
+		{name: "secret int", key: "pin", value: "hunter2-pin"},
+		{name: "secret int with quotes", key: "pin", value: `s3"cr\et`},
+		{name: "secret bool normalizes input", key: "enabled", value: "  TopSecret  "},
+		{name: "secret duration", key: "timeout", value: "tok_abc123"},
+		{name: "secret optional", key: "optional", value: "tok_xyz789"},
+		{name: "secret int with underscores", key: "pin", value: "98_76x", leaked: []string{"9876x"}},
+		{name: "secret grouped", key: "grouped", value: "12,345,67a", leaked: []string{"1234567a"}},
+		{name: "secret byte size", key: "size", value: "10 hunter2", leaked: []string{"hunter2", "HUNTER2"}},
+		{name: "non-secret keeps value", key: "port", value: "not-a-number", wantValue: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLoader[Config]().
+				WithSource(&mockSource{data: map[string]any{tt.key: tt.value}}).
+				Load(context.Background())
+			if err == nil {
+				t.Fatal("expected conversion error")
+			}
+
+			raw := strings.TrimSpace(tt.value.(string))
+			contains := strings.Contains(err.Error(), raw) || strings.Contains(strings.ToLower(err.Error()), strings.ToLower(raw))
+			if contains != tt.wantValue {
+				t.Errorf("error contains raw value = %v, want %v: %v", contains, tt.wantValue, err)
+			}
+			if !tt.wantValue && !strings.Contains(err.Error(), "***redacted***") {
+				t.Errorf("expected redaction marker in error: %v", err)
+			}
+			for _, form := range tt.leaked {
+				if strings.Contains(err.Error(), form) {
+					t.Errorf("error contains %q: %v", form, err)
+				}
+			}
+		})
+	}
+}
+
+func TestBindStruct_SliceElementError(t *testing.T) {
+	type Config struct {
+		Backoffs []time.Duration
+		PINs     []int `conf:"secret"`
+	}
+
+	data := map[string]mergedEntry{
+		"backoffs": {value: "soon,2s,forever", sourceName: "env"},
+		"pins":     {value: []any{1234, "98x7"}, sourceName: "file"},
+	}
+
+	var cfg Config
+	errors := bindStruct(reflect.ValueOf(&cfg), data, nil, "", "", false, CollectAll)
+
+	if len(errors) != 3 {
+		t.Fatalf("errors = %+v, want 3", errors)
+	}
+	for _, fe := range errors {
+		if fe.Code != ErrCodeInvalidType {
+			t.Errorf("code = %q, want %q", fe.Code, ErrCodeInvalidType)
+		}
+	}
+	paths := []string{errors[0].FieldPath, errors[1].FieldPath, errors[2].FieldPath}
+	if !reflect.DeepEqual(paths, []string{"Backoffs[0]", "Backoffs[2]", "PINs[1]"}) {
+		t.Errorf("field paths = %v, want [Backoffs[0] Backoffs[2] PINs[1]]", paths)
+	}
+	if !strings.Contains(errors[0].Message, "soon") || !strings.Contains(errors[1].Message, "forever") {
+		t.Errorf("expected the malformed elements in the messages: %q, %q", errors[0].Message, errors[1].Message)
+	}
+	if strings.Contains(errors[2].Message, "98x7") {
+		t.Errorf("secret element leaked into the message: %q", errors[2].Message)
+	}
+}
+
+func TestBindStruct_FailFast(t *testing.T) {
+	type Config struct {
+		Port    int
+		Timeout time.Duration
+		Host    string
+	}
+
+	data := map[string]mergedEntry{
+		"port":    {value: "eighty", sourceName: "env"},
+		"timeout": {value: "soon", sourceName: "env"},
+		"host":    {value: "localhost", sourceName: "env"},
+	}
+
+	var cfg Config
+	var prov []FieldProvenance
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &prov, "", "", false, FailFast)
+
+	if len(errors) != 1 || errors[0].FieldPath != "Port" {
+		t.Fatalf("errors = %+v, want only Port", errors)
+	}
+	if cfg.Host != "" || len(prov) != 0 {
+		t.Errorf("fields after the first error were bound: %+v, %+v", cfg, prov)
+	}
+}
+
 func TestBindStruct_NestedStruct(t *testing.T) {
 	type Database struct {
 		Host string
@@ -147,7 +263,7 @@ func TestBindStruct_NestedStruct(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) > 0 {
 		t.Fatalf("unexpected errors: %v", errors)
@@ -186,7 +302,7 @@ func TestBindStruct_NestedStructWithPrefix(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) > 0 {
 		t.Fatalf("unexpected errors: %v", errors)
@@ -220,7 +336,7 @@ func TestBindStruct_CustomName(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) > 0 {
 		t.Fatalf("unexpected errors: %v", errors)
@@ -251,7 +367,7 @@ func TestBindStruct_SecretField(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) > 0 {
 		t.Fatalf("unexpected errors: %v", errors)
@@ -283,7 +399,7 @@ func TestBindStruct_OptionalField(t *testing.T) {
 
 		var cfg Config
 		var provFields []FieldProvenance
-		errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+		errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 		if len(errors) > 0 {
 			t.Fatalf("unexpected errors: %v", errors)
@@ -303,7 +419,7 @@ func TestBindStruct_OptionalField(t *testing.T) {
 
 		var cfg Config
 		var provFields []FieldProvenance
-		errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+		errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 		if len(errors) > 0 {
 			t.Fatalf("unexpected errors: %v", errors)
@@ -324,7 +440,7 @@ func TestBindStruct_OptionalField(t *testing.T) {
 
 		var cfg ConfigWithDefault
 		var provFields []FieldProvenance
-		errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+		errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 		if len(errors) > 0 {
 			t.Fatalf("unexpected errors: %v", errors)
@@ -354,7 +470,7 @@ func TestBindStruct_MultipleErrors(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	// Binding phase only checks type conversion errors, not required fields
 	// Should have 1 error: 1 type conversion (required checks are in validation phase)
@@ -412,7 +528,7 @@ func TestBindStruct_AllTypes(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) > 0 {
 		t.Fatalf("unexpected errors: %v", errors)
@@ -490,7 +606,7 @@ func TestBindStruct_NestedStructFromMap(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) > 0 {
 		t.Fatalf("unexpected errors: %v", errors)
@@ -513,3 +629,259 @@ func findProvenance(fields []FieldProvenance, fieldPath string) *FieldProvenance
 	}
 	return nil
 }
+
+func TestBindStruct_EmbeddedStruct(t *testing.T) {
+	type Base struct {
+		Port     int    `conf:"min:1"`
+		LogLevel string `conf:"default:info"`
+	}
+	type Database struct {
+		Host string
+	}
+	type Config struct {
+		Base
+		Database `conf:"prefix:db"`
+		Name     string
+	}
+
+	data := map[string]mergedEntry{
+		"port":    {value: "8080", sourceName: "env"},
+		"db.host": {value: "db.internal", sourceName: "file"},
+		"name":    {value: "api", sourceName: "env"},
+	}
+
+	var cfg Config
+	var provFields []FieldProvenance
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
+	if len(errors) > 0 {
+		t.Fatalf("unexpected errors: %v", errors)
+	}
+
+	if cfg.Port != 8080 {
+		t.Errorf("Port = %d, want 8080", cfg.Port)
+	}
+	if cfg.LogLevel != "info" {
+		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "info")
+	}
+	if cfg.Host != "db.internal" {
+		t.Errorf("Host = %q, want %q", cfg.Host, "db.internal")
+	}
+
+	tests := []struct {
+		fieldPath string
+		keyPath   string
+		source    string
+	}{
+		{fieldPath: "Port", keyPath: "port", source: "env"},
+		{fieldPath: "LogLevel", keyPath: "loglevel", source: "default"},
+		{fieldPath: "Database.Host", keyPath: "db.host", source: "file"},
+		{fieldPath: "Name", keyPath: "name", source: "env"},
+	}
+	for _, tt := range tests {
+		prov := findProvenance(provFields, tt.fieldPath)
+		if prov == nil {
+			t.Errorf("provenance for %s not found in %+v", tt.fieldPath, provFields)
+			continue
+		}
+		if prov.KeyPath != tt.keyPath || prov.SourceName != tt.source {
+			t.Errorf("provenance for %s = %+v, want key %q from %q", tt.fieldPath, prov, tt.keyPath, tt.source)
+		}
+	}
+}
+
+func TestBindStruct_OptionalStruct(t *testing.T) {
+	type TLS struct {
+		Cert    string
+		Key     string
+		MinVers string `conf:"default:1.2"`
+	}
+	type Config struct {
+		TLS Optional[TLS]
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]mergedEntry
+		wantSet bool
+		want    TLS
+		prov    map[string]string // Field path -> source
+	}{
+		{
+			name:    "no inner key present",
+			data:    map[string]mergedEntry{},
+			wantSet: false,
+			want:    TLS{},
+		},
+		{
+			name: "partial presence applies inner defaults",
+			data: map[string]mergedEntry{
+				"tls.cert": {value: "/etc/cert.pem", sourceName: "env"},
+			},
+			wantSet: true,
+			want:    TLS{Cert: "/etc/cert.pem", MinVers: "1.2"},
+			prov:    map[string]string{"TLS.Cert": "env", "TLS.MinVers": "default"},
+		},
+		{
+			name: "nested map value",
+			data: map[string]mergedEntry{
+				"tls": {value: map[string]any{"cert": "c", "key": "k"}, sourceName: "file"},
+			},
+			wantSet: true,
+			want:    TLS{Cert: "c", Key: "k", MinVers: "1.2"},
+			prov:    map[string]string{"TLS.Cert": "file", "TLS.Key": "file", "TLS.MinVers": "default"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			var provFields []FieldProvenance
+			errors := bindStruct(reflect.ValueOf(&cfg), tt.data, &provFields, "", "", false, CollectAll)
+			if len(errors) > 0 {
+				t.Fatalf("unexpected errors: %v", errors)
+			}
+
+			if cfg.TLS.Set != tt.wantSet {
+				t.Errorf("TLS.Set = %v, want %v", cfg.TLS.Set, tt.wantSet)
+			}
+			if cfg.TLS.Value != tt.want {
+				t.Errorf("TLS.Value = %+v, want %+v", cfg.TLS.Value, tt.want)
+			}
+
+			if len(provFields) != len(tt.prov) {
+				t.Errorf("provenance fields = %+v, want %d", provFields, len(tt.prov))
+			}
+			for fieldPath, source := range tt.prov {
+				p := findProvenance(provFields, fieldPath)
+				if p == nil || p.SourceName != source {
+					t.Errorf("provenance for %s = %+v, want source %q", fieldPath, p, source)
+				}
+			}
+		})
+	}
+}
+
+func TestBindStruct_StringNormalization(t *testing.T) {
+	type Config struct {
+		Trimmed string   `conf:"trim"`
+		Lower   string   `conf:"trim,lower"`
+		Upper   string   `conf:"upper"`
+		Title   string   `conf:"title"`
+		Regions []string `conf:"trim,upper"`
+		Default string   `conf:"default: Info ,trim,lower"`
+		Plain   string
+	}
+
+	regions := []any{" eu-west-1", "us-east-1 "}
+	data := map[string]mergedEntry{
+		"trimmed": {value: "  host  ", sourceName: "env"},
+		"lower":   {value: " DEBUG\n", sourceName: "env"},
+		"upper":   {value: "abc", sourceName: "env"},
+		"title":   {value: "hello WORLD  again", sourceName: "env"},
+		"regions": {value: regions, sourceName: "file"},
+		"plain":   {value: "  As Is ", sourceName: "env"},
+	}
+
+	var cfg Config
+	if errs := bindStruct(reflect.ValueOf(&cfg), data, nil, "", "", false, CollectAll); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	tests := []struct {
+		field string
+		got   any
+		want  any
+	}{
+		{"Trimmed", cfg.Trimmed, "host"},
+		{"Lower", cfg.Lower, "debug"},
+		{"Upper", cfg.Upper, "ABC"},
+		{"Title", cfg.Title, "Hello World  Again"},
+		{"Regions", cfg.Regions, []string{"EU-WEST-1", "US-EAST-1"}},
+		{"Default", cfg.Default, "info"},
+		{"Plain", cfg.Plain, "  As Is "},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.field, tt.got, tt.want)
+		}
+	}
+
+	if regions[0] != " eu-west-1" {
+		t.Errorf("source slice was modified: %q", regions)
+	}
+}
+
+func TestBindStruct_PointerStruct(t *testing.T) {
+	type Database struct {
+		Host string
+		Port int `conf:"default:5432"`
+	}
+	type Config struct {
+		Database *Database
+		Replica  *Database `conf:"prefix:replica"`
+		Timeout  *int
+	}
+
+	tests := []struct {
+		name        string
+		data        map[string]mergedEntry
+		wantDB      *Database
+		wantReplica *Database
+	}{
+		{
+			name: "no data leaves pointers nil",
+			data: map[string]mergedEntry{},
+		},
+		{
+			name: "flattened keys allocate the struct",
+			data: map[string]mergedEntry{
+				"database.host": {value: "db.internal", sourceName: "env"},
+			},
+			wantDB: &Database{Host: "db.internal", Port: 5432},
+		},
+		{
+			name: "nested map value",
+			data: map[string]mergedEntry{
+				"database": {value: map[string]any{"host": "a", "port": 1}, sourceName: "file"},
+			},
+			wantDB: &Database{Host: "a", Port: 1},
+		},
+		{
+			name: "prefix tag",
+			data: map[string]mergedEntry{
+				"replica.host": {value: "replica.internal", sourceName: "env"},
+			},
+			wantReplica: &Database{Host: "replica.internal", Port: 5432},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg Config
+			if errs := bindStruct(reflect.ValueOf(&cfg), tt.data, nil, "", "", true, CollectAll); len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+
+			if !reflect.DeepEqual(cfg.Database, tt.wantDB) {
+				t.Errorf("Database = %+v, want %+v", cfg.Database, tt.wantDB)
+			}
+			if !reflect.DeepEqual(cfg.Replica, tt.wantReplica) {
+				t.Errorf("Replica = %+v, want %+v", cfg.Replica, tt.wantReplica)
+			}
+			if cfg.Timeout != nil {
+				t.Errorf("Timeout = %v, want nil", *cfg.Timeout)
+			}
+		})
+	}
+
+	t.Run("leaves without pointerStructs", func(t *testing.T) {
+		var cfg Config
+		data := map[string]mergedEntry{"database.host": {value: "db.internal", sourceName: "env"}}
+		if errs := bindStruct(reflect.ValueOf(&cfg), data, nil, "", "", false, CollectAll); len(errs) > 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		if cfg.Database != nil {
+			t.Errorf("Database = %+v, want nil", cfg.Database)
+		}
+	})
+}
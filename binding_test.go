@@ -1,6 +1,9 @@
 package rigging
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"reflect"
 	"strings"
 	"testing"
@@ -599,6 +602,84 @@ func TestBinding_ParseTag(t *testing.T) {
 			expected: tagConfig{},
 		},
 
+		// List values that begin with a flag name
+		{
+			name: "oneof values prefixed by case directives",
+			tag:  "oneof:lowercase,uppercase,titlecase",
+			expected: tagConfig{
+				oneof: []string{"lowercase", "titlecase", "uppercase"},
+			},
+		},
+		{
+			name: "oneof value prefixed by trim",
+			tag:  "oneof:a,trimmed,b",
+			expected: tagConfig{
+				oneof: []string{"a", "b", "trimmed"},
+			},
+		},
+		{
+			name: "oneof values prefixed by deprecated, required and secret",
+			tag:  "oneof:deprecated_v1,requiredness,secretive,required",
+			expected: tagConfig{
+				oneof:    []string{"deprecated_v1", "requiredness", "secretive"},
+				required: true,
+			},
+		},
+		{
+			name: "oneof followed by a case directive",
+			tag:  "oneof:a,b,lower",
+			expected: tagConfig{
+				oneof:    []string{"a", "b"},
+				caseMode: "lower",
+			},
+		},
+
+		// String normalization directives
+		{
+			name: "trim directive",
+			tag:  "trim",
+			expected: tagConfig{
+				trim: true,
+			},
+		},
+		{
+			name: "lower directive",
+			tag:  "lower",
+			expected: tagConfig{
+				caseMode: "lower",
+			},
+		},
+		{
+			name: "upper directive",
+			tag:  "upper",
+			expected: tagConfig{
+				caseMode: "upper",
+			},
+		},
+		{
+			name: "title directive",
+			tag:  "title",
+			expected: tagConfig{
+				caseMode: "title",
+			},
+		},
+		{
+			name: "trim with case directive",
+			tag:  "env:REGION,trim,upper",
+			expected: tagConfig{
+				env:      "REGION",
+				trim:     true,
+				caseMode: "upper",
+			},
+		},
+		{
+			name: "last case directive wins",
+			tag:  "lower,title",
+			expected: tagConfig{
+				caseMode: "title",
+			},
+		},
+
 		// Edge cases
 		{
 			name: "duplicate directives - last one wins",
@@ -655,6 +736,12 @@ func TestBinding_ParseTag(t *testing.T) {
 			if result.secret != tt.expected.secret {
 				t.Errorf("secret: got %v, want %v", result.secret, tt.expected.secret)
 			}
+			if result.trim != tt.expected.trim {
+				t.Errorf("trim: got %v, want %v", result.trim, tt.expected.trim)
+			}
+			if result.caseMode != tt.expected.caseMode {
+				t.Errorf("caseMode: got %q, want %q", result.caseMode, tt.expected.caseMode)
+			}
 		})
 	}
 }
@@ -811,7 +898,14 @@ func TestBinding_ConvertValue(t *testing.T) {
 			rawValue:    "-1",
 			targetType:  reflect.TypeOf(uint(0)),
 			wantErr:     true,
-			errContains: "cannot convert",
+			errContains: `cannot convert "-1" to uint: value must be non-negative`,
+		},
+		{
+			name:        "negative int to uint",
+			rawValue:    -1,
+			targetType:  reflect.TypeOf(uint(0)),
+			wantErr:     true,
+			errContains: `cannot convert "-1" to uint: value must be non-negative`,
 		},
 
 		// Uint8 conversions
@@ -821,6 +915,27 @@ func TestBinding_ConvertValue(t *testing.T) {
 			targetType: reflect.TypeOf(uint8(0)),
 			want:       uint8(255),
 		},
+		{
+			name:        "negative int to uint8",
+			rawValue:    -1,
+			targetType:  reflect.TypeOf(uint8(0)),
+			wantErr:     true,
+			errContains: `cannot convert "-1" to uint8: value must be non-negative`,
+		},
+		{
+			name:        "negative float to uint8",
+			rawValue:    -2.5,
+			targetType:  reflect.TypeOf(uint8(0)),
+			wantErr:     true,
+			errContains: "value must be non-negative",
+		},
+		{
+			name:        "non-numeric string to uint8",
+			rawValue:    "-abc",
+			targetType:  reflect.TypeOf(uint8(0)),
+			wantErr:     true,
+			errContains: "invalid syntax",
+		},
 
 		// Uint16 conversions
 		{
@@ -1167,6 +1282,156 @@ func TestBinding_ParseStringSlice(t *testing.T) {
 	}
 }
 
+func TestBinding_ConvertSlice(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     any
+		target    reflect.Type
+		want      any
+		wantIndex int // Index of the failing element, -1 for success
+	}{
+		{
+			name:      "[]int from comma-separated string",
+			input:     "80, 443,8080",
+			target:    reflect.TypeOf([]int{}),
+			want:      []int{80, 443, 8080},
+			wantIndex: -1,
+		},
+		{
+			name:      "[]int from YAML array",
+			input:     []any{80, "443"},
+			target:    reflect.TypeOf([]int{}),
+			want:      []int{80, 443},
+			wantIndex: -1,
+		},
+		{
+			name:      "[]time.Duration from string",
+			input:     "1s,2s,4s",
+			target:    reflect.TypeOf([]time.Duration{}),
+			want:      []time.Duration{time.Second, 2 * time.Second, 4 * time.Second},
+			wantIndex: -1,
+		},
+		{
+			name:      "[]float64 from array",
+			input:     []any{0.5, 1},
+			target:    reflect.TypeOf([]float64{}),
+			want:      []float64{0.5, 1},
+			wantIndex: -1,
+		},
+		{
+			name:      "empty string",
+			input:     "",
+			target:    reflect.TypeOf([]int{}),
+			want:      []int{},
+			wantIndex: -1,
+		},
+		{
+			name:      "[]Optional[int] from array with nulls",
+			input:     []any{1, nil, 0},
+			target:    reflect.TypeOf([]Optional[int]{}),
+			want:      []Optional[int]{{Value: 1, Set: true}, {}, {Value: 0, Set: true}},
+			wantIndex: -1,
+		},
+		{
+			name:      "[]Optional[int] from string with empty parts",
+			input:     "1,,3",
+			target:    reflect.TypeOf([]Optional[int]{}),
+			want:      []Optional[int]{{Value: 1, Set: true}, {}, {Value: 3, Set: true}},
+			wantIndex: -1,
+		},
+		{
+			name:      "malformed Optional element",
+			input:     []any{1, "x"},
+			target:    reflect.TypeOf([]Optional[int]{}),
+			wantIndex: 1,
+		},
+		{
+			name:      "malformed element",
+			input:     "1s,soon,4s",
+			target:    reflect.TypeOf([]time.Duration{}),
+			wantIndex: 1,
+		},
+		{
+			name:      "out of range element",
+			input:     []any{1, 300},
+			target:    reflect.TypeOf([]uint8{}),
+			wantIndex: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertValue(tt.input, tt.target)
+			if tt.wantIndex >= 0 {
+				elemErr, ok := err.(*sliceElementError)
+				if !ok {
+					t.Fatalf("expected sliceElementError, got %v", err)
+				}
+				if elemErr.indexes[0] != tt.wantIndex {
+					t.Errorf("first index = %d, want %d", elemErr.indexes[0], tt.wantIndex)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertValue() unexpected error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("convertValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := convertValue("a", reflect.TypeOf([][]int{})); err == nil || !strings.Contains(err.Error(), "unsupported slice type") {
+		t.Errorf("expected unsupported slice type error, got %v", err)
+	}
+	if _, err := convertValue("a", reflect.TypeOf([]Optional[[]int]{})); err == nil || !strings.Contains(err.Error(), "unsupported slice type") {
+		t.Errorf("expected unsupported slice type error for Optional of a slice, got %v", err)
+	}
+}
+
+func TestBinding_OptionalCollections(t *testing.T) {
+	type Config struct {
+		Retries []Optional[int]
+		Labels  map[string]Optional[string]
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{
+			"retries":      []any{3, nil, 0},
+			"labels.team":  "core",
+			"labels.owner": nil,
+		}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	wantRetries := []Optional[int]{{Value: 3, Set: true}, {}, {Value: 0, Set: true}}
+	if !reflect.DeepEqual(cfg.Retries, wantRetries) {
+		t.Errorf("Retries = %+v, want %+v", cfg.Retries, wantRetries)
+	}
+	wantLabels := map[string]Optional[string]{"team": {Value: "core", Set: true}, "owner": {}}
+	if !reflect.DeepEqual(cfg.Labels, wantLabels) {
+		t.Errorf("Labels = %+v, want %+v", cfg.Labels, wantLabels)
+	}
+
+	// Dumps show set elements unwrapped and unset ones as null
+	var buf bytes.Buffer
+	if err := DumpEffective(&buf, cfg, AsJSON()); err != nil {
+		t.Fatalf("DumpEffective failed: %v", err)
+	}
+	var dumped map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &dumped); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if want := []any{3.0, nil, 0.0}; !reflect.DeepEqual(dumped["retries"], want) {
+		t.Errorf("dumped retries = %v, want %v", dumped["retries"], want)
+	}
+	if want := map[string]any{"team": "core", "owner": nil}; !reflect.DeepEqual(dumped["labels"], want) {
+		t.Errorf("dumped labels = %v, want %v", dumped["labels"], want)
+	}
+}
+
 func TestBinding_DetermineKeyPath(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1610,6 +1875,26 @@ func TestBinding_StartsWithDirective(t *testing.T) {
 			input:    "some env:TEST",
 			expected: false,
 		},
+		{
+			name:     "flag followed by a value",
+			input:    "required:true",
+			expected: true,
+		},
+		{
+			name:     "flag followed by another directive",
+			input:    "lower,required",
+			expected: true,
+		},
+		{
+			name:     "value starting with a flag name",
+			input:    "lowercase",
+			expected: false,
+		},
+		{
+			name:     "value starting with deprecated",
+			input:    "deprecated_v1",
+			expected: false,
+		},
 		{
 			name:     "only comma at the end",
 			input:    " ,",
@@ -1626,3 +1911,192 @@ func TestBinding_StartsWithDirective(t *testing.T) {
 		})
 	}
 }
+
+func TestBinding_ConvertFieldValue_Bytes(t *testing.T) {
+	tags := tagConfig{format: "bytes"}
+
+	tests := []struct {
+		name       string
+		rawValue   any
+		targetType reflect.Type
+		want       any
+		wantErr    string
+	}{
+		{name: "bare integer", rawValue: "2048", targetType: reflect.TypeOf(int64(0)), want: int64(2048)},
+		{name: "bytes unit", rawValue: "512B", targetType: reflect.TypeOf(int(0)), want: 512},
+		{name: "SI kilobytes", rawValue: "10KB", targetType: reflect.TypeOf(int64(0)), want: int64(10000)},
+		{name: "SI megabytes", rawValue: "10MB", targetType: reflect.TypeOf(uint64(0)), want: uint64(10000000)},
+		{name: "binary kibibytes", rawValue: "10KiB", targetType: reflect.TypeOf(int64(0)), want: int64(10240)},
+		{name: "binary gibibytes", rawValue: "2GiB", targetType: reflect.TypeOf(uint64(0)), want: uint64(2 << 30)},
+		{name: "case insensitive with space", rawValue: "1 mib", targetType: reflect.TypeOf(int(0)), want: 1 << 20},
+		{name: "fractional", rawValue: "1.5KiB", targetType: reflect.TypeOf(int(0)), want: 1536},
+		{name: "non-string passes through", rawValue: 4096, targetType: reflect.TypeOf(int64(0)), want: int64(4096)},
+		{name: "unknown unit", rawValue: "10XB", targetType: reflect.TypeOf(int64(0)), wantErr: `unknown unit "XB"`},
+		{name: "missing number", rawValue: "MB", targetType: reflect.TypeOf(int64(0)), wantErr: "cannot parse"},
+		{name: "overflow", rawValue: "100000PiB", targetType: reflect.TypeOf(uint64(0)), wantErr: "overflows"},
+		{name: "out of range for int8", rawValue: "1KB", targetType: reflect.TypeOf(int8(0)), wantErr: "out of range"},
+		{name: "non-integer field", rawValue: "1KB", targetType: reflect.TypeOf(""), wantErr: "requires an integer field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertFieldValue(tt.rawValue, tt.targetType, tags)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("convertFieldValue() expected error containing %q, got nil", tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("convertFieldValue() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertFieldValue() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("convertFieldValue() = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+
+	t.Run("Optional[int64]", func(t *testing.T) {
+		got, err := convertFieldValue("4KiB", reflect.TypeOf(Optional[int64]{}), tags)
+		if err != nil {
+			t.Fatalf("convertFieldValue() unexpected error = %v", err)
+		}
+		opt := got.(Optional[int64])
+		if !opt.Set || opt.Value != 4096 {
+			t.Errorf("convertFieldValue() = %+v, want {Value:4096 Set:true}", opt)
+		}
+	})
+}
+
+func TestBinding_ConvertFieldValue_Percent(t *testing.T) {
+	tags := tagConfig{format: "percent"}
+
+	tests := []struct {
+		name       string
+		rawValue   any
+		targetType reflect.Type
+		want       any
+		wantErr    string
+	}{
+		{name: "percentage", rawValue: "25%", targetType: reflect.TypeOf(float64(0)), want: 0.25},
+		{name: "percentage with spaces", rawValue: " 12.5 % ", targetType: reflect.TypeOf(float64(0)), want: 0.125},
+		{name: "bare ratio", rawValue: "0.25", targetType: reflect.TypeOf(float64(0)), want: 0.25},
+		{name: "float32 field", rawValue: "50%", targetType: reflect.TypeOf(float32(0)), want: float32(0.5)},
+		{name: "non-string passes through", rawValue: 0.75, targetType: reflect.TypeOf(float64(0)), want: 0.75},
+		{name: "invalid percentage", rawValue: "abc%", targetType: reflect.TypeOf(float64(0)), wantErr: "cannot parse \"abc%\" as percentage"},
+		{name: "non-float field", rawValue: "25%", targetType: reflect.TypeOf(int(0)), wantErr: "requires a float field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertFieldValue(tt.rawValue, tt.targetType, tags)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("convertFieldValue() expected error containing %q, got nil", tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("convertFieldValue() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertFieldValue() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("convertFieldValue() = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinding_ConvertValue_DigitSeparators(t *testing.T) {
+	tests := []struct {
+		name       string
+		rawValue   any
+		targetType reflect.Type
+		tags       tagConfig
+		want       any
+		wantErr    string
+	}{
+		{name: "underscores in int", rawValue: "1_000_000", targetType: reflect.TypeOf(int(0)), want: 1000000},
+		{name: "underscores in negative int64", rawValue: "-2_500", targetType: reflect.TypeOf(int64(0)), want: int64(-2500)},
+		{name: "underscores in uint32", rawValue: "4_294_967_295", targetType: reflect.TypeOf(uint32(0)), want: uint32(4294967295)},
+		{name: "plain number unchanged", rawValue: "1000", targetType: reflect.TypeOf(int(0)), want: 1000},
+		{name: "leading underscore", rawValue: "_1000", targetType: reflect.TypeOf(int(0)), wantErr: "invalid placement"},
+		{name: "trailing underscore", rawValue: "1000_", targetType: reflect.TypeOf(int(0)), wantErr: "invalid placement"},
+		{name: "doubled underscore", rawValue: "1__000", targetType: reflect.TypeOf(int(0)), wantErr: "invalid placement"},
+		{name: "underscore after sign", rawValue: "-_1", targetType: reflect.TypeOf(int(0)), wantErr: "invalid placement"},
+		{name: "commas rejected without format", rawValue: "1,000", targetType: reflect.TypeOf(int(0)), wantErr: "cannot convert"},
+		{name: "grouped commas", rawValue: "1,000,000", targetType: reflect.TypeOf(uint64(0)), tags: tagConfig{format: "grouped"}, want: uint64(1000000)},
+		{name: "grouped plain number", rawValue: "42", targetType: reflect.TypeOf(int(0)), tags: tagConfig{format: "grouped"}, want: 42},
+		{name: "grouped doubled comma", rawValue: "1,,000", targetType: reflect.TypeOf(int(0)), tags: tagConfig{format: "grouped"}, wantErr: "invalid placement"},
+		{name: "grouped trailing comma", rawValue: "1,000,", targetType: reflect.TypeOf(int(0)), tags: tagConfig{format: "grouped"}, wantErr: "invalid placement"},
+		{name: "grouped non-integer field", rawValue: "1,000", targetType: reflect.TypeOf(""), tags: tagConfig{format: "grouped"}, wantErr: "requires an integer field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertFieldValue(tt.rawValue, tt.targetType, tt.tags)
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("convertFieldValue() expected error containing %q, got %v", tt.wantErr, got)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("convertFieldValue() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("convertFieldValue() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("convertFieldValue() = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+// TestBinding_NegativeUnsigned verifies that negative values for unsigned fields
+// report the field path and that the value must be non-negative.
+func TestBinding_NegativeUnsigned(t *testing.T) {
+	type Config struct {
+		Port    uint16
+		Workers uint
+		Server  struct {
+			Retries uint8
+		}
+	}
+
+	_, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{
+			"port":           -1,
+			"workers":        "-1",
+			"server.retries": -1,
+		}}).
+		Load(context.Background())
+
+	valErr, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+
+	want := map[string]string{
+		"Port":           `type conversion failed: cannot convert "-1" to uint16: value must be non-negative`,
+		"Workers":        `type conversion failed: cannot convert "-1" to uint: value must be non-negative`,
+		"Server.Retries": `type conversion failed: cannot convert "-1" to uint8: value must be non-negative`,
+	}
+	if len(valErr.FieldErrors) != len(want) {
+		t.Fatalf("got %d errors, want %d: %v", len(valErr.FieldErrors), len(want), valErr)
+	}
+	for _, fe := range valErr.FieldErrors {
+		if fe.Code != ErrCodeInvalidType {
+			t.Errorf("%s: code = %q, want %q", fe.FieldPath, fe.Code, ErrCodeInvalidType)
+		}
+		if fe.Message != want[fe.FieldPath] {
+			t.Errorf("%s: message = %q, want %q", fe.FieldPath, fe.Message, want[fe.FieldPath])
+		}
+	}
+}
@@ -2,6 +2,7 @@ package rigging
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -95,7 +96,7 @@ func TestBindStruct_TimeTimeField(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) > 0 {
 		t.Fatalf("unexpected errors: %v", errors)
@@ -131,7 +132,7 @@ func TestBindStruct_TimeTimeInvalidFormat(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) == 0 {
 		t.Fatal("expected error for invalid time format")
@@ -142,6 +143,89 @@ func TestBindStruct_TimeTimeInvalidFormat(t *testing.T) {
 	}
 }
 
+// TestBindStruct_TimeTimeLayout tests the timeformat:<layout> directive.
+func TestBindStruct_TimeTimeLayout(t *testing.T) {
+	type Config struct {
+		Day      time.Time           `conf:"timeformat:02/01/2006"`
+		Stamp    time.Time           `conf:"timeformat:2006-01-02 15:04"`
+		Maybe    Optional[time.Time] `conf:"timeformat:02/01/2006"`
+		Fallback time.Time
+	}
+
+	data := map[string]mergedEntry{
+		"day":      {value: "31/12/2025", sourceName: "file"},
+		"stamp":    {value: "2025-12-31 23:59", sourceName: "file"},
+		"maybe":    {value: "01/02/2025", sourceName: "file"},
+		"fallback": {value: "2025-12-31", sourceName: "file"},
+	}
+
+	var cfg Config
+	var provFields []FieldProvenance
+	if errs := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if want := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC); !cfg.Day.Equal(want) {
+		t.Errorf("Day = %v, want %v", cfg.Day, want)
+	}
+	if want := time.Date(2025, 12, 31, 23, 59, 0, 0, time.UTC); !cfg.Stamp.Equal(want) {
+		t.Errorf("Stamp = %v, want %v", cfg.Stamp, want)
+	}
+	if got, ok := cfg.Maybe.Get(); !ok || !got.Equal(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Maybe = %v (set: %v), want 2025-02-01", got, ok)
+	}
+	if want := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC); !cfg.Fallback.Equal(want) {
+		t.Errorf("Fallback = %v, want %v", cfg.Fallback, want)
+	}
+}
+
+// TestBindStruct_TimeTimeLayoutInvalid tests errors for timeformat:<layout>.
+func TestBindStruct_TimeTimeLayoutInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  any
+		value   any
+		wantMsg string
+	}{
+		{
+			name: "value does not match layout",
+			config: &struct {
+				Field time.Time `conf:"timeformat:02/01/2006"`
+			}{},
+			value:   "2025-12-31", // Valid RFC3339 date, but no fallback to the default layouts
+			wantMsg: `cannot parse "2025-12-31" as time.Time (expected layout "02/01/2006")`,
+		},
+		{
+			name: "not a time field",
+			config: &struct {
+				Field string `conf:"timeformat:02/01/2006"`
+			}{},
+			value:   "31/12/2025",
+			wantMsg: "timeformat:02/01/2006 requires a time.Time field, got string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := map[string]mergedEntry{
+				"field": {value: tt.value, sourceName: "file"},
+			}
+
+			var provFields []FieldProvenance
+			errs := bindStruct(reflect.ValueOf(tt.config), data, &provFields, "", "", false, CollectAll)
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 error, got %v", errs)
+			}
+			if errs[0].Code != ErrCodeInvalidType {
+				t.Errorf("expected code %q, got %q", ErrCodeInvalidType, errs[0].Code)
+			}
+			if !strings.Contains(errs[0].Message, tt.wantMsg) {
+				t.Errorf("message = %q, want it to contain %q", errs[0].Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
 // TestBindStruct_TimeDurationAndTimeTime tests both time types together.
 func TestBindStruct_TimeDurationAndTimeTime(t *testing.T) {
 	type Config struct {
@@ -156,7 +240,7 @@ func TestBindStruct_TimeDurationAndTimeTime(t *testing.T) {
 
 	var cfg Config
 	var provFields []FieldProvenance
-	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "")
+	errors := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll)
 
 	if len(errors) > 0 {
 		t.Fatalf("unexpected errors: %v", errors)
@@ -216,3 +300,106 @@ func TestConvertValue_TimeDuration(t *testing.T) {
 		})
 	}
 }
+
+// TestBindStruct_TimeTimeUnix tests the timeformat:unix and timeformat:unixmilli directives.
+func TestBindStruct_TimeTimeUnix(t *testing.T) {
+	type Config struct {
+		Seconds time.Time           `conf:"timeformat:unix"`
+		Millis  time.Time           `conf:"timeformat:unixmilli"`
+		Maybe   Optional[time.Time] `conf:"timeformat:unix"`
+	}
+
+	want := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	wantMillis := want.Add(123 * time.Millisecond)
+
+	tests := []struct {
+		name    string
+		seconds any
+		millis  any
+	}{
+		{name: "integers", seconds: 1700000000, millis: int64(1700000000123)},
+		{name: "numeric strings", seconds: "1700000000", millis: "1700000000123"},
+		{name: "JSON numbers", seconds: float64(1700000000), millis: float64(1700000000123)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := map[string]mergedEntry{
+				"seconds": {value: tt.seconds, sourceName: "file"},
+				"millis":  {value: tt.millis, sourceName: "file"},
+				"maybe":   {value: tt.seconds, sourceName: "file"},
+			}
+
+			var cfg Config
+			var provFields []FieldProvenance
+			if errs := bindStruct(reflect.ValueOf(&cfg), data, &provFields, "", "", false, CollectAll); len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+
+			if !cfg.Seconds.Equal(want) || cfg.Seconds.Location() != time.UTC {
+				t.Errorf("Seconds = %v, want %v", cfg.Seconds, want)
+			}
+			if !cfg.Millis.Equal(wantMillis) {
+				t.Errorf("Millis = %v, want %v", cfg.Millis, wantMillis)
+			}
+			if got, ok := cfg.Maybe.Get(); !ok || !got.Equal(want) {
+				t.Errorf("Maybe = %v (set: %v), want %v", got, ok, want)
+			}
+		})
+	}
+}
+
+// TestBindStruct_TimeTimeUnixInvalid tests errors for timeformat:unix.
+func TestBindStruct_TimeTimeUnixInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  any
+		value   any
+		wantMsg string
+	}{
+		{
+			name: "non-numeric input",
+			config: &struct {
+				Field time.Time `conf:"timeformat:unix"`
+			}{},
+			value:   "2025-11-30T12:00:00Z",
+			wantMsg: `cannot parse 2025-11-30T12:00:00Z as a Unix timestamp (timeformat:unix expects an integer)`,
+		},
+		{
+			name: "fractional number",
+			config: &struct {
+				Field time.Time `conf:"timeformat:unix"`
+			}{},
+			value:   1700000000.5,
+			wantMsg: `cannot parse 1.7000000005e+09 as a Unix timestamp (timeformat:unix expects an integer)`,
+		},
+		{
+			name: "not a time field",
+			config: &struct {
+				Field int64 `conf:"timeformat:unix"`
+			}{},
+			value:   1700000000,
+			wantMsg: "timeformat:unix requires a time.Time field, got int64",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := map[string]mergedEntry{
+				"field": {value: tt.value, sourceName: "file"},
+			}
+
+			var provFields []FieldProvenance
+			errs := bindStruct(reflect.ValueOf(tt.config), data, &provFields, "", "", false, CollectAll)
+			if len(errs) != 1 {
+				t.Fatalf("expected 1 error, got %v", errs)
+			}
+			if errs[0].Code != ErrCodeInvalidType {
+				t.Errorf("expected code %q, got %q", ErrCodeInvalidType, errs[0].Code)
+			}
+			if !strings.Contains(errs[0].Message, tt.wantMsg) {
+				t.Errorf("message = %q, want it to contain %q", errs[0].Message, tt.wantMsg)
+			}
+		})
+	}
+}
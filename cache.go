@@ -0,0 +1,174 @@
+package rigging
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type cacheSource struct {
+	inner Source
+	ttl   time.Duration
+	now   func() time.Time // Clock, overridable in tests
+
+	mu       sync.Mutex
+	loaded   loadedSource
+	loadedAt time.Time
+	cached   bool
+}
+
+// CacheSource wraps a source so that its last successful Load result is reused for ttl.
+// After the TTL expires the next Load refreshes from the inner source; if the refresh fails,
+// the stale value keeps being served until a later attempt succeeds.
+// Callers receive deep copies of the cached map, including nested maps and slices. Original
+// keys, positions, and secret keys reported by the inner source are cached with the data.
+// Loads are serialized: a refresh holds the cache lock while the inner source loads, so a slow
+// inner source also delays concurrent callers that would otherwise get the cached or stale value.
+// Watch and Name are forwarded to the inner source.
+func CacheSource(inner Source, ttl time.Duration) Source {
+	return &cacheSource{inner: inner, ttl: ttl, now: time.Now}
+}
+
+// Load returns the cached data, refreshing it from the inner source once the TTL has expired.
+func (c *cacheSource) Load(ctx context.Context) (map[string]any, error) {
+	loaded, err := c.loadAll(ctx)
+	return loaded.data, err
+}
+
+// LoadWithKeys returns the cached data and original keys, refreshing them once the TTL has expired.
+func (c *cacheSource) LoadWithKeys(ctx context.Context) (map[string]any, map[string]string, error) {
+	loaded, err := c.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, err
+}
+
+// LoadWithPositions returns the cached data, original keys, and lines, refreshing them once the TTL has expired.
+func (c *cacheSource) LoadWithPositions(ctx context.Context) (map[string]any, map[string]string, map[string]int, error) {
+	loaded, err := c.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, loaded.lines, err
+}
+
+// LoadWithSecrets returns the cached data, original keys, and secret keys, refreshing them once the TTL has expired.
+func (c *cacheSource) LoadWithSecrets(ctx context.Context) (map[string]any, map[string]string, map[string]bool, error) {
+	loaded, err := c.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, loaded.secrets, err
+}
+
+// loadAll returns a copy of the cached result, refreshing it once the TTL has expired.
+func (c *cacheSource) loadAll(ctx context.Context) (loadedSource, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cached && c.now().Sub(c.loadedAt) < c.ttl {
+		return copyLoaded(c.loaded), nil
+	}
+
+	loaded, err := loadFrom(ctx, c.inner)
+	if err != nil {
+		// Keep serving the stale value; the next call tries again
+		if c.cached {
+			return copyLoaded(c.loaded), nil
+		}
+		return loadedSource{}, err
+	}
+
+	c.loaded = copyLoaded(loaded)
+	c.loadedAt = c.now()
+	c.cached = true
+
+	return copyLoaded(c.loaded), nil
+}
+
+// Watch forwards to the inner source.
+func (c *cacheSource) Watch(ctx context.Context) (<-chan ChangeEvent, error) {
+	return c.inner.Watch(ctx)
+}
+
+// Name forwards to the inner source.
+func (c *cacheSource) Name() string {
+	return c.inner.Name()
+}
+
+// copyLoaded returns a copy of a loaded source result.
+func copyLoaded(loaded loadedSource) loadedSource {
+	return loadedSource{
+		data:         copyData(loaded.data),
+		originalKeys: copyKeys(loaded.originalKeys),
+		lines:        copyLines(loaded.lines),
+		secrets:      copySecrets(loaded.secrets),
+	}
+}
+
+// copyData returns a deep copy of a source data map. Nested maps and slices
+// (e.g. YAML objects and arrays) are copied too; other values are shared.
+func copyData(data map[string]any) map[string]any {
+	if data == nil {
+		return nil
+	}
+	result := make(map[string]any, len(data))
+	for k, v := range data {
+		result[k] = copyValue(v)
+	}
+	return result
+}
+
+// copyValue returns a deep copy of v if it is a map or slice, and v otherwise.
+func copyValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		return copyData(val)
+	case []any:
+		if val == nil {
+			return val
+		}
+		result := make([]any, len(val))
+		for i, elem := range val {
+			result[i] = copyValue(elem)
+		}
+		return result
+	case []string:
+		if val == nil {
+			return val
+		}
+		result := make([]string, len(val))
+		copy(result, val)
+		return result
+	default:
+		return v
+	}
+}
+
+// copyKeys returns a copy of an original keys map.
+func copyKeys(keys map[string]string) map[string]string {
+	if keys == nil {
+		return nil
+	}
+	result := make(map[string]string, len(keys))
+	for k, v := range keys {
+		result[k] = v
+	}
+	return result
+}
+
+// copyLines returns a copy of a key -> line map.
+func copyLines(lines map[string]int) map[string]int {
+	if lines == nil {
+		return nil
+	}
+	result := make(map[string]int, len(lines))
+	for k, v := range lines {
+		result[k] = v
+	}
+	return result
+}
+
+// copySecrets returns a copy of a secret keys map.
+func copySecrets(secrets map[string]bool) map[string]bool {
+	if secrets == nil {
+		return nil
+	}
+	result := make(map[string]bool, len(secrets))
+	for k, v := range secrets {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,162 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingSource is a test helper that counts Load calls and can be made to fail.
+type countingSource struct {
+	mu    sync.Mutex
+	calls int
+	value string
+	err   error
+}
+
+func (c *countingSource) Load(ctx context.Context) (map[string]any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return map[string]any{"host": c.value}, nil
+}
+
+func (c *countingSource) Watch(ctx context.Context) (<-chan ChangeEvent, error) {
+	return nil, ErrWatchNotSupported
+}
+
+func (c *countingSource) Name() string {
+	return "counting"
+}
+
+func TestCacheSource_ServesCachedValueWithinTTL(t *testing.T) {
+	inner := &countingSource{value: "a"}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	src := CacheSource(inner, time.Minute).(*cacheSource)
+	src.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if _, err := src.Load(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inner.value = "b"
+	now = now.Add(30 * time.Second)
+	data, _ := src.Load(ctx)
+	if data["host"] != "a" || inner.calls != 1 {
+		t.Errorf("expected cached value a with 1 call, got %v with %d calls", data["host"], inner.calls)
+	}
+
+	now = now.Add(time.Minute)
+	data, _ = src.Load(ctx)
+	if data["host"] != "b" || inner.calls != 2 {
+		t.Errorf("expected refreshed value b with 2 calls, got %v with %d calls", data["host"], inner.calls)
+	}
+}
+
+func TestCacheSource_StaleOnRefreshFailure(t *testing.T) {
+	inner := &countingSource{value: "a"}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	src := CacheSource(inner, time.Minute).(*cacheSource)
+	src.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if _, err := src.Load(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inner.err = errors.New("backend down")
+	now = now.Add(2 * time.Minute)
+	data, err := src.Load(ctx)
+	if err != nil || data["host"] != "a" {
+		t.Fatalf("expected stale value a, got %v (err %v)", data["host"], err)
+	}
+
+	// The failed refresh did not extend the TTL, so the next call retries
+	inner.err = nil
+	inner.value = "c"
+	data, _ = src.Load(ctx)
+	if data["host"] != "c" || inner.calls != 3 {
+		t.Errorf("expected refreshed value c with 3 calls, got %v with %d calls", data["host"], inner.calls)
+	}
+}
+
+func TestCacheSource_FirstLoadFailure(t *testing.T) {
+	wantErr := errors.New("backend down")
+	src := CacheSource(&countingSource{err: wantErr}, time.Minute)
+
+	if _, err := src.Load(context.Background()); !errors.Is(err, wantErr) {
+		t.Errorf("expected %v, got %v", wantErr, err)
+	}
+}
+
+func TestCacheSource_ReturnsCopies(t *testing.T) {
+	src := CacheSource(&countingSource{value: "a"}, time.Minute)
+	ctx := context.Background()
+
+	data, _ := src.Load(ctx)
+	data["host"] = "mutated"
+
+	data, _ = src.Load(ctx)
+	if data["host"] != "a" {
+		t.Errorf("cached map was mutated through a returned copy: %v", data["host"])
+	}
+}
+
+func TestCacheSource_ReturnsDeepCopies(t *testing.T) {
+	inner := &mutableSource{data: map[string]any{
+		"hosts":    []any{"a", "b"},
+		"database": map[string]any{"replicas": []any{map[string]any{"host": "r1"}}},
+	}}
+	src := CacheSource(inner, time.Minute)
+	ctx := context.Background()
+
+	data, _ := src.Load(ctx)
+	data["hosts"].([]any)[0] = "mutated"
+	replicas := data["database"].(map[string]any)["replicas"].([]any)
+	replicas[0].(map[string]any)["host"] = "mutated"
+
+	data, _ = src.Load(ctx)
+	if got := data["hosts"].([]any)[0]; got != "a" {
+		t.Errorf("cached slice was mutated through a returned copy: %v", got)
+	}
+	replicas = data["database"].(map[string]any)["replicas"].([]any)
+	if got := replicas[0].(map[string]any)["host"]; got != "r1" {
+		t.Errorf("cached nested map was mutated through a returned copy: %v", got)
+	}
+}
+
+func TestCacheSource_ConcurrentLoad(t *testing.T) {
+	inner := &countingSource{value: "a"}
+	src := CacheSource(inner, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := src.Load(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if inner.calls != 1 {
+		t.Errorf("expected 1 inner call, got %d", inner.calls)
+	}
+	if src.Name() != "counting" {
+		t.Errorf("Name() = %q, want %q", src.Name(), "counting")
+	}
+}
+
+func TestCacheSource_ForwardsPositionsAndSecrets(t *testing.T) {
+	testWrapperForwards(t, func(inner Source) Source {
+		return CacheSource(inner, time.Minute)
+	})
+}
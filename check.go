@@ -0,0 +1,71 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Check loads and validates configuration and writes a human-readable report to w,
+// for a "config check" subcommand. On success, it writes the effective configuration
+// with sources (secrets redacted, see DumpEffective) and returns nil. On validation
+// failure, it writes every field error grouped by field path and returns the
+// *ValidationError; other load errors are written and returned as is.
+// Callers typically exit non-zero when Check returns an error.
+func Check[T any](ctx context.Context, loader *Loader[T], w io.Writer) error {
+	cfg, err := loader.Load(ctx)
+	if err != nil {
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			if _, werr := fmt.Fprintf(w, "configuration check failed: %v\n", err); werr != nil {
+				return fmt.Errorf("write error: %w", werr)
+			}
+			return err
+		}
+		if werr := writeCheckErrors(w, valErr.FieldErrors); werr != nil {
+			return werr
+		}
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w, "configuration valid"); err != nil {
+		return fmt.Errorf("write error: %w", err)
+	}
+	return DumpEffective(w, cfg, WithSources())
+}
+
+// writeCheckErrors writes field errors grouped by field path, in field path order.
+// Errors of the same field keep their reported order.
+func writeCheckErrors(w io.Writer, fieldErrors []FieldError) error {
+	byPath := make(map[string][]FieldError)
+	var paths []string
+	for _, fe := range fieldErrors {
+		if _, ok := byPath[fe.FieldPath]; !ok {
+			paths = append(paths, fe.FieldPath)
+		}
+		byPath[fe.FieldPath] = append(byPath[fe.FieldPath], fe)
+	}
+	sort.Strings(paths)
+
+	noun := "errors"
+	if len(fieldErrors) == 1 {
+		noun = "error"
+	}
+	if _, err := fmt.Fprintf(w, "configuration invalid: %d %s\n", len(fieldErrors), noun); err != nil {
+		return fmt.Errorf("write error: %w", err)
+	}
+
+	for _, path := range paths {
+		if _, err := fmt.Fprintf(w, "  %s\n", path); err != nil {
+			return fmt.Errorf("write error: %w", err)
+		}
+		for _, fe := range byPath[path] {
+			if _, err := fmt.Fprintf(w, "    %s: %s\n", fe.Code, fe.Message); err != nil {
+				return fmt.Errorf("write error: %w", err)
+			}
+		}
+	}
+	return nil
+}
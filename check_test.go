@@ -0,0 +1,66 @@
+package rigging
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	type Config struct {
+		Host     string `conf:"required"`
+		Port     int    `conf:"min:1024"`
+		Password string `conf:"secret"`
+		Env      string `conf:"oneof:prod,dev"`
+	}
+
+	tests := []struct {
+		name    string
+		source  Source
+		wantErr bool
+		want    string
+	}{
+		{
+			name:   "valid config",
+			source: &mockSource{name: "env", data: map[string]any{"host": "localhost", "port": 8080, "password": "hunter2", "env": "dev"}},
+			want: "configuration valid\n" +
+				"host: \"localhost\" (source: env)\n" +
+				"port: 8080 (source: env)\n" +
+				"password: ***redacted*** (source: env)\n" +
+				"env: \"dev\" (source: env)\n",
+		},
+		{
+			name:    "validation errors grouped by field",
+			source:  &mockSource{data: map[string]any{"port": "80", "env": "staging"}},
+			wantErr: true,
+			want: "configuration invalid: 3 errors\n" +
+				"  Env\n" +
+				"    oneof: value \"staging\" must be one of: dev, prod\n" +
+				"  Host\n" +
+				"    required: field is required but not provided\n" +
+				"  Port\n" +
+				"    min: value 80 is below minimum 1024\n",
+		},
+		{
+			name:    "source error",
+			source:  &mockSource{name: "vault", err: errors.New("connection refused")},
+			wantErr: true,
+			want:    "configuration check failed: load source vault: connection refused\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := Check(context.Background(), NewLoader[Config]().WithSource(tt.source), &buf)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package rigging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// Fingerprint returns a stable hex-encoded SHA-256 of the effective values of cfg, a struct or
+// a pointer to one, e.g. to tag deployments and detect drift. Keys are canonically ordered and
+// only values count: configs with the same values have the same fingerprint regardless of which
+// sources supplied them. Secret values are included but cannot be recovered from the result.
+// It matches Loader.ConfigHash for the same config.
+func Fingerprint(cfg any) (string, error) {
+	v := reflect.ValueOf(cfg)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", ErrNilConfig
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", ErrNilConfig
+	}
+	if v.Kind() != reflect.Struct {
+		return "", fmt.Errorf("rigging: Fingerprint requires a struct, got %s", v.Type())
+	}
+	return configHash(v)
+}
+
+// configHash returns the hex-encoded SHA-256 of the canonical flattened config.
+// Secret values are included, so rotating a secret changes the hash, but they
+// cannot be recovered from it. The result is deterministic across runs and platforms.
+// NaN and infinite floats, which JSON cannot represent, are hashed as "NaN", "+Inf", and "-Inf".
+func configHash(v reflect.Value) (string, error) {
+	flat := flattenValues(v)
+	for key, value := range flat {
+		flat[key] = finiteValue(value)
+	}
+
+	data, err := canonicalJSON(flat)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// finiteValue returns v with NaN and infinite floats replaced by their string form,
+// including the elements of slices, arrays, and string-keyed maps. Values without
+// such floats are returned unchanged, so they encode exactly as before.
+func finiteValue(v any) any {
+	result, _ := replaceNonFinite(v)
+	return result
+}
+
+// replaceNonFinite implements finiteValue and reports whether anything was replaced.
+func replaceNonFinite(v any) (any, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return strconv.FormatFloat(f, 'g', -1, 64), true
+		}
+	case reflect.Slice, reflect.Array:
+		result := make([]any, rv.Len())
+		changed := false
+		for i := range result {
+			var elemChanged bool
+			result[i], elemChanged = replaceNonFinite(rv.Index(i).Interface())
+			changed = changed || elemChanged
+		}
+		if changed {
+			return result, true
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return v, false
+		}
+		result := make(map[string]any, rv.Len())
+		changed := false
+		iter := rv.MapRange()
+		for iter.Next() {
+			var elemChanged bool
+			result[iter.Key().String()], elemChanged = replaceNonFinite(iter.Value().Interface())
+			changed = changed || elemChanged
+		}
+		if changed {
+			return result, true
+		}
+	}
+	return v, false
+}
@@ -0,0 +1,162 @@
+package rigging
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLoader_ConfigHash(t *testing.T) {
+	type Config struct {
+		Host     string
+		Port     int
+		Password string `conf:"secret"`
+	}
+
+	load := func(data map[string]any) *Loader[Config] {
+		t.Helper()
+		loader := NewLoader[Config]().WithSource(&mockSource{data: data})
+		if _, err := loader.Load(context.Background()); err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		return loader
+	}
+
+	if got := NewLoader[Config]().ConfigHash(); got != "" {
+		t.Errorf("ConfigHash() before Load = %q, want empty", got)
+	}
+
+	base := map[string]any{"host": "localhost", "port": "8080", "password": "hunter2"}
+	hash := load(base).ConfigHash()
+	if len(hash) != 64 {
+		t.Fatalf("ConfigHash() = %q, want 64 hex characters", hash)
+	}
+
+	tests := []struct {
+		name     string
+		data     map[string]any
+		wantSame bool
+	}{
+		{name: "identical config", data: map[string]any{"host": "localhost", "port": "8080", "password": "hunter2"}, wantSame: true},
+		{name: "same value from a different type", data: map[string]any{"host": "localhost", "port": 8080, "password": "hunter2"}, wantSame: true},
+		{name: "changed value", data: map[string]any{"host": "db.internal", "port": "8080", "password": "hunter2"}, wantSame: false},
+		{name: "rotated secret", data: map[string]any{"host": "localhost", "port": "8080", "password": "hunter3"}, wantSame: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := load(tt.data).ConfigHash()
+			if (got == hash) != tt.wantSame {
+				t.Errorf("ConfigHash() = %q, base %q, want same = %v", got, hash, tt.wantSame)
+			}
+			if strings.Contains(got, "hunter") {
+				t.Error("hash must not contain the secret")
+			}
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	type Config struct {
+		Host     string
+		Port     int
+		Password string `conf:"secret"`
+	}
+
+	load := func(sources ...Source) *Config {
+		t.Helper()
+		loader := NewLoader[Config]()
+		for _, source := range sources {
+			loader.WithSource(source)
+		}
+		cfg, err := loader.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		return cfg
+	}
+
+	file := &mockSource{name: "file", data: map[string]any{"host": "localhost", "port": 8080}}
+	env := &mockSource{name: "env", data: map[string]any{"port": "8080", "password": "hunter2"}}
+
+	cfg := load(file, env)
+	fingerprint, err := Fingerprint(cfg)
+	if err != nil {
+		t.Fatalf("Fingerprint() unexpected error: %v", err)
+	}
+	if len(fingerprint) != 64 || strings.Contains(fingerprint, "hunter") {
+		t.Fatalf("Fingerprint() = %q, want 64 hex characters without the secret", fingerprint)
+	}
+
+	reordered, err := Fingerprint(load(env, file))
+	if err != nil {
+		t.Fatalf("Fingerprint() unexpected error: %v", err)
+	}
+	if reordered != fingerprint {
+		t.Errorf("Fingerprint() with reordered sources = %q, want %q", reordered, fingerprint)
+	}
+
+	if byValue, _ := Fingerprint(*cfg); byValue != fingerprint {
+		t.Errorf("Fingerprint() of the struct value = %q, want %q", byValue, fingerprint)
+	}
+
+	loader := NewLoader[Config]().WithSource(file).WithSource(env)
+	if _, err := loader.Load(context.Background()); err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if loader.ConfigHash() != fingerprint {
+		t.Errorf("ConfigHash() = %q, want it to match Fingerprint() %q", loader.ConfigHash(), fingerprint)
+	}
+
+	rotated := *cfg
+	rotated.Password = "hunter3"
+	if changed, _ := Fingerprint(&rotated); changed == fingerprint {
+		t.Error("Fingerprint() unchanged after rotating the secret")
+	}
+}
+
+func TestFingerprint_Errors(t *testing.T) {
+	var nilCfg *struct{ Host string }
+	tests := []struct {
+		name string
+		cfg  any
+	}{
+		{name: "nil", cfg: nil},
+		{name: "nil pointer", cfg: nilCfg},
+		{name: "not a struct", cfg: map[string]any{"host": "localhost"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Fingerprint(tt.cfg); err == nil {
+				t.Errorf("Fingerprint(%v) expected error", tt.cfg)
+			}
+		})
+	}
+}
+
+func TestLoader_ConfigHashNonFiniteFloats(t *testing.T) {
+	type Config struct {
+		Ratio   float64
+		Limit   float32
+		Weights []float64
+	}
+
+	hashOf := func(data map[string]any) string {
+		t.Helper()
+		loader := NewLoader[Config]().WithSource(&mockSource{data: data})
+		if _, err := loader.Load(context.Background()); err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		return loader.ConfigHash()
+	}
+
+	nan := hashOf(map[string]any{"ratio": math.NaN(), "limit": math.Inf(1), "weights": []any{1.0, math.Inf(-1)}})
+	if len(nan) != 64 {
+		t.Fatalf("ConfigHash() = %q, want 64 hex characters", nan)
+	}
+	if other := hashOf(map[string]any{"ratio": math.Inf(1), "limit": math.Inf(1), "weights": []any{1.0, math.Inf(-1)}}); other == nan {
+		t.Error("NaN and +Inf should hash differently")
+	}
+}
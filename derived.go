@@ -0,0 +1,90 @@
+package rigging
+
+import (
+	"context"
+	"strings"
+)
+
+// derivedSourceName is the provenance source name of keys computed by a DerivedSource.
+const derivedSourceName = "derived"
+
+type derivedSource struct {
+	fn func(existing map[string]any) map[string]any
+}
+
+// DerivedSource creates a source that computes keys from the values of the other layers,
+// e.g. assembling "database.dsn" from "database.host" and "database.port".
+//
+// The loader calls fn after merging everything else (WithDefaults, WithBaseConfig, all other
+// sources regardless of their position, context overrides, and secret files), passing the
+// merged values keyed by lowercase key path; tag defaults are not included. Derived keys only
+// fill gaps: they replace WithDefaults and WithBaseConfig values but never a key set by a
+// source or context override, unless the source is added with WithDerivedOverride.
+// Several derived sources run in precedence order, each seeing the keys derived before it.
+// Provenance records derived keys with source "derived".
+//
+// Load on its own derives from an empty view. Watch returns ErrWatchNotSupported.
+func DerivedSource(fn func(existing map[string]any) map[string]any) Source {
+	return &derivedSource{fn: fn}
+}
+
+// WithDerivedOverride lets a DerivedSource replace keys set by sources and context overrides.
+// It has no effect on other sources.
+func WithDerivedOverride() SourceOption {
+	return func(cfg *sourceConfig) {
+		cfg.override = true
+	}
+}
+
+// Load derives keys from an empty view of the configuration.
+func (d *derivedSource) Load(ctx context.Context) (map[string]any, error) {
+	derived := d.fn(map[string]any{})
+	if derived == nil {
+		derived = map[string]any{}
+	}
+	return derived, nil
+}
+
+// Watch returns ErrWatchNotSupported.
+func (d *derivedSource) Watch(ctx context.Context) (<-chan ChangeEvent, error) {
+	return nil, ErrWatchNotSupported
+}
+
+// Name returns "derived".
+func (d *derivedSource) Name() string {
+	return derivedSourceName
+}
+
+// applyDerived runs the derived sources over the merged data, adding the keys they compute.
+func (l *Loader[T]) applyDerived(ctx context.Context, mergedData map[string]mergedEntry, strictKeys map[string]bool, trace *mergeTrace) {
+	for i, source := range l.sources {
+		derived, ok := source.(*derivedSource)
+		if !ok {
+			continue
+		}
+
+		existing := make(map[string]any, len(mergedData))
+		for key, entry := range mergedData {
+			existing[key] = entry.value
+		}
+
+		for key, value := range derived.fn(existing) {
+			key = strings.ToLower(key)
+			if previous, ok := mergedData[key]; ok {
+				if !isDefaultSource(previous.sourceName) && !l.sourceOpts[i].override {
+					continue
+				}
+				l.logDebug(ctx, "key overridden", "key", key, "previous", previous.sourceName, "source", derivedSourceName)
+			}
+
+			mergedData[key] = mergedEntry{
+				value:      value,
+				sourceName: derivedSourceName,
+				sourceKey:  derivedSourceName,
+				layer:      l.sourceOpts[i].tag,
+			}
+			trace.offer(key, mergedData[key])
+			strictKeys[key] = true
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package rigging
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestDerivedSource(t *testing.T) {
+	type Database struct {
+		Host string
+		Port int
+		Name string
+		DSN  string
+	}
+	type Config struct {
+		Database Database
+	}
+
+	dsn := DerivedSource(func(existing map[string]any) map[string]any {
+		return map[string]any{
+			"Database.DSN": fmt.Sprintf("postgres://%v:%v/%v", existing["database.host"], existing["database.port"], existing["database.name"]),
+		}
+	})
+
+	tests := []struct {
+		name       string
+		loader     func() *Loader[Config]
+		wantDSN    string
+		wantSource string
+	}{
+		{
+			name: "fills missing key from all sources",
+			loader: func() *Loader[Config] {
+				// Registered first, but still sees the sources added after it
+				return NewLoader[Config]().
+					WithSource(dsn).
+					WithDefaults(map[string]any{"database.dsn": "sqlite://memory", "database.port": 5432}).
+					WithSource(&mockSource{data: map[string]any{"database.host": "db", "database.name": "app"}})
+			},
+			wantDSN:    "postgres://db:5432/app",
+			wantSource: "derived",
+		},
+		{
+			name: "explicit key wins",
+			loader: func() *Loader[Config] {
+				return NewLoader[Config]().
+					WithSource(&mockSource{data: map[string]any{"database.host": "db", "database.dsn": "postgres://explicit"}}).
+					WithSource(dsn)
+			},
+			wantDSN:    "postgres://explicit",
+			wantSource: "mock",
+		},
+		{
+			name: "override option",
+			loader: func() *Loader[Config] {
+				return NewLoader[Config]().
+					WithSource(&mockSource{data: map[string]any{
+						"database.host": "db",
+						"database.port": 5432,
+						"database.name": "app",
+						"database.dsn":  "postgres://explicit",
+					}}).
+					WithSource(dsn, WithDerivedOverride())
+			},
+			wantDSN:    "postgres://db:5432/app",
+			wantSource: "derived",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := tt.loader().Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Database.DSN != tt.wantDSN {
+				t.Errorf("DSN = %q, want %q", cfg.Database.DSN, tt.wantDSN)
+			}
+
+			prov, _ := GetProvenance(cfg)
+			field := findProvenance(prov.Fields, "Database.DSN")
+			if field == nil || field.SourceName != tt.wantSource {
+				t.Errorf("DSN provenance = %+v, want source %q", field, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestDerivedSource_Chained(t *testing.T) {
+	type Config struct {
+		Host string
+		URL  string
+		Ping string
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{"host": "example.com"}}).
+		WithSource(DerivedSource(func(existing map[string]any) map[string]any {
+			return map[string]any{"url": fmt.Sprintf("https://%v", existing["host"])}
+		})).
+		WithSource(DerivedSource(func(existing map[string]any) map[string]any {
+			return map[string]any{"ping": fmt.Sprintf("%v/ping", existing["url"])}
+		})).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Ping != "https://example.com/ping" {
+		t.Errorf("Ping = %q, want %q", cfg.Ping, "https://example.com/ping")
+	}
+}
+
+func TestDerivedSource_Standalone(t *testing.T) {
+	source := DerivedSource(func(existing map[string]any) map[string]any {
+		return map[string]any{"keys": len(existing)}
+	})
+
+	data, err := source.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if data["keys"] != 0 {
+		t.Errorf("Load() = %v, want keys derived from an empty view", data)
+	}
+	if source.Name() != "derived" {
+		t.Errorf("Name() = %q, want %q", source.Name(), "derived")
+	}
+	if _, err := source.Watch(context.Background()); err != ErrWatchNotSupported {
+		t.Errorf("Watch() error = %v, want ErrWatchNotSupported", err)
+	}
+}
@@ -13,7 +13,7 @@
 //
 //	cfg, err := loader.Load(context.Background())
 //
-// Tag directives: env:VAR, default:val, required, min:N, max:N, oneof:a,b,c, secret, prefix:path, name:path
+// Tag directives: env:VAR, default:val, required, min:N, max:N, oneof:a,b,c, requiredkeys:a,b, secret, deprecated, group:name, required-group:name, prefix:path, name:path
 //
 // See example_test.go and README.md for detailed usage.
 package rigging
@@ -1,6 +1,7 @@
 package rigging
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,10 +17,12 @@ type DumpOption func(*dumpConfig)
 type dumpConfig struct {
 	withSources bool   // Include source attribution for each field
 	asJSON      bool   // Output as JSON instead of text format
+	detailed    bool   // Output every JSON field as a detail record (see AsJSONDetailed)
 	indent      string // Indentation for JSON output (default: "  ")
 }
 
-// WithSources includes source attribution in output.
+// WithSources includes source attribution in output, and whether each value was set explicitly
+// (FieldProvenance.Explicit): text output marks values from defaults with "explicit: false".
 func WithSources() DumpOption {
 	return func(cfg *dumpConfig) {
 		cfg.withSources = true
@@ -33,6 +36,17 @@ func AsJSON() DumpOption {
 	}
 }
 
+// AsJSONDetailed outputs configuration as JSON in which every field is an object
+// {"value": ..., "source": ..., "secret": bool, "keyPath": ...}, also fields without
+// a source (source ""). Nested structs are nested objects of these records, and unset
+// Optional fields have a null value. Secrets are still redacted.
+func AsJSONDetailed() DumpOption {
+	return func(cfg *dumpConfig) {
+		cfg.asJSON = true
+		cfg.detailed = true
+	}
+}
+
 // WithIndent sets JSON indentation (default: "  "). No effect for text output.
 func WithIndent(indent string) DumpOption {
 	return func(cfg *dumpConfig) {
@@ -42,6 +56,8 @@ func WithIndent(indent string) DumpOption {
 
 // DumpEffective writes configuration with automatic secret redaction.
 // Supports text or JSON format. Use WithSources(), AsJSON(), WithIndent() options.
+// Output is deterministic: fields appear in struct declaration order in both
+// formats, and map-valued fields are written with sorted keys.
 func DumpEffective[T any](w io.Writer, cfg *T, opts ...DumpOption) error {
 	if cfg == nil {
 		return fmt.Errorf("config is nil")
@@ -82,6 +98,33 @@ func DumpEffective[T any](w io.Writer, cfg *T, opts ...DumpOption) error {
 	return dumpAsText(w, v, provenanceMap, config)
 }
 
+// RedactedString renders configuration as a multi-line "key: value" string with secrets redacted.
+// It is a safe replacement for fmt's %v when logging a loaded config.
+// Fields tagged secret are always redacted; values a source marked as secret are only
+// known for config pointers returned by Load, like in DumpEffective.
+func RedactedString(cfg any) string {
+	v := reflect.ValueOf(cfg)
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return "<nil>"
+	}
+
+	provenanceMap := storedFieldProvenance(cfg)
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "<not a struct>"
+	}
+
+	var b strings.Builder
+	for _, field := range collectFields(v, "", provenanceMap) {
+		fmt.Fprintf(&b, "%s: %s\n", field.keyPath, field.displayValue)
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
 // dumpAsText outputs configuration in text format (key: value).
 func dumpAsText(w io.Writer, v reflect.Value, provenanceMap map[string]*FieldProvenance, config dumpConfig) error {
 	fields := collectFields(v, "", provenanceMap)
@@ -89,7 +132,15 @@ func dumpAsText(w io.Writer, v reflect.Value, provenanceMap map[string]*FieldPro
 	for _, field := range fields {
 		line := fmt.Sprintf("%s: %s", field.keyPath, field.displayValue)
 		if config.withSources && field.sourceName != "" {
-			line += fmt.Sprintf(" (source: %s)", field.sourceName)
+			attribution := "source: " + field.sourceName
+			if field.layer != "" {
+				attribution += ", layer: " + field.layer
+			}
+			// Values from defaults are marked; explicit is the common case
+			if !field.explicit {
+				attribution += ", explicit: false"
+			}
+			line += " (" + attribution + ")"
 		}
 		line += "\n"
 
@@ -104,7 +155,7 @@ func dumpAsText(w io.Writer, v reflect.Value, provenanceMap map[string]*FieldPro
 // dumpAsJSON outputs configuration as JSON with secret redaction.
 func dumpAsJSON(w io.Writer, v reflect.Value, provenanceMap map[string]*FieldProvenance, config dumpConfig) error {
 	// Build a nested map structure for JSON output
-	result := buildJSONStructure(v, "", provenanceMap, config.withSources)
+	result := buildJSONStructure(v, "", "", provenanceMap, config)
 
 	// Marshal to JSON
 	var data []byte
@@ -136,6 +187,8 @@ type fieldData struct {
 	keyPath      string // Dot-separated key path (e.g., "database.host")
 	displayValue string // Value to display (redacted if secret)
 	sourceName   string // Source attribution
+	layer        string // Source layer tag
+	explicit     bool   // Set by a source rather than a default (see FieldProvenance.Explicit)
 }
 
 // collectFields recursively walks a struct and collects field data.
@@ -153,6 +206,16 @@ func collectFieldsWithPath(v reflect.Value, fieldPathPrefix string, keyPathPrefi
 		field := t.Field(i)
 		fieldValue := v.Field(i)
 
+		// Parse tag to get custom name or prefix
+		tag := field.Tag.Get("conf")
+		tagCfg := parseTag(tag)
+
+		// Embedded structs are flattened into the parent scope
+		if isPromotedStruct(field, tagCfg) {
+			fields = append(fields, collectFieldsWithPath(fieldValue, fieldPathPrefix, keyPathPrefix, provenanceMap)...)
+			continue
+		}
+
 		// Skip unexported fields
 		if !field.IsExported() {
 			continue
@@ -164,10 +227,6 @@ func collectFieldsWithPath(v reflect.Value, fieldPathPrefix string, keyPathPrefi
 			fieldPath = fieldPathPrefix + "." + field.Name
 		}
 
-		// Parse tag to get custom name or prefix
-		tag := field.Tag.Get("conf")
-		tagCfg := parseTag(tag)
-
 		// Get provenance info first
 		var prov *FieldProvenance
 		if p, ok := provenanceMap[fieldPath]; ok {
@@ -189,6 +248,11 @@ func collectFieldsWithPath(v reflect.Value, fieldPathPrefix string, keyPathPrefi
 			}
 		}
 
+		// Allocated *Struct fields are handled like value structs
+		if isStructPointer(field.Type) && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+
 		// Handle nested structs recursively
 		if fieldValue.Kind() == reflect.Struct && field.Type.String() != "time.Time" {
 			// Check if this is an Optional type
@@ -197,11 +261,13 @@ func collectFieldsWithPath(v reflect.Value, fieldPathPrefix string, keyPathPrefi
 				setField := fieldValue.FieldByName("Set")
 				valueField := fieldValue.FieldByName("Value")
 				if setField.IsValid() && setField.Bool() && valueField.IsValid() {
-					displayValue := formatValue(valueField, prov)
+					displayValue := formatValue(valueField, prov, tagCfg)
 					fields = append(fields, fieldData{
 						keyPath:      keyPath,
 						displayValue: displayValue,
 						sourceName:   getSourceName(prov),
+						layer:        getLayer(prov),
+						explicit:     prov != nil && prov.Explicit,
 					})
 				} else {
 					// Not set, show as empty or skip
@@ -209,6 +275,8 @@ func collectFieldsWithPath(v reflect.Value, fieldPathPrefix string, keyPathPrefi
 						keyPath:      keyPath,
 						displayValue: "<not set>",
 						sourceName:   getSourceName(prov),
+						layer:        getLayer(prov),
+						explicit:     prov != nil && prov.Explicit,
 					})
 				}
 			} else {
@@ -229,27 +297,86 @@ func collectFieldsWithPath(v reflect.Value, fieldPathPrefix string, keyPathPrefi
 		}
 
 		// Format the value (with redaction if secret)
-		displayValue := formatValue(fieldValue, prov)
+		displayValue := formatValue(fieldValue, prov, tagCfg)
 
 		fields = append(fields, fieldData{
 			keyPath:      keyPath,
 			displayValue: displayValue,
 			sourceName:   getSourceName(prov),
+			layer:        getLayer(prov),
+			explicit:     prov != nil && prov.Explicit,
 		})
 	}
 
 	return fields
 }
 
-// buildJSONStructure recursively builds a nested map for JSON output.
-func buildJSONStructure(v reflect.Value, prefix string, provenanceMap map[string]*FieldProvenance, withSources bool) map[string]any {
-	result := make(map[string]any)
+// jsonObject is a JSON object that marshals its keys in insertion order,
+// so dumps follow struct declaration order instead of map iteration order.
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func newJSONObject() *jsonObject {
+	return &jsonObject{values: make(map[string]any)}
+}
+
+// set adds or replaces a key. Replaced keys keep their original position.
+func (o *jsonObject) set(key string, value any) {
+	if _, exists := o.values[key]; !exists {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o *jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// buildJSONStructure recursively builds a nested object for JSON output.
+// prefix is the field path of v, keyPathPrefix its key path (for detailed output).
+func buildJSONStructure(v reflect.Value, prefix, keyPathPrefix string, provenanceMap map[string]*FieldProvenance, config dumpConfig) *jsonObject {
+	result := newJSONObject()
 
 	t := v.Type()
 	for i := 0; i < v.NumField(); i++ {
 		field := t.Field(i)
 		fieldValue := v.Field(i)
 
+		// Parse tag
+		tag := field.Tag.Get("conf")
+		tagCfg := parseTag(tag)
+
+		// Embedded structs are flattened into the parent object
+		if isPromotedStruct(field, tagCfg) {
+			embedded := buildJSONStructure(fieldValue, prefix, keyPathPrefix, provenanceMap, config)
+			for _, key := range embedded.keys {
+				result.set(key, embedded.values[key])
+			}
+			continue
+		}
+
 		// Skip unexported fields
 		if !field.IsExported() {
 			continue
@@ -261,10 +388,6 @@ func buildJSONStructure(v reflect.Value, prefix string, provenanceMap map[string
 			fieldPath = prefix + "." + field.Name
 		}
 
-		// Parse tag
-		tag := field.Tag.Get("conf")
-		tagCfg := parseTag(tag)
-
 		// Determine JSON key
 		jsonKey := deriveKeyPath(field.Name)
 		if tagCfg.name != "" {
@@ -279,6 +402,24 @@ func buildJSONStructure(v reflect.Value, prefix string, provenanceMap map[string
 			prov = p
 		}
 
+		// Determine key path, as for text output
+		var keyPath string
+		if prov != nil && prov.KeyPath != "" {
+			keyPath = prov.KeyPath
+		} else if tagCfg.name != "" {
+			keyPath = tagCfg.name
+		} else {
+			keyPath = deriveKeyPath(field.Name)
+			if keyPathPrefix != "" {
+				keyPath = keyPathPrefix + "." + keyPath
+			}
+		}
+
+		// Allocated *Struct fields are handled like value structs
+		if isStructPointer(field.Type) && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+
 		// Handle nested structs recursively
 		if fieldValue.Kind() == reflect.Struct && field.Type.String() != "time.Time" {
 			// Check if this is an Optional type
@@ -287,42 +428,69 @@ func buildJSONStructure(v reflect.Value, prefix string, provenanceMap map[string
 				setField := fieldValue.FieldByName("Set")
 				valueField := fieldValue.FieldByName("Value")
 				if setField.IsValid() && setField.Bool() && valueField.IsValid() {
-					result[jsonKey] = buildJSONFieldValue(formatValueForJSON(valueField, prov), prov, withSources)
+					result.set(jsonKey, buildJSONFieldValue(formatValueForJSON(valueField, prov, tagCfg), prov, keyPath, tagCfg, config))
+				} else if config.detailed {
+					result.set(jsonKey, buildJSONFieldValue(nil, prov, keyPath, tagCfg, config))
 				} else {
-					result[jsonKey] = nil
+					result.set(jsonKey, nil)
 				}
 			} else {
 				// Regular nested struct
 				nestedPrefix := fieldPath
-				result[jsonKey] = buildJSONStructure(fieldValue, nestedPrefix, provenanceMap, withSources)
+				nestedKeyPrefix := keyPath
+				if tagCfg.prefix != "" {
+					nestedKeyPrefix = tagCfg.prefix
+				}
+				result.set(jsonKey, buildJSONStructure(fieldValue, nestedPrefix, nestedKeyPrefix, provenanceMap, config))
 			}
 			continue
 		}
 
 		// Format value for JSON
-		result[jsonKey] = buildJSONFieldValue(formatValueForJSON(fieldValue, prov), prov, withSources)
+		result.set(jsonKey, buildJSONFieldValue(formatValueForJSON(fieldValue, prov, tagCfg), prov, keyPath, tagCfg, config))
 	}
 
 	return result
 }
 
 // buildJSONFieldValue wraps a value with source information if requested.
-func buildJSONFieldValue(value any, prov *FieldProvenance, withSources bool) any {
-	if !withSources || prov == nil || prov.SourceName == "" {
+func buildJSONFieldValue(value any, prov *FieldProvenance, keyPath string, tagCfg tagConfig, config dumpConfig) any {
+	if config.detailed {
+		secret := tagCfg.secret || (prov != nil && prov.Secret)
+		if secret && prov == nil && value != nil {
+			value = "***redacted***" // Set after Load, so not redacted through provenance
+		}
+		result := newJSONObject()
+		result.set("value", value)
+		result.set("source", getSourceName(prov))
+		result.set("secret", secret)
+		result.set("keyPath", keyPath)
+		return result
+	}
+
+	if !config.withSources || prov == nil || prov.SourceName == "" {
 		return value
 	}
 
 	// When sources are requested, return an object with value and source
-	return map[string]any{
-		"value":  value,
-		"source": prov.SourceName,
+	result := newJSONObject()
+	result.set("value", value)
+	result.set("source", prov.SourceName)
+	if prov.Layer != "" {
+		result.set("layer", prov.Layer)
 	}
+	result.set("explicit", prov.Explicit)
+	return result
 }
 
 // formatValue formats a field value as a string, redacting secrets.
-func formatValue(v reflect.Value, prov *FieldProvenance) string {
-	// Check if this field is secret
-	if prov != nil && prov.Secret {
+func formatValue(v reflect.Value, prov *FieldProvenance, tagCfg tagConfig) string {
+	if prov != nil && prov.mask != nil {
+		return prov.mask.maskValue(v)
+	}
+
+	// Secret-tagged fields are redacted even without provenance (e.g. a config not from Load)
+	if tagCfg.secret || (prov != nil && prov.Secret) {
 		return "***redacted***"
 	}
 
@@ -330,9 +498,13 @@ func formatValue(v reflect.Value, prov *FieldProvenance) string {
 }
 
 // formatValueForJSON formats a field value for JSON output, redacting secrets.
-func formatValueForJSON(v reflect.Value, prov *FieldProvenance) any {
-	// Check if this field is secret
-	if prov != nil && prov.Secret {
+func formatValueForJSON(v reflect.Value, prov *FieldProvenance, tagCfg tagConfig) any {
+	if prov != nil && prov.mask != nil {
+		return prov.mask.maskValue(v)
+	}
+
+	// Secret-tagged fields are redacted even without provenance (e.g. a config not from Load)
+	if tagCfg.secret || (prov != nil && prov.Secret) {
 		return "***redacted***"
 	}
 
@@ -340,6 +512,9 @@ func formatValueForJSON(v reflect.Value, prov *FieldProvenance) any {
 	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
 		return nil
 	}
+	if elems, ok := optionalElems(v); ok {
+		return elems
+	}
 
 	// Handle different types
 	switch v.Kind() {
@@ -391,6 +566,9 @@ func formatValueAsString(v reflect.Value) string {
 	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
 		return "<nil>"
 	}
+	if elems, ok := optionalElems(v); ok {
+		return fmt.Sprintf("%v", elems)
+	}
 
 	switch v.Kind() {
 	case reflect.String:
@@ -431,6 +609,35 @@ func formatValueAsString(v reflect.Value) string {
 	}
 }
 
+// optionalElems returns the elements of a slice or map of Optional values, unwrapped, with
+// unset elements as nil. ok is false for other values.
+func optionalElems(v reflect.Value) (elems any, ok bool) {
+	if (v.Kind() != reflect.Slice && v.Kind() != reflect.Map) || !isOptionalType(v.Type().Elem()) {
+		return nil, false
+	}
+
+	unwrap := func(elem reflect.Value) any {
+		if !elem.Field(1).Bool() {
+			return nil
+		}
+		return elem.Field(0).Interface()
+	}
+
+	if v.Kind() == reflect.Slice {
+		result := make([]any, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result[i] = unwrap(v.Index(i))
+		}
+		return result, true
+	}
+	result := make(map[string]any, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		result[fmt.Sprint(iter.Key().Interface())] = unwrap(iter.Value())
+	}
+	return result, true
+}
+
 // deriveKeyPath derives a key path from a field name (lowercase first letter).
 func deriveKeyPath(fieldName string) string {
 	if fieldName == "" {
@@ -446,3 +653,11 @@ func getSourceName(prov *FieldProvenance) string {
 	}
 	return prov.SourceName
 }
+
+// getLayer extracts the source layer tag from provenance, or returns empty string.
+func getLayer(prov *FieldProvenance) string {
+	if prov == nil {
+		return ""
+	}
+	return prov.Layer
+}
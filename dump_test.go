@@ -2,6 +2,7 @@ package rigging
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -66,19 +67,22 @@ func TestDumpEffective_TextFormat(t *testing.T) {
 
 func TestDumpEffective_WithSources(t *testing.T) {
 	type Config struct {
-		Host string `conf:"name:host"`
-		Port int    `conf:"name:port"`
+		Host    string `conf:"name:host"`
+		Port    int    `conf:"name:port"`
+		Timeout int    `conf:"name:timeout"`
 	}
 
 	cfg := &Config{
-		Host: "localhost",
-		Port: 8080,
+		Host:    "localhost",
+		Port:    8080,
+		Timeout: 30,
 	}
 
 	prov := &Provenance{
 		Fields: []FieldProvenance{
-			{FieldPath: "Host", KeyPath: "host", SourceName: "env:HOST", Secret: false},
-			{FieldPath: "Port", KeyPath: "port", SourceName: "file:/etc/config.yaml", Secret: false},
+			{FieldPath: "Host", KeyPath: "host", SourceName: "env:HOST", Secret: false, Explicit: true},
+			{FieldPath: "Port", KeyPath: "port", SourceName: "file:/etc/config.yaml", Secret: false, Explicit: true},
+			{FieldPath: "Timeout", KeyPath: "timeout", SourceName: "default"},
 		},
 	}
 	storeProvenance(cfg, prov)
@@ -98,6 +102,9 @@ func TestDumpEffective_WithSources(t *testing.T) {
 	if !strings.Contains(output, "(source: file:/etc/config.yaml)") {
 		t.Errorf("Expected source attribution for port, got: %s", output)
 	}
+	if !strings.Contains(output, "timeout: 30 (source: default, explicit: false)") {
+		t.Errorf("Expected defaults to be marked as not explicit, got: %s", output)
+	}
 }
 
 func TestDumpEffective_JSONFormat(t *testing.T) {
@@ -152,6 +159,87 @@ func TestDumpEffective_JSONFormat(t *testing.T) {
 	}
 }
 
+func TestDumpEffective_JSONDetailed(t *testing.T) {
+	type Pool struct {
+		Size int
+	}
+	type Database struct {
+		Host     string
+		Password string `conf:"secret"`
+		Pool     Pool   `conf:"prefix:pool"`
+	}
+	type Config struct {
+		Name     string
+		Timeout  time.Duration
+		Replicas Optional[int]
+		Database Database
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{name: "file:config.yaml", data: map[string]any{
+			"name":              "api",
+			"database.host":     "db.internal",
+			"database.password": "hunter2",
+		}}).
+		WithSource(&mockSource{name: "env", data: map[string]any{"pool.size": 10}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := DumpEffective(&buf, cfg, AsJSONDetailed()); err != nil {
+		t.Fatalf("DumpEffective failed: %v", err)
+	}
+
+	golden := `{
+  "name": {
+    "value": "api",
+    "source": "file:config.yaml",
+    "secret": false,
+    "keyPath": "name"
+  },
+  "timeout": {
+    "value": "0s",
+    "source": "",
+    "secret": false,
+    "keyPath": "timeout"
+  },
+  "replicas": {
+    "value": null,
+    "source": "",
+    "secret": false,
+    "keyPath": "replicas"
+  },
+  "database": {
+    "host": {
+      "value": "db.internal",
+      "source": "file:config.yaml",
+      "secret": false,
+      "keyPath": "database.host"
+    },
+    "password": {
+      "value": "***redacted***",
+      "source": "file:config.yaml",
+      "secret": true,
+      "keyPath": "database.password"
+    },
+    "pool": {
+      "size": {
+        "value": 10,
+        "source": "env",
+        "secret": false,
+        "keyPath": "pool.size"
+      }
+    }
+  }
+}
+`
+	if got := buf.String(); got != golden {
+		t.Errorf("DumpEffective(AsJSONDetailed()) =\n%s\nwant\n%s", got, golden)
+	}
+}
+
 func TestDumpEffective_NestedStructs(t *testing.T) {
 	type Database struct {
 		Host     string `conf:"name:host"`
@@ -657,3 +745,176 @@ func TestDumpEffective_JSONNestedWithSources(t *testing.T) {
 		t.Errorf("Expected database.password source=env:DB_PASSWORD, got: %v", dbPassword["source"])
 	}
 }
+
+func TestDumpEffective_DeterministicOrder(t *testing.T) {
+	type Database struct {
+		User     string `conf:"name:user"`
+		Host     string `conf:"name:host"`
+		Password string `conf:"name:password,secret"`
+	}
+	type Config struct {
+		Zone     string            `conf:"name:zone"`
+		Database Database          `conf:"prefix:database"`
+		Labels   map[string]string `conf:"name:labels"`
+		Alpha    int               `conf:"name:alpha"`
+		Middle   bool              `conf:"name:middle"`
+	}
+
+	cfg := &Config{
+		Zone:     "eu-west",
+		Database: Database{User: "admin", Host: "db", Password: "hunter2"},
+		Labels:   map[string]string{"team": "core", "app": "api", "env": "prod", "region": "eu"},
+		Alpha:    1,
+		Middle:   true,
+	}
+	storeProvenance(cfg, &Provenance{
+		Fields: []FieldProvenance{
+			{FieldPath: "Zone", KeyPath: "zone", SourceName: "env", Layer: "base"},
+			{FieldPath: "Database.User", KeyPath: "database.user", SourceName: "file"},
+			{FieldPath: "Database.Host", KeyPath: "database.host", SourceName: "file"},
+			{FieldPath: "Database.Password", KeyPath: "database.password", SourceName: "env", Secret: true},
+			{FieldPath: "Labels", KeyPath: "labels", SourceName: "file"},
+			{FieldPath: "Alpha", KeyPath: "alpha", SourceName: "default"},
+			{FieldPath: "Middle", KeyPath: "middle", SourceName: "default"},
+		},
+	})
+
+	tests := []struct {
+		name  string
+		opts  []DumpOption
+		order []string
+	}{
+		{
+			name:  "text",
+			order: []string{"zone:", "database.user:", "database.host:", "database.password:", "labels:", "alpha:", "middle:"},
+		},
+		{
+			name:  "text with sources",
+			opts:  []DumpOption{WithSources()},
+			order: []string{"zone:", "database.user:", "database.host:", "database.password:", "labels:", "alpha:", "middle:"},
+		},
+		{
+			name:  "json",
+			opts:  []DumpOption{AsJSON()},
+			order: []string{`"zone"`, `"database"`, `"user"`, `"host"`, `"password"`, `"labels"`, `"app"`, `"env"`, `"region"`, `"team"`, `"alpha"`, `"middle"`},
+		},
+		{
+			name:  "json with sources",
+			opts:  []DumpOption{AsJSON(), WithSources(), WithIndent("")},
+			order: []string{`"zone":{"value":"eu-west","source":"env","layer":"base","explicit":false}`, `"database"`, `"user"`, `"host"`, `"password"`, `"labels"`, `"alpha"`, `"middle"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var first bytes.Buffer
+			if err := DumpEffective(&first, cfg, tt.opts...); err != nil {
+				t.Fatalf("DumpEffective failed: %v", err)
+			}
+
+			for i := 0; i < 20; i++ {
+				var buf bytes.Buffer
+				if err := DumpEffective(&buf, cfg, tt.opts...); err != nil {
+					t.Fatalf("DumpEffective failed: %v", err)
+				}
+				if buf.String() != first.String() {
+					t.Fatalf("output differs between calls:\nfirst:\n%s\ncall %d:\n%s", first.String(), i+2, buf.String())
+				}
+			}
+
+			output := first.String()
+			pos := -1
+			for _, want := range tt.order {
+				idx := strings.Index(output[pos+1:], want)
+				if idx < 0 {
+					t.Fatalf("expected %s after position %d in output:\n%s", want, pos, output)
+				}
+				pos += idx + 1
+			}
+		})
+	}
+}
+
+func TestRedactedString(t *testing.T) {
+	type Config struct {
+		Host     string
+		Port     int
+		Password string `conf:"secret"`
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{
+			"host":     "localhost",
+			"port":     8080,
+			"password": "hunter2",
+		}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	output := RedactedString(cfg)
+
+	if strings.Contains(output, "hunter2") {
+		t.Errorf("secret value leaked: %s", output)
+	}
+
+	for _, want := range []string{`host: "localhost"`, "port: 8080", "password: ***redacted***"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if strings.HasSuffix(output, "\n") {
+		t.Error("output should not end with a newline")
+	}
+}
+
+func TestRedactedString_WithoutProvenance(t *testing.T) {
+	type Config struct {
+		Hosts    []string
+		Labels   map[string]string
+		Password string `conf:"secret"`
+		Token    string `conf:"mask:partial"`
+	}
+	cfg := Config{
+		Hosts:    []string{"a", "b"},
+		Labels:   map[string]string{"env": "prod"},
+		Password: "hunter2",
+		Token:    "tok_abcdef123456",
+	}
+
+	// Neither a struct value with slice or map fields nor a pointer that Load did not
+	// return has stored provenance; secret-tagged fields are redacted anyway.
+	for _, arg := range []any{cfg, &cfg} {
+		output := RedactedString(arg)
+		for _, secret := range []string{"hunter2", "tok_", "3456"} {
+			if strings.Contains(output, secret) {
+				t.Errorf("RedactedString(%T) leaked %q:\n%s", arg, secret, output)
+			}
+		}
+		if !strings.Contains(output, "password: ***redacted***") || !strings.Contains(output, "hosts: [a, b]") {
+			t.Errorf("RedactedString(%T) = \n%s", arg, output)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := DumpEffective(&buf, &cfg); err != nil {
+		t.Fatalf("DumpEffective failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("DumpEffective leaked a secret:\n%s", buf.String())
+	}
+}
+
+func TestRedactedString_Nil(t *testing.T) {
+	type Config struct{ Host string }
+	var cfg *Config
+
+	if got := RedactedString(cfg); got != "<nil>" {
+		t.Errorf("RedactedString(nil) = %q, want %q", got, "<nil>")
+	}
+	if got := RedactedString(nil); got != "<nil>" {
+		t.Errorf("RedactedString(nil) = %q, want %q", got, "<nil>")
+	}
+}
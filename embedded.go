@@ -0,0 +1,53 @@
+package rigging
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Azhovan/rigging/internal/document"
+)
+
+// embeddedDefaultSource is the provenance source name of values from DefaultProvider.
+const embeddedDefaultSource = "embedded-default"
+
+// DefaultProvider is implemented by config types that ship their baseline values as a
+// YAML or JSON document, e.g. one embedded with go:embed, instead of (or besides) default tags.
+//
+//	//go:embed defaults.yaml
+//	var defaultsYAML []byte
+//
+//	func (Config) DefaultConfig() []byte { return defaultsYAML }
+//
+// The loader detects the method on T or *T (called on a zero value) and merges the parsed
+// document beneath every other layer, with provenance source "embedded-default".
+// Precedence: tag default < DefaultConfig < WithDefaults < WithBaseConfig < sources.
+// Like source keys, its keys must map to struct fields in strict mode.
+type DefaultProvider interface {
+	DefaultConfig() []byte
+}
+
+// embeddedDefaults returns the flattened document of T's DefaultConfig method, or nil
+// if T does not implement DefaultProvider.
+func embeddedDefaults[T any]() (map[string]any, error) {
+	var zero T
+	provider, ok := any(zero).(DefaultProvider)
+	if !ok {
+		if provider, ok = any(&zero).(DefaultProvider); !ok {
+			return nil, nil
+		}
+	}
+
+	// YAML is a superset of JSON, so both document formats parse as YAML
+	doc, err := document.Parse("yaml", provider.DefaultConfig())
+	if err != nil {
+		return nil, fmt.Errorf("parse embedded defaults of %s: %w", reflect.TypeOf(zero), err)
+	}
+
+	flattened, _ := document.Flatten(doc)
+	result := make(map[string]any, len(flattened))
+	for key, value := range flattened {
+		result[strings.ToLower(key)] = value
+	}
+	return result, nil
+}
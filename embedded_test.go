@@ -0,0 +1,167 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type embeddedDatabase struct {
+	Host    string `conf:"default:tag.internal"`
+	Port    int    `conf:"default:1"`
+	Name    string `conf:"default:tagdb"`
+	Timeout int
+}
+
+type embeddedConfig struct {
+	Database embeddedDatabase
+	Debug    bool
+}
+
+func (embeddedConfig) DefaultConfig() []byte {
+	return []byte(`
+database:
+  host: embedded.internal
+  port: 5432
+debug: true
+`)
+}
+
+// embeddedPointerConfig implements DefaultProvider with a pointer receiver and JSON.
+type embeddedPointerConfig struct {
+	Host string
+}
+
+func (*embeddedPointerConfig) DefaultConfig() []byte {
+	return []byte(`{"host": "json.internal"}`)
+}
+
+type embeddedUnknownConfig struct {
+	Host string
+}
+
+func (embeddedUnknownConfig) DefaultConfig() []byte {
+	return []byte("host: localhost\nhots: typo\n")
+}
+
+type embeddedMalformedConfig struct {
+	Host string
+}
+
+func (embeddedMalformedConfig) DefaultConfig() []byte {
+	return []byte("host: [unclosed\n")
+}
+
+func TestEmbeddedDefaults_Precedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		loader     func() *Loader[embeddedConfig]
+		wantHost   string
+		wantPort   int
+		wantName   string
+		hostSource string
+		portSource string
+	}{
+		{
+			name: "embedded defaults above tag defaults",
+			loader: func() *Loader[embeddedConfig] {
+				return NewLoader[embeddedConfig]()
+			},
+			wantHost:   "embedded.internal",
+			wantPort:   5432,
+			wantName:   "tagdb",
+			hostSource: "embedded-default",
+			portSource: "embedded-default",
+		},
+		{
+			name: "loader defaults above embedded defaults",
+			loader: func() *Loader[embeddedConfig] {
+				return NewLoader[embeddedConfig]().WithDefaults(map[string]any{"database.port": 6543})
+			},
+			wantHost:   "embedded.internal",
+			wantPort:   6543,
+			wantName:   "tagdb",
+			hostSource: "embedded-default",
+			portSource: "loader-default",
+		},
+		{
+			name: "sources above embedded defaults",
+			loader: func() *Loader[embeddedConfig] {
+				return NewLoader[embeddedConfig]().
+					WithSource(&mockSource{data: map[string]any{"database.host": "db.internal"}})
+			},
+			wantHost:   "db.internal",
+			wantPort:   5432,
+			wantName:   "tagdb",
+			hostSource: "mock",
+			portSource: "embedded-default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := tt.loader().Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Database.Host != tt.wantHost || cfg.Database.Port != tt.wantPort || cfg.Database.Name != tt.wantName {
+				t.Errorf("Database = %+v, want host %q, port %d, name %q", cfg.Database, tt.wantHost, tt.wantPort, tt.wantName)
+			}
+			if !cfg.Debug {
+				t.Error("Debug = false, want true from embedded defaults")
+			}
+
+			prov, ok := GetProvenance(cfg)
+			if !ok {
+				t.Fatal("GetProvenance() found no provenance")
+			}
+			for path, want := range map[string]string{"Database.Host": tt.hostSource, "Database.Port": tt.portSource} {
+				field := findProvenance(prov.Fields, path)
+				if field == nil {
+					t.Fatalf("no provenance for %s", path)
+				}
+				if field.SourceName != want {
+					t.Errorf("%s source = %q, want %q", path, field.SourceName, want)
+				}
+				if field.Explicit != (want == "mock") {
+					t.Errorf("%s Explicit = %v, want %v", path, field.Explicit, want == "mock")
+				}
+			}
+		})
+	}
+}
+
+func TestEmbeddedDefaults_PointerReceiver(t *testing.T) {
+	cfg, err := NewLoader[embeddedPointerConfig]().Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Host != "json.internal" {
+		t.Errorf("Host = %q, want %q", cfg.Host, "json.internal")
+	}
+}
+
+func TestEmbeddedDefaults_Errors(t *testing.T) {
+	t.Run("unknown key in strict mode", func(t *testing.T) {
+		_, err := NewLoader[embeddedUnknownConfig]().Load(context.Background())
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Fatalf("Load() error = %v, want *ValidationError", err)
+		}
+		if len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].Code != ErrCodeUnknownKey || valErr.FieldErrors[0].FieldPath != "hots" {
+			t.Errorf("FieldErrors = %+v, want unknown key hots", valErr.FieldErrors)
+		}
+
+		if _, err := NewLoader[embeddedUnknownConfig]().Strict(false).Load(context.Background()); err != nil {
+			t.Errorf("Load() with Strict(false) error = %v", err)
+		}
+	})
+
+	t.Run("malformed document", func(t *testing.T) {
+		_, err := NewLoader[embeddedMalformedConfig]().Load(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "parse embedded defaults") {
+			t.Fatalf("Load() error = %v, want parse error", err)
+		}
+	})
+}
@@ -1,18 +1,32 @@
 package rigging
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // Error codes for validation failures.
 const (
-	ErrCodeRequired    = "required"     // Field is required but not provided
-	ErrCodeMin         = "min"          // Value is below minimum constraint
-	ErrCodeMax         = "max"          // Value exceeds maximum constraint
-	ErrCodeOneOf       = "oneof"        // Value is not in the allowed set
-	ErrCodeInvalidType = "invalid_type" // Type conversion failed
-	ErrCodeUnknownKey  = "unknown_key"  // Configuration key doesn't map to any field (strict mode)
+	ErrCodeRequired       = "required"        // Field is required but not provided
+	ErrCodeMin            = "min"             // Value is below minimum constraint
+	ErrCodeMax            = "max"             // Value exceeds maximum constraint
+	ErrCodeGt             = "gt"              // Value is not greater than the exclusive lower bound
+	ErrCodeLt             = "lt"              // Value is not less than the exclusive upper bound
+	ErrCodeOneOf          = "oneof"           // Value is not in the allowed set
+	ErrCodeInvalidType    = "invalid_type"    // Type conversion failed
+	ErrCodeUnknownKey     = "unknown_key"     // Configuration key doesn't map to any field (strict mode)
+	ErrCodeRequiredKeys   = "required_keys"   // Map field is missing one or more required keys
+	ErrCodeDeprecated     = "deprecated"      // Deprecated field was set (see WithDeprecationError)
+	ErrCodeExclusiveGroup = "exclusive_group" // More than one field of a group is set
+	ErrCodeRequiredGroup  = "required_group"  // No field of a required group is set
+	ErrCodeConfigSchema   = "config_schema"   // Config struct is invalid (e.g. two fields share a key path)
+	ErrCodeTypeConflict   = "type_conflict"   // Sources disagree on whether a key is a map or a scalar
+	ErrCodeSecretFile     = "secret_file"     // File named by a secret's "_file" key cannot be read
+	ErrCodeLoad           = "load"            // Error not tied to a field, such as a failing source (LoadPartial only)
 )
 
 // ValidationError aggregates field-level validation failures.
@@ -40,9 +54,73 @@ func (e *ValidationError) Error() string {
 	return strings.TrimRight(b.String(), "\n")
 }
 
-// FieldError represents a single field validation failure.
-type FieldError struct {
+// MarshalJSON encodes the error as {"errors":[{"field":...,"code":...,"message":...}]}
+// so it can be returned from an HTTP handler as is. Errors are sorted by field path,
+// then code, so the output is stable across loads. Messages never contain secret values,
+// whether the field is tagged secret or a source marked the value as secret (messages from
+// custom validators are passed through as is).
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	fieldErrors := make([]FieldError, len(e.FieldErrors))
+	copy(fieldErrors, e.FieldErrors)
+	sort.SliceStable(fieldErrors, func(i, j int) bool {
+		if fieldErrors[i].FieldPath != fieldErrors[j].FieldPath {
+			return fieldErrors[i].FieldPath < fieldErrors[j].FieldPath
+		}
+		return fieldErrors[i].Code < fieldErrors[j].Code
+	})
+	return json.Marshal(struct {
+		Errors []FieldError `json:"errors"`
+	}{Errors: fieldErrors})
+}
+
+// FieldWarning represents a non-fatal finding about a field, such as use of a deprecated key.
+// Warnings are delivered to the handler set with WithWarningHandler and never fail Load.
+type FieldWarning struct {
 	FieldPath string // Dot notation (e.g., "Database.Host")
-	Code      string // Error code (e.g., "required", "min")
+	Code      string // Warning code (e.g., "deprecated")
 	Message   string // Human-readable description
 }
+
+// Warn reports a non-fatal finding from a custom validator, e.g. a pool size that is unusually high.
+// Call it with the context passed to Validate. Warnings are delivered to the WithWarningHandler
+// callback once all validators have run and never fail Load. Outside a validator, Warn does nothing.
+func Warn(ctx context.Context, w FieldWarning) {
+	if collector, ok := ctx.Value(warningCollectorKey{}).(*warningCollector); ok {
+		collector.add(w)
+	}
+}
+
+// warningCollectorKey is the context key of the collector passed to each validator run.
+type warningCollectorKey struct{}
+
+// warningCollector gathers the warnings of one validator run.
+// It is safe for concurrent use, so validators may report from their own goroutines.
+type warningCollector struct {
+	mu       sync.Mutex
+	warnings []FieldWarning
+}
+
+func (c *warningCollector) add(w FieldWarning) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.warnings = append(c.warnings, w)
+}
+
+func (c *warningCollector) collected() []FieldWarning {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.warnings
+}
+
+// validatorResult holds the findings of one custom validator run.
+type validatorResult struct {
+	fieldErrors []FieldError
+	warnings    []FieldWarning
+}
+
+// FieldError represents a single field validation failure.
+type FieldError struct {
+	FieldPath string `json:"field"`   // Dot notation (e.g., "Database.Host")
+	Code      string `json:"code"`    // Error code (e.g., "required", "min")
+	Message   string `json:"message"` // Human-readable description
+}
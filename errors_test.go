@@ -1,8 +1,12 @@
 package rigging
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidationError_Error_SingleError(t *testing.T) {
@@ -90,6 +94,10 @@ func TestErrorCodes(t *testing.T) {
 		{"max code", ErrCodeMax, "max"},
 		{"oneof code", ErrCodeOneOf, "oneof"},
 		{"invalid_type code", ErrCodeInvalidType, "invalid_type"},
+		{"required_keys code", ErrCodeRequiredKeys, "required_keys"},
+		{"deprecated code", ErrCodeDeprecated, "deprecated"},
+		{"exclusive_group code", ErrCodeExclusiveGroup, "exclusive_group"},
+		{"required_group code", ErrCodeRequiredGroup, "required_group"},
 	}
 
 	for _, tt := range tests {
@@ -148,3 +156,153 @@ func TestValidationError_ErrorFormatting(t *testing.T) {
 		t.Errorf("ValidationError.Error() field error should be indented with '  - ', got: %q", lines[1])
 	}
 }
+
+func TestValidationError_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		ve   *ValidationError
+		want string
+	}{
+		{
+			name: "no errors",
+			ve:   &ValidationError{},
+			want: `{"errors":[]}`,
+		},
+		{
+			name: "single error",
+			ve: &ValidationError{FieldErrors: []FieldError{
+				{FieldPath: "Database.Host", Code: ErrCodeRequired, Message: "field is required but not provided"},
+			}},
+			want: `{"errors":[{"field":"Database.Host","code":"required","message":"field is required but not provided"}]}`,
+		},
+		{
+			name: "sorted by field then code",
+			ve: &ValidationError{FieldErrors: []FieldError{
+				{FieldPath: "Port", Code: ErrCodeMin, Message: "value -1 is below minimum 1"},
+				{FieldPath: "Env", Code: ErrCodeOneOf, Message: "bad env"},
+				{FieldPath: "Env", Code: "custom", Message: "first"},
+				{FieldPath: "Env", Code: "custom", Message: "second"},
+			}},
+			want: `{"errors":[` +
+				`{"field":"Env","code":"custom","message":"first"},` +
+				`{"field":"Env","code":"custom","message":"second"},` +
+				`{"field":"Env","code":"oneof","message":"bad env"},` +
+				`{"field":"Port","code":"min","message":"value -1 is below minimum 1"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := append([]FieldError(nil), tt.ve.FieldErrors...)
+
+			data, err := json.Marshal(tt.ve)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("json.Marshal()\ngot:  %s\nwant: %s", data, tt.want)
+			}
+
+			// Marshaling must not reorder the caller's errors
+			for i := range before {
+				if tt.ve.FieldErrors[i] != before[i] {
+					t.Errorf("FieldErrors[%d] changed: %+v, want %+v", i, tt.ve.FieldErrors[i], before[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidationError_MarshalJSONRedactsSecrets(t *testing.T) {
+	type Config struct {
+		PIN     int           `conf:"secret,min:1000"`
+		Ratio   float64       `conf:"secret,max:1"`
+		TTL     time.Duration `conf:"secret,max:1m"`
+		Token   string        `conf:"secret,oneof:tok_live,tok_test"`
+		Retries int           `conf:"secret"`
+	}
+
+	_, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{
+			"pin":     "42",
+			"ratio":   "7.5",
+			"ttl":     "36h",
+			"token":   "tok_hunter2",
+			"retries": "s3cr3t",
+		}}).
+		Load(context.Background())
+
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if len(ve.FieldErrors) != 5 {
+		t.Fatalf("expected 5 field errors, got %d: %v", len(ve.FieldErrors), ve)
+	}
+
+	data, err := json.Marshal(ve)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	for _, secret := range []string{"42", "7.5", "36h", "tok_hunter2", "s3cr3t"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("JSON contains secret value %q: %s", secret, data)
+		}
+		if strings.Contains(ve.Error(), secret) {
+			t.Errorf("Error() contains secret value %q: %s", secret, ve.Error())
+		}
+	}
+	if !strings.Contains(string(data), "***redacted***") {
+		t.Errorf("expected redaction marker in JSON: %s", data)
+	}
+}
+
+func TestValidationError_NeverContainsSecrets(t *testing.T) {
+	type Token struct {
+		ID string
+	}
+	type Config struct {
+		PIN     int    `conf:"secret"`
+		Grouped int    `conf:"secret,format:grouped"`
+		Size    int64  `conf:"secret,format:bytes"`
+		Token   Token  `conf:"secret,format:json"`
+		Port    int    `conf:"min:1024"`
+		Mode    string `conf:"oneof:fast,slow"`
+	}
+
+	// Port and mode are not tagged secret, but the source marks their values as secret
+	src := &mockSourceWithSecrets{
+		mockSourceWithKeys: mockSourceWithKeys{name: "vault", data: map[string]any{
+			"pin":     "98_76x",
+			"grouped": "12,345,67a",
+			"size":    "10 hunter2",
+			"token":   `{"id": s3cr3t}`,
+			"port":    "443",
+			"mode":    "tok_live",
+		}},
+		secrets: map[string]bool{"port": true, "mode": true},
+	}
+
+	_, err := NewLoader[Config]().WithSource(src).Load(context.Background())
+	var ve *ValidationError
+	if !errors.As(err, &ve) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+	if len(ve.FieldErrors) != 6 {
+		t.Fatalf("expected 6 field errors, got %d: %v", len(ve.FieldErrors), ve)
+	}
+
+	data, err := json.Marshal(ve)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	// Includes the forms converters normalize the values to
+	for _, secret := range []string{"98_76x", "9876x", "12,345,67a", "1234567a", "hunter2", "s3cr3t", "443", "tok_live"} {
+		if strings.Contains(strings.ToLower(string(data)), secret) {
+			t.Errorf("JSON contains secret value %q: %s", secret, data)
+		}
+		if strings.Contains(strings.ToLower(ve.Error()), secret) {
+			t.Errorf("Error() contains secret value %q: %s", secret, ve.Error())
+		}
+	}
+}
@@ -178,7 +178,7 @@ func ExampleDumpEffective_withSources() {
 
 	// Output:
 	// port: 9090 (source: env:EXDUMP_PORT)
-	// host: "localhost" (source: default)
+	// host: "localhost" (source: default, explicit: false)
 }
 
 // ExampleDumpEffective_asJSON demonstrates JSON output format.
@@ -205,12 +205,14 @@ func ExampleDumpEffective_asJSON() {
 	// Output:
 	// {
 	//   "environment": {
+	//     "value": "production",
 	//     "source": "env:EXJSON_ENVIRONMENT",
-	//     "value": "production"
+	//     "explicit": true
 	//   },
 	//   "port": {
+	//     "value": 8080,
 	//     "source": "default",
-	//     "value": 8080
+	//     "explicit": false
 	//   }
 	// }
 }
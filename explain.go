@@ -0,0 +1,141 @@
+package rigging
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// KeyExplanation describes how a single key was resolved across the loader's layers.
+type KeyExplanation struct {
+	KeyPath    string         // Normalized key (e.g., "database.host")
+	FieldPath  string         // Field bound to the key (e.g., "Database.Host"), empty if none
+	Candidates []KeyCandidate // Every offered value, lowest precedence first
+}
+
+// KeyCandidate is one value offered for a key by a source or layer.
+type KeyCandidate struct {
+	SourceName string // Source identifier (e.g., "file:config.yaml", "default")
+	SourceKey  string // Original key in the source (e.g., "env:APP_DATABASE__HOST")
+	Layer      string // Tag of the source (see WithTag), empty if untagged
+	Line       int    // Line in the source (see SourceWithPositions), 0 if unknown
+	Value      any    // Offered value, "***redacted***" for secrets
+	Won        bool   // Whether this value was bound
+}
+
+// Winner returns the candidate whose value was bound, or nil if no layer provided the key.
+func (e *KeyExplanation) Winner() *KeyCandidate {
+	for i := range e.Candidates {
+		if e.Candidates[i].Won {
+			return &e.Candidates[i]
+		}
+	}
+	return nil
+}
+
+// Explain loads all sources and reports, for one key path (e.g. "database.host"), every
+// value offered by WithDefaults, WithBaseConfig, the sources, context overrides, secret files,
+// and the field's default tag, in precedence order, and which one won. Fallback chains
+// (WithFallbackChain) are taken into account. Secret values are redacted.
+//
+// Unlike provenance, which only records the winner, this shows the values that were overridden.
+// Validation is not run, so Explain also works for configs that fail to load, including strict
+// mode errors for the key itself. Errors loading sources or merging them are returned.
+func (l *Loader[T]) Explain(ctx context.Context, keyPath string) (*KeyExplanation, error) {
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+		defer cancel()
+	}
+
+	trace := &mergeTrace{key: strings.ToLower(keyPath)}
+	if _, err := l.bind(ctx, bindOptions{trace: trace}); err != nil && !trace.decided {
+		return nil, err
+	}
+
+	explanation := &KeyExplanation{KeyPath: trace.key}
+
+	var field *reflect.StructField
+	walkFieldKeys(reflect.TypeOf((*T)(nil)).Elem(), "", "", l.ptrStructs, func(path, fieldPath string, f reflect.StructField) {
+		if field == nil && strings.EqualFold(path, trace.key) && !isNestedStructType(f.Type, l.ptrStructs) {
+			explanation.FieldPath = fieldPath
+			field = &f
+		}
+	})
+
+	var tagCfg tagConfig
+	if field != nil {
+		tagCfg = parseTag(field.Tag.Get("conf"))
+	}
+
+	for _, entry := range trace.candidates {
+		var value any = entry.value
+		if entry.secret || tagCfg.secret {
+			value = "***redacted***"
+		}
+		explanation.Candidates = append(explanation.Candidates, KeyCandidate{
+			SourceName: entry.sourceName,
+			SourceKey:  entry.sourceKey,
+			Layer:      entry.layer,
+			Line:       entry.line,
+			Value:      value,
+			Won:        entry == trace.winner,
+		})
+	}
+
+	// The default tag only applies when no layer provided the key
+	if tagCfg.hasDefault {
+		var value any = tagCfg.defValue
+		if tagCfg.secret {
+			value = "***redacted***"
+		}
+		explanation.Candidates = append([]KeyCandidate{{
+			SourceName: "default",
+			SourceKey:  "default",
+			Value:      value,
+			Won:        trace.winner == nil,
+		}}, explanation.Candidates...)
+	}
+
+	return explanation, nil
+}
+
+// mergeTrace records the entries offered for one key while sources are merged.
+// A nil trace records nothing.
+type mergeTrace struct {
+	key        string
+	candidates []*mergedEntry
+	winner     *mergedEntry // Entry bound to the key, nil if none
+	decided    bool         // The merge finished and winner is final
+}
+
+// offer records entry if it is for the traced key.
+func (t *mergeTrace) offer(key string, entry mergedEntry) {
+	if t == nil || key != t.key {
+		return
+	}
+	t.candidates = append(t.candidates, &entry)
+}
+
+// decide records which entry won once the merge is complete. Entries that were not offered
+// by a layer (e.g. values read from secret files) are added as the last candidate.
+func (t *mergeTrace) decide(merged map[string]mergedEntry) {
+	if t == nil {
+		return
+	}
+	t.decided = true
+
+	final, ok := merged[t.key]
+	if !ok {
+		return
+	}
+	for i := len(t.candidates) - 1; i >= 0; i-- {
+		c := t.candidates[i]
+		if c.sourceName == final.sourceName && c.sourceKey == final.sourceKey && c.layer == final.layer {
+			t.winner = c
+			return
+		}
+	}
+	t.winner = &final
+	t.candidates = append(t.candidates, t.winner)
+}
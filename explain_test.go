@@ -0,0 +1,158 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLoader_Explain(t *testing.T) {
+	type Database struct {
+		Host     string `conf:"default:localhost"`
+		Port     int
+		Password string `conf:"secret"`
+	}
+	type Config struct {
+		Database Database
+		LogLevel string `conf:"default:info"`
+	}
+
+	newLoader := func() *Loader[Config] {
+		return NewLoader[Config]().
+			WithDefaults(map[string]any{"database.port": 5432}).
+			WithSource(&mockSource{name: "file:base.yaml", data: map[string]any{
+				"database.host":     "base.internal",
+				"database.port":     5433,
+				"database.password": "base-secret",
+			}}).
+			WithSource(&mockSource{name: "file:prod.yaml", data: map[string]any{
+				"database.host": "prod.internal",
+			}}).
+			WithSource(&mockSource{name: "env", data: map[string]any{
+				"database.port": 6432,
+				"unknown.key":   "x",
+			}})
+	}
+
+	tests := []struct {
+		name       string
+		loader     func() *Loader[Config]
+		ctx        context.Context
+		keyPath    string
+		wantField  string
+		wantValues []any
+		wantWinner string // Source name of the winner, empty for none
+	}{
+		{
+			name:       "later source wins over tag default",
+			loader:     newLoader,
+			keyPath:    "database.host",
+			wantField:  "Database.Host",
+			wantValues: []any{"localhost", "base.internal", "prod.internal"},
+			wantWinner: "file:prod.yaml",
+		},
+		{
+			name:       "key path is case-insensitive",
+			loader:     newLoader,
+			keyPath:    "Database.Port",
+			wantField:  "Database.Port",
+			wantValues: []any{5432, 5433, 6432},
+			wantWinner: "env",
+		},
+		{
+			name:       "secrets are redacted",
+			loader:     newLoader,
+			keyPath:    "database.password",
+			wantField:  "Database.Password",
+			wantValues: []any{"***redacted***"},
+			wantWinner: "file:base.yaml",
+		},
+		{
+			name:       "tag default wins without sources",
+			loader:     newLoader,
+			keyPath:    "loglevel",
+			wantField:  "LogLevel",
+			wantValues: []any{"info"},
+			wantWinner: "default",
+		},
+		{
+			name:       "context override",
+			loader:     newLoader,
+			ctx:        WithContextOverrides(context.Background(), map[string]any{"database.port": 7000}),
+			keyPath:    "database.port",
+			wantField:  "Database.Port",
+			wantValues: []any{5432, 5433, 6432, 7000},
+			wantWinner: contextOverrideSource,
+		},
+		{
+			name: "fallback chain",
+			loader: func() *Loader[Config] {
+				return newLoader().WithFallbackChain("database.host", []string{"file:base.yaml", "file:prod.yaml"})
+			},
+			keyPath:    "database.host",
+			wantField:  "Database.Host",
+			wantValues: []any{"localhost", "base.internal", "prod.internal"},
+			wantWinner: "file:base.yaml",
+		},
+		{
+			name:       "unknown key in strict mode",
+			loader:     func() *Loader[Config] { return newLoader().Strict(true) },
+			keyPath:    "unknown.key",
+			wantValues: []any{"x"},
+			wantWinner: "env",
+		},
+		{
+			name:    "key not provided",
+			loader:  newLoader,
+			keyPath: "missing.key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			got, err := tt.loader().Explain(ctx, tt.keyPath)
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+			if got.FieldPath != tt.wantField {
+				t.Errorf("FieldPath = %q, want %q", got.FieldPath, tt.wantField)
+			}
+
+			var values []any
+			for _, c := range got.Candidates {
+				values = append(values, c.Value)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("candidate values = %v, want %v", values, tt.wantValues)
+			}
+
+			winner := got.Winner()
+			switch {
+			case tt.wantWinner == "" && winner != nil:
+				t.Errorf("Winner() = %+v, want nil", winner)
+			case tt.wantWinner != "" && (winner == nil || winner.SourceName != tt.wantWinner):
+				t.Errorf("Winner() = %+v, want source %q", winner, tt.wantWinner)
+			}
+		})
+	}
+}
+
+func TestLoader_Explain_SourceError(t *testing.T) {
+	type Config struct {
+		Host string
+	}
+
+	loadErr := errors.New("connection refused")
+	_, err := NewLoader[Config]().
+		WithSource(&mockSource{err: loadErr}).
+		Explain(context.Background(), "host")
+	if !errors.Is(err, loadErr) {
+		t.Errorf("Explain() error = %v, want %v", err, loadErr)
+	}
+}
@@ -0,0 +1,89 @@
+// Package document parses the YAML, JSON, and TOML documents read by the file, HTTP,
+// and reader sources and embedded defaults, and flattens them to dot-separated key paths.
+package document
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnsupportedFormat is returned by Parse for a format other than yaml, yml, json, or toml.
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+// Parse decodes data in the given format ("yaml", "yml", "json", or "toml").
+// Decoding errors are returned as is, so callers can add the document's location.
+func Parse(format string, data []byte) (map[string]any, error) {
+	var raw map[string]any
+	var err error
+	switch format {
+	case "yaml", "yml":
+		err = yaml.Unmarshal(data, &raw)
+	case "json":
+		err = json.Unmarshal(data, &raw)
+	case "toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// Name returns the display name of a format for error messages, e.g. "YAML" for "yml".
+func Name(format string) string {
+	switch format {
+	case "yaml", "yml":
+		return "YAML"
+	case "json":
+		return "JSON"
+	case "toml":
+		return "TOML"
+	default:
+		return format
+	}
+}
+
+// Flatten flattens nested maps to dot-separated keys (e.g. "database.host").
+// It also returns the original key of each flattened key, which for documents is the key itself.
+func Flatten(raw map[string]any) (map[string]any, map[string]string) {
+	flattened := make(map[string]any)
+	originalKeys := make(map[string]string)
+	flatten("", raw, flattened, originalKeys)
+	return flattened, originalKeys
+}
+
+// flatten recursively flattens value below prefix into result and originalKeys.
+func flatten(prefix string, value any, result map[string]any, originalKeys map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, val := range v {
+			newPrefix := key
+			if prefix != "" {
+				newPrefix = prefix + "." + key
+			}
+			flatten(newPrefix, val, result, originalKeys)
+		}
+	case map[any]any:
+		for key, val := range v {
+			keyStr, ok := key.(string)
+			if !ok {
+				continue
+			}
+			newPrefix := keyStr
+			if prefix != "" {
+				newPrefix = prefix + "." + keyStr
+			}
+			flatten(newPrefix, val, result, originalKeys)
+		}
+	default:
+		if prefix != "" {
+			result[prefix] = value
+			originalKeys[prefix] = prefix
+		}
+	}
+}
@@ -16,6 +16,36 @@ func ToLowerDotPath(key string) string {
 	return strings.ToLower(normalized)
 }
 
+// ToSnakeDotPath normalizes a key to a lowercase dot-separated path, keeping single underscores.
+// Examples: FOO__BAR → foo.bar, MAX_CONNECTIONS → max_connections
+func ToSnakeDotPath(key string) string {
+	return strings.ToLower(strings.ReplaceAll(key, "__", "."))
+}
+
+// ToCamelDotPath normalizes a key to a dot-separated path of camelCase segments.
+// Double underscores (__) → dots, single underscores separate words.
+// Examples: FOO__BAR → foo.bar, DB__MAX_CONNECTIONS → db.maxConnections
+func ToCamelDotPath(key string) string {
+	segments := strings.Split(key, "__")
+	for i, segment := range segments {
+		var b strings.Builder
+		for _, word := range strings.Split(segment, "_") {
+			if word == "" {
+				continue
+			}
+			word = strings.ToLower(word)
+			if b.Len() > 0 {
+				runes := []rune(word)
+				runes[0] = unicode.ToUpper(runes[0])
+				word = string(runes)
+			}
+			b.WriteString(word)
+		}
+		segments[i] = b.String()
+	}
+	return strings.Join(segments, ".")
+}
+
 // DeriveFieldPath lowercases the first letter of a field name.
 // Examples: Host → host, APIKey → aPIKey
 func DeriveFieldPath(fieldName string) string {
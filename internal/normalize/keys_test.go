@@ -67,6 +67,52 @@ func TestToLowerDotPath(t *testing.T) {
 	}
 }
 
+func TestToSnakeDotPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "single underscore kept", input: "MAX_CONNECTIONS", expected: "max_connections"},
+		{name: "double underscore to dot", input: "DB__MAX_CONNECTIONS", expected: "db.max_connections"},
+		{name: "no underscores", input: "HOST", expected: "host"},
+		{name: "empty string", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ToSnakeDotPath(tt.input)
+			if result != tt.expected {
+				t.Errorf("ToSnakeDotPath(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToCamelDotPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "words joined in camelCase", input: "MAX_CONNECTIONS", expected: "maxConnections"},
+		{name: "double underscore to dot", input: "DB__MAX_CONNECTIONS", expected: "db.maxConnections"},
+		{name: "single word", input: "HOST", expected: "host"},
+		{name: "lowercase input", input: "api_rate_limit", expected: "apiRateLimit"},
+		{name: "repeated separators ignored", input: "A___B", expected: "a.b"},
+		{name: "empty string", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ToCamelDotPath(tt.input)
+			if result != tt.expected {
+				t.Errorf("ToCamelDotPath(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestDeriveFieldPath(t *testing.T) {
 	tests := []struct {
 		name      string
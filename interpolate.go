@@ -0,0 +1,170 @@
+package rigging
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithInterpolation resolves ${key.path} references in string values against the other merged
+// keys, e.g. log_dir: "${base_dir}/logs". References are case-insensitive, may be chained, and
+// see the final merged values, including derived keys (see DerivedSource); write $${ for a
+// literal "${". missing decides what happens to references to keys no layer provides.
+// Reference cycles fail Load with ErrCodeConfigSchema. Interpolated fields are marked in
+// provenance (FieldProvenance.Interpolated), and are treated as secret if they reference
+// a secret. Tag defaults are not interpolated.
+func (l *Loader[T]) WithInterpolation(missing MissingRefPolicy) *Loader[T] {
+	l.interp = true
+	l.missingRef = missing
+	return l
+}
+
+// interpolator resolves ${key} references in the merged data in place.
+type interpolator struct {
+	data       map[string]mergedEntry
+	missing    MissingRefPolicy
+	secretKeys map[string]bool // Keys of fields tagged secret
+
+	state  map[string]int // 0 = unvisited, 1 = resolving, 2 = done
+	failed map[string]bool
+	stack  []string // Keys being resolved, for cycle messages
+	errs   []FieldError
+}
+
+const (
+	interpResolving = 1
+	interpDone      = 2
+)
+
+// interpolate resolves the references in all string values of data and returns
+// an error for each key with a missing reference (under MissingRefError) or a cycle.
+func interpolate(t reflect.Type, data map[string]mergedEntry, missing MissingRefPolicy) []FieldError {
+	in := &interpolator{
+		data:       data,
+		missing:    missing,
+		secretKeys: make(map[string]bool),
+		state:      make(map[string]int),
+		failed:     make(map[string]bool),
+	}
+	for _, f := range cachedFields(t, true) {
+		if parseTag(f.field.Tag.Get("conf")).secret {
+			in.secretKeys[f.keyPath] = true
+		}
+	}
+
+	// Sorted so that errors come out in a stable order
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		in.resolve(key)
+	}
+	return in.errs
+}
+
+// resolve interpolates the value of key, resolving the keys it references first.
+// It reports whether the value could be resolved.
+func (in *interpolator) resolve(key string) bool {
+	entry := in.data[key]
+	str, ok := entry.value.(string)
+	if !ok || !strings.Contains(str, "${") {
+		return true
+	}
+
+	switch in.state[key] {
+	case interpDone:
+		return !in.failed[key]
+	case interpResolving:
+		start := 0
+		for i, k := range in.stack {
+			if k == key {
+				start = i
+			}
+		}
+		cycle := append(append([]string(nil), in.stack[start:]...), key)
+		in.errs = append(in.errs, FieldError{
+			FieldPath: key,
+			Code:      ErrCodeConfigSchema,
+			Message:   "interpolation cycle: " + strings.Join(cycle, " -> "),
+		})
+		return false
+	}
+
+	in.state[key] = interpResolving
+	in.stack = append(in.stack, key)
+	value, secret, ok := in.expand(key, str)
+	in.stack = in.stack[:len(in.stack)-1]
+	in.state[key] = interpDone
+
+	if !ok {
+		in.failed[key] = true
+		return false
+	}
+	entry.value = value
+	entry.interpolated = true
+	entry.secret = entry.secret || secret
+	in.data[key] = entry
+	return true
+}
+
+// expand replaces the references in the value s of key. It also reports whether a
+// referenced value is secret.
+func (in *interpolator) expand(key, s string) (string, bool, bool) {
+	var b strings.Builder
+	secret := false
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+
+		// "$${" is an escaped, literal "${"
+		if i > 0 && s[i-1] == '$' {
+			b.WriteString(s[:i-1])
+			b.WriteString("${")
+			s = s[i+2:]
+			continue
+		}
+
+		end := strings.IndexByte(s[i+2:], '}')
+		if end < 0 {
+			b.WriteString(s) // Unterminated, kept as written
+			break
+		}
+		raw := s[i : i+2+end+1]
+		ref := strings.ToLower(strings.TrimSpace(s[i+2 : i+2+end]))
+		b.WriteString(s[:i])
+		s = s[i+len(raw):]
+
+		refEntry, exists := in.data[ref]
+		if !exists {
+			switch in.missing {
+			case MissingRefEmpty:
+			case MissingRefKeep:
+				b.WriteString(raw)
+			default:
+				in.errs = append(in.errs, FieldError{
+					FieldPath: key,
+					Code:      ErrCodeConfigSchema,
+					Message:   fmt.Sprintf("interpolation reference %s not found", raw),
+				})
+				return "", false, false
+			}
+			continue
+		}
+
+		if !in.resolve(ref) {
+			return "", false, false
+		}
+		refEntry = in.data[ref]
+		secret = secret || refEntry.secret || in.secretKeys[ref]
+		if refEntry.value != nil {
+			b.WriteString(fmt.Sprint(refEntry.value))
+		}
+	}
+	return b.String(), secret, true
+}
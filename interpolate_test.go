@@ -0,0 +1,149 @@
+package rigging
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLoader_WithInterpolation(t *testing.T) {
+	type Config struct {
+		BaseDir string
+		LogDir  string
+		Archive string
+		Port    int
+		Address string
+		Note    string
+	}
+
+	tests := []struct {
+		name    string
+		missing MissingRefPolicy
+		data    map[string]any
+		want    Config
+		wantErr string
+	}{
+		{
+			name: "simple reference",
+			data: map[string]any{"basedir": "/srv/app", "logdir": "${basedir}/logs"},
+			want: Config{BaseDir: "/srv/app", LogDir: "/srv/app/logs"},
+		},
+		{
+			name: "chained reference",
+			data: map[string]any{
+				"archive": "${logdir}/archive",
+				"logdir":  "${BaseDir}/logs",
+				"basedir": "/srv/app",
+			},
+			want: Config{BaseDir: "/srv/app", LogDir: "/srv/app/logs", Archive: "/srv/app/logs/archive"},
+		},
+		{
+			name: "non-string reference",
+			data: map[string]any{"port": 8080, "address": "localhost:${port}"},
+			want: Config{Port: 8080, Address: "localhost:8080"},
+		},
+		{
+			name: "escaped reference",
+			data: map[string]any{"note": "literal $${basedir}"},
+			want: Config{Note: "literal ${basedir}"},
+		},
+		{
+			name:    "cycle",
+			data:    map[string]any{"basedir": "${archive}", "archive": "${logdir}", "logdir": "${basedir}/logs"},
+			wantErr: "config_schema (interpolation cycle: archive -> logdir -> basedir -> archive)",
+		},
+		{
+			name:    "self reference",
+			data:    map[string]any{"note": "${note}!"},
+			wantErr: "config_schema (interpolation cycle: note -> note)",
+		},
+		{
+			name:    "missing reference fails",
+			data:    map[string]any{"logdir": "${missing}/logs"},
+			wantErr: "logdir: config_schema (interpolation reference ${missing} not found)",
+		},
+		{
+			name:    "missing reference empty",
+			missing: MissingRefEmpty,
+			data:    map[string]any{"logdir": "${missing}/logs"},
+			want:    Config{LogDir: "/logs"},
+		},
+		{
+			name:    "missing reference kept",
+			missing: MissingRefKeep,
+			data:    map[string]any{"logdir": "${ missing }/logs"},
+			want:    Config{LogDir: "${ missing }/logs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewLoader[Config]().
+				WithSource(&mockSource{data: tt.data}).
+				WithInterpolation(tt.missing).
+				Load(context.Background())
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Load() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if *cfg != tt.want {
+				t.Errorf("Load() = %+v, want %+v", *cfg, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoader_WithInterpolation_Provenance(t *testing.T) {
+	type Config struct {
+		Password string `conf:"secret"`
+		Host     string
+		DSN      string
+		LogDir   string
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{
+			"password": "hunter2",
+			"host":     "db",
+			"dsn":      "postgres://app:${password}@${host}",
+			"logdir":   "/var/log",
+		}}).
+		WithInterpolation(MissingRefError).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DSN != "postgres://app:hunter2@db" {
+		t.Errorf("DSN = %q", cfg.DSN)
+	}
+
+	prov, _ := GetProvenance(cfg)
+	if dsn := findProvenance(prov.Fields, "DSN"); !dsn.Interpolated || !dsn.Secret {
+		t.Errorf("DSN provenance = %+v, want interpolated and secret (references a secret)", dsn)
+	}
+	if logDir := findProvenance(prov.Fields, "LogDir"); logDir.Interpolated || logDir.Secret {
+		t.Errorf("LogDir provenance = %+v, want neither interpolated nor secret", logDir)
+	}
+}
+
+func TestLoader_WithoutInterpolation(t *testing.T) {
+	type Config struct {
+		LogDir string
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{"logdir": "${basedir}/logs"}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.LogDir != "${basedir}/logs" {
+		t.Errorf("LogDir = %q, want references left as is", cfg.LogDir)
+	}
+}
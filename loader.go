@@ -4,18 +4,76 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// loaderDefaultSource is the provenance source name of values set with WithDefaults.
+const loaderDefaultSource = "loader-default"
+
+// baseConfigSource is the provenance source name of values set with WithBaseConfig.
+const baseConfigSource = "base"
+
+// isDefaultSource reports whether sourceName is one of the default layers rather than a source
+// the operator configured: the `default:` tag, WithDefaults, or WithBaseConfig.
+func isDefaultSource(sourceName string) bool {
+	return sourceName == "default" || sourceName == embeddedDefaultSource || sourceName == loaderDefaultSource || sourceName == baseConfigSource
+}
+
+// defaultMaxDepth is the default limit on struct nesting (see WithMaxDepth).
+const defaultMaxDepth = 32
+
+// maxWalkDepth bounds the reflection walkers regardless of WithMaxDepth, so that
+// self-referential types such as `type Node struct{ Next *Node }` cannot overflow the stack.
+const maxWalkDepth = 256
+
 // Loader loads and validates configuration from multiple sources.
 // Sources are processed in order (later override earlier). Supports tag-based and custom validation.
 // Thread-safe for reads, not for concurrent configuration changes.
 type Loader[T any] struct {
-	sources    []Source
+	sources    []Source       // Sorted by ascending priority
+	sourceOpts []sourceConfig // Per-source settings, aligned with sources
 	validators []Validator[T]
-	strict     bool // Fail on unknown keys (default: true)
+	strict     bool     // Fail on unknown keys (default: true)
+	ignoreKeys []string // Unknown keys or key prefixes exempt from the strict check (lowercased)
+	diffGate   *DiffGate
+	metrics    Metrics // Reload metrics for Watch (default: no-op)
+	logger     *slog.Logger
+	valCache   *validationCache // Results of keyed validators (nil when disabled)
+	concurrent bool             // Run custom validators concurrently
+	valStop    bool             // Skip the validators after the first one reporting an error
+	onWarning  func(FieldWarning)
+	deprecErr  bool // Report deprecated fields as errors instead of warnings
+	emptyUnset bool // Drop empty-string source values (see WithTreatEmptyAsUnset)
+	ptrStructs bool // Bind *Struct fields like nested structs (see WithPointerStructs)
+	bindHook   func(fieldPath string, value any, source string)
+	onReload   func(Snapshot[T])   // Called by Start for each snapshot
+	onReloadEr func(error)         // Called by Start for each failed reload
+	valMode    ValidationMode      // CollectAll (default) or FailFast
+	interp     bool                // Resolve ${key} references (see WithInterpolation)
+	profiles   bool                // Resolve key@profile variants (see WithProfile)
+	profile    string              // Active profile, lowercased ("" = none)
+	missingRef MissingRefPolicy    // Handling of references to missing keys
+	fallbacks  map[string][]string // Per-key source precedence (see WithFallbackChain)
+	defaults   map[string]any      // Loader-level defaults beneath all sources
+	base       map[string]any      // Base config between defaults and sources, flattened and lowercased
+
+	reloadDiff bool             // Attach a ConfigDiff to reload snapshots
+	freeze     bool             // Record a checksum so GetProvenance detects later mutation
+	timeout    time.Duration    // Bound on the total Load duration (0 = none)
+	clock      func() time.Time // Source of Snapshot.LoadedAt (nil = time.Now)
+	maxDepth   int              // Limit on struct nesting (0 = defaultMaxDepth)
+	maskRule   *MaskRule        // Partial masking of mask:partial fields (nil = defaultMaskRule)
+
+	hashMu sync.Mutex
+	hash   string // ConfigHash of the last successful Load
+
+	gatePassed atomic.Bool // A Load has passed diffGate; later loads skip it
 }
 
 // NewLoader creates a Loader with no sources/validators and strict mode enabled.
@@ -24,164 +82,1504 @@ func NewLoader[T any]() *Loader[T] {
 		sources:    make([]Source, 0),
 		validators: make([]Validator[T], 0),
 		strict:     true, // Default to strict mode
+		metrics:    noopMetrics{},
+	}
+}
+
+// WithSource adds a source. Sources are processed in order (later override earlier).
+// Options such as WithTag configure how the source is tracked.
+// It is equivalent to WithSourceAt with the number of sources registered so far as priority.
+func (l *Loader[T]) WithSource(src Source, opts ...SourceOption) *Loader[T] {
+	return l.WithSourceAt(len(l.sources), src, opts...)
+}
+
+// WithSourceAt adds a source with an explicit priority, regardless of call order.
+// Sources with a higher priority override those with a lower one; on a tie, the source
+// added later wins. WithSource assigns priorities 0, 1, 2, ... in call order.
+func (l *Loader[T]) WithSourceAt(priority int, src Source, opts ...SourceOption) *Loader[T] {
+	cfg := sourceConfig{priority: priority}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// Insert after every source with the same or a lower priority
+	i := sort.Search(len(l.sourceOpts), func(i int) bool {
+		return l.sourceOpts[i].priority > priority
+	})
+	l.sources = append(l.sources[:i], append([]Source{src}, l.sources[i:]...)...)
+	l.sourceOpts = append(l.sourceOpts[:i], append([]sourceConfig{cfg}, l.sourceOpts[i:]...)...)
+	return l
+}
+
+// WithLenientSource adds a source whose unknown keys are tolerated in strict mode, e.g. a
+// third-party source that provides many unrelated keys. A key is exempt only if no strict source
+// (or WithDefaults, WithBaseConfig, or context override) provides it too; exempt keys are listed
+// in Provenance.Ignored. Otherwise it behaves like WithSource.
+func (l *Loader[T]) WithLenientSource(src Source, opts ...SourceOption) *Loader[T] {
+	opts = append(opts, func(cfg *sourceConfig) {
+		cfg.lenient = true
+	})
+	return l.WithSource(src, opts...)
+}
+
+// WithMaskRule sets how values of `mask:partial` fields are shown in DumpEffective and
+// snapshots. The default shows the last 4 characters of values with at least 16 characters.
+func (l *Loader[T]) WithMaskRule(rule MaskRule) *Loader[T] {
+	l.maskRule = &rule
+	return l
+}
+
+// WithDefaults sets loader-level default values, keyed by key path (e.g. "database.host").
+// They are merged beneath all sources and recorded with provenance source "loader-default".
+// Precedence: tag default < DefaultProvider < WithDefaults < sources. Calling it again replaces
+// the previous defaults.
+func (l *Loader[T]) WithDefaults(defaults map[string]any) *Loader[T] {
+	l.defaults = defaults
+	return l
+}
+
+// WithBaseConfig sets a base configuration computed at runtime, e.g. per tenant. It is merged
+// beneath all sources and recorded with provenance source "base". Nested maps are flattened to
+// key paths, so {"database": {"host": "x"}} and {"database.host": "x"} are equivalent.
+// Like source keys, base keys must map to struct fields in strict mode.
+// Precedence: tag default < WithDefaults < WithBaseConfig < sources. Calling it again replaces
+// the previous base config.
+func (l *Loader[T]) WithBaseConfig(base map[string]any) *Loader[T] {
+	l.base = make(map[string]any, len(base))
+	var flatten func(prefix string, m map[string]any)
+	flatten = func(prefix string, m map[string]any) {
+		for k, v := range m {
+			key := prefix + strings.ToLower(k)
+			if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+				flatten(key+".", nested)
+				continue
+			}
+			l.base[key] = v
+		}
+	}
+	flatten("", base)
+	return l
+}
+
+// WithMaxDepth limits how deeply structs may be nested in T (default 32). A deeper type,
+// typically a generated or self-referential one (`Next *Node`), fails Load with a config_schema
+// error instead of being walked without bound. Values above 256 are capped.
+func (l *Loader[T]) WithMaxDepth(depth int) *Loader[T] {
+	l.maxDepth = depth
+	return l
+}
+
+// WithFallbackChain sets the source precedence for a single key, overriding the global source order.
+// The value comes from the first source in sourceNames (matched against Source.Name) that provides
+// the key. If none of them provides it, the global order applies.
+func (l *Loader[T]) WithFallbackChain(keyPath string, sourceNames []string) *Loader[T] {
+	if l.fallbacks == nil {
+		l.fallbacks = make(map[string][]string)
+	}
+	l.fallbacks[strings.ToLower(keyPath)] = sourceNames
+	return l
+}
+
+// WithValidator adds a custom validator (executed after tag-based validation).
+func (l *Loader[T]) WithValidator(v Validator[T]) *Loader[T] {
+	l.validators = append(l.validators, v)
+	return l
+}
+
+// Strict controls whether unknown keys cause errors. Default: true.
+func (l *Loader[T]) Strict(strict bool) *Loader[T] {
+	l.strict = strict
+	return l
+}
+
+// IgnoreKeys exempts unknown keys from the strict check, e.g. a shared "meta" block read by other tools.
+// Each entry matches the key itself and every key below it ("meta" matches "meta.owner").
+// Ignored keys are not bound; they are listed in Provenance.Ignored and logged at debug level.
+// Calls accumulate.
+func (l *Loader[T]) IgnoreKeys(keys ...string) *Loader[T] {
+	for _, key := range keys {
+		l.ignoreKeys = append(l.ignoreKeys, strings.ToLower(key))
+	}
+	return l
+}
+
+// WithFreeze records a checksum of each loaded config so that GetProvenance reports
+// Provenance.Modified when the config is changed after Load (provenance would no longer
+// match its values). Checking costs a hash of the config per GetProvenance call. Default: false.
+func (l *Loader[T]) WithFreeze(enabled bool) *Loader[T] {
+	l.freeze = enabled
+	return l
+}
+
+// WithMetrics sets the metrics sink for Watch reloads. Passing nil restores the no-op default.
+func (l *Loader[T]) WithMetrics(m Metrics) *Loader[T] {
+	if m == nil {
+		m = noopMetrics{}
+	}
+	l.metrics = m
+	return l
+}
+
+// WithLogger enables debug logging of load events: sources loaded, winning source per field,
+// and validation outcomes. Values are never logged, only key paths and source names.
+// Default: no logging.
+func (l *Loader[T]) WithLogger(logger *slog.Logger) *Loader[T] {
+	l.logger = logger
+	return l
+}
+
+// WithValidationCache enables skipping KeyedValidators whose declared keys are unchanged
+// since their last run; the previous result is reused. Other validators always run.
+func (l *Loader[T]) WithValidationCache(enabled bool) *Loader[T] {
+	if enabled {
+		l.valCache = newValidationCache()
+	} else {
+		l.valCache = nil
+	}
+	return l
+}
+
+// WithConcurrentValidators runs custom validators concurrently instead of one after another.
+// Useful when validators are slow and independent (e.g. network checks). Validators must be
+// side-effect-free and safe to call concurrently; they share the config and the Load context,
+// which is cancelled as soon as one validator returns a non-validation error.
+// Field errors are still reported in registration order. Default: false.
+func (l *Loader[T]) WithConcurrentValidators(enabled bool) *Loader[T] {
+	l.concurrent = enabled
+	return l
+}
+
+// WithValidatorStopOnError sets whether the first custom validator that returns an error skips
+// the validators registered after it. Validators run in registration order; by default (false)
+// all of them run and their field errors are merged in that order. Enable it when a validator
+// relies on earlier ones having passed, e.g. a connectivity check after a DSN syntax check.
+// All field errors of the failing validator are reported. When enabled, validators run one
+// after another even with WithConcurrentValidators.
+func (l *Loader[T]) WithValidatorStopOnError(enabled bool) *Loader[T] {
+	l.valStop = enabled
+	return l
+}
+
+// WithWarningHandler sets a callback for non-fatal findings, such as deprecated fields being set.
+// Warnings are also logged at warn level when a logger is configured. Default: warnings are dropped.
+func (l *Loader[T]) WithWarningHandler(fn func(FieldWarning)) *Loader[T] {
+	l.onWarning = fn
+	return l
+}
+
+// WithBindHook sets a callback invoked for every field bound from a source or default, e.g. to
+// build field-level audit logs. Secret values are passed as "***redacted***"; Optional values are unwrapped.
+// The hook runs during Load before validation, so it also sees fields of configs that fail validation.
+func (l *Loader[T]) WithBindHook(fn func(fieldPath string, value any, source string)) *Loader[T] {
+	l.bindHook = fn
+	return l
+}
+
+// OnReload sets the callback Start invokes for each validated snapshot, starting with the
+// initial one (Version 1).
+func (l *Loader[T]) OnReload(fn func(snapshot Snapshot[T])) *Loader[T] {
+	l.onReload = fn
+	return l
+}
+
+// OnReloadError sets the callback Start invokes when a reload fails. The previous
+// configuration stays in effect.
+func (l *Loader[T]) OnReloadError(fn func(err error)) *Loader[T] {
+	l.onReloadEr = fn
+	return l
+}
+
+// WithValidationMode sets whether Load reports every field error (CollectAll, the default) or
+// only the first one (FailFast). With FailFast, Load returns after the first phase that finds an
+// error (unknown keys, binding, tag validation and deprecations, custom validators), so later
+// phases such as slow custom validators are skipped. Within a phase, binding and tag validation
+// stop at the first invalid field and serial custom validators at the first one that reports an error.
+func (l *Loader[T]) WithValidationMode(mode ValidationMode) *Loader[T] {
+	l.valMode = mode
+	return l
+}
+
+// WithDeprecationError makes setting a field tagged `deprecated` fail Load with ErrCodeDeprecated
+// instead of producing a warning. Useful for enforcing deprecations in CI. Default: false (warn only).
+func (l *Loader[T]) WithDeprecationError(enabled bool) *Loader[T] {
+	l.deprecErr = enabled
+	return l
+}
+
+// WithTreatEmptyAsUnset makes Load drop empty-string values from every source, as if the
+// source did not provide the key, so that lower-precedence sources, WithDefaults, or tag
+// defaults apply instead. This changes override behavior: an empty value can no longer
+// clear a value set by an earlier source. Defaults, WithBaseConfig, and context overrides are
+// not affected. For a single environment source, see sourceenv.Options.TreatEmptyAsUnset.
+// Default: false (an empty string is bound like any other value).
+func (l *Loader[T]) WithTreatEmptyAsUnset(enabled bool) *Loader[T] {
+	l.emptyUnset = enabled
+	return l
+}
+
+// WithPointerStructs makes Load treat *Struct fields like nested structs: the struct is allocated
+// and bound (defaults, validation, strict keys) as soon as any key below the field is provided,
+// and the field stays nil otherwise. Without it, *Struct fields are leaves, so keys such as
+// "database.host" for `Database *DB` are unknown in strict mode. Pointers to non-struct types
+// are always leaves. Default: false.
+func (l *Loader[T]) WithPointerStructs(enabled bool) *Loader[T] {
+	l.ptrStructs = enabled
+	return l
+}
+
+// WithReloadDiff makes Watch attach the changes since the previous snapshot to each reload
+// snapshot (Snapshot.Diff), e.g. to log exactly what changed. Secret values are redacted.
+func (l *Loader[T]) WithReloadDiff(enabled bool) *Loader[T] {
+	l.reloadDiff = enabled
+	return l
+}
+
+// WithStartupSnapshotDiffGate makes Load compare the loaded config against a baseline snapshot
+// and fail with ErrCriticalConfigChange if a critical key changed without an approval marker.
+// The gate guards startup only: once a Load passes it, later loads (including Watch, Start,
+// WatchInto, and Reload) skip it and do not read the baseline again.
+func (l *Loader[T]) WithStartupSnapshotDiffGate(gate DiffGate) *Loader[T] {
+	l.diffGate = &gate
+	return l
+}
+
+// WithTimeout bounds the total duration of each Load (including Watch reloads), on top of any
+// deadline of the caller's context; the shorter one applies. A source still loading when the
+// deadline passes fails Load with an error naming it and wrapping context.DeadlineExceeded,
+// even if the source ignores its context. Zero disables the timeout. Default: 0.
+func (l *Loader[T]) WithTimeout(d time.Duration) *Loader[T] {
+	l.timeout = d
+	return l
+}
+
+// WithClock sets the function that provides Snapshot.LoadedAt for the snapshots emitted by
+// Watch, Start, and WatchInto, e.g. a fixed time in tests. Durations (timeouts, metrics) still
+// use the real clock. Default: time.Now.
+func (l *Loader[T]) WithClock(now func() time.Time) *Loader[T] {
+	l.clock = now
+	return l
+}
+
+// now returns the current time from the clock set with WithClock.
+func (l *Loader[T]) now() time.Time {
+	if l.clock != nil {
+		return l.clock()
+	}
+	return time.Now()
+}
+
+// Load loads, merges, binds, and validates configuration from all sources.
+// Returns populated config or ValidationError with all field errors.
+func (l *Loader[T]) Load(ctx context.Context) (*T, error) {
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+		defer cancel()
+	}
+
+	// Steps 0-4: Check the struct definition, load and merge sources, and bind
+	b, err := l.bind(ctx, bindOptions{mode: l.valMode})
+	if err != nil {
+		return nil, err
+	}
+	cfg, cfgValue, provenanceFields, ignoredKeys, bindErrors := b.cfg, b.value, b.provenance, b.ignored, b.errors
+	for _, field := range provenanceFields {
+		l.logDebug(ctx, "field bound", "field", field.FieldPath, "key", field.KeyPath, "source", field.SourceName)
+		if l.bindHook != nil {
+			l.bindHook(field.FieldPath, boundValue(cfgValue, field), field.SourceName)
+		}
+	}
+
+	if l.valMode == FailFast && len(bindErrors) > 0 {
+		return nil, l.firstError(ctx, bindErrors)
+	}
+
+	// Step 5: Validate struct (tag-based validation)
+	validationErrors := validateStruct(cfgValue, boundFields(provenanceFields), l.valMode)
+
+	// Merge binding and validation errors
+	allErrors := append(bindErrors, validationErrors...)
+
+	// Report deprecated fields that were set by a source
+	allErrors = append(allErrors, l.checkDeprecated(ctx, provenanceFields)...)
+	if l.valMode == FailFast && len(allErrors) > 0 {
+		return nil, l.firstError(ctx, allErrors)
+	}
+
+	// Step 6: Run custom validators
+	customErrors, err := l.runValidators(ctx, cfg, cfgValue)
+	if err != nil {
+		return nil, err
+	}
+	if l.valMode == FailFast && len(customErrors) > 0 {
+		return nil, l.firstError(ctx, customErrors)
+	}
+	allErrors = append(allErrors, customErrors...)
+
+	// Step 7: Return error if any validation failed
+	l.logValidation(ctx, allErrors)
+	if len(allErrors) > 0 {
+		return nil, &ValidationError{FieldErrors: allErrors}
+	}
+
+	// Step 8: Store provenance for the config instance
+	l.applyMaskRule(provenanceFields)
+	prov := &Provenance{Fields: provenanceFields, Ignored: ignoredKeys}
+	storeProvenance(cfg, prov)
+
+	// Step 9: Compare against the baseline snapshot until a load has passed the diff gate
+	if l.diffGate != nil && !l.gatePassed.Load() {
+		if err := l.checkDiffGate(cfg); err != nil {
+			deleteProvenance(cfg)
+			return nil, err
+		}
+		l.gatePassed.Store(true)
+	}
+
+	// Step 10: Record the fingerprint of the loaded configuration.
+	// A config that cannot be fingerprinted still loads; ConfigHash then reports "".
+	hash, err := configHash(cfgValue)
+	if err != nil {
+		l.logDebug(ctx, "config hash failed", "error", err)
+		hash = ""
+	}
+	l.hashMu.Lock()
+	l.hash = hash
+	l.hashMu.Unlock()
+	if l.freeze {
+		prov.checksum = hash
+	}
+
+	// Step 11: Return the loaded configuration
+	return cfg, nil
+}
+
+// MissingRequired loads and binds configuration like Load and returns the sorted field paths
+// of required fields that nothing supplied, e.g. to prompt for them in a setup wizard.
+// Other validation rules and custom validators are not run, and binding errors are ignored
+// (such fields were supplied). Errors that stop Load before binding, such as a failing source
+// or an unknown key in strict mode, are returned.
+func (l *Loader[T]) MissingRequired(ctx context.Context) ([]string, error) {
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+		defer cancel()
+	}
+
+	b, err := l.bind(ctx, bindOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, fe := range validateStruct(b.value, boundFields(b.provenance), CollectAll) {
+		if fe.Code == ErrCodeRequired {
+			missing = append(missing, fe.FieldPath)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// LoadPartial loads configuration like Load but keeps going past errors, for tools such as a
+// config explorer that show whatever could be loaded. Fields whose values fail to convert keep
+// their zero value, failing sources are skipped, and unknown keys in strict mode are reported
+// without stopping. All errors are collected in the returned ValidationError, which is nil
+// only if Load would succeed; errors not tied to a field (such as a failing source) have code
+// ErrCodeLoad and an empty FieldPath.
+//
+// The config is never nil. It has provenance for the successfully bound fields only, but is
+// otherwise not treated as loaded: ConfigHash and the startup diff gate are not updated, and
+// WithValidationMode is ignored. A config struct that fails its schema checks (see Check)
+// yields a zero config.
+func (l *Loader[T]) LoadPartial(ctx context.Context) (*T, *ValidationError) {
+	if l.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.timeout)
+		defer cancel()
+	}
+
+	b, err := l.bind(ctx, bindOptions{partial: true})
+	if err != nil {
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			valErr = &ValidationError{FieldErrors: []FieldError{{Code: ErrCodeLoad, Message: err.Error()}}}
+		}
+		return new(T), valErr
+	}
+
+	allErrors := append(b.errors, validateStruct(b.value, boundFields(b.provenance), CollectAll)...)
+	allErrors = append(allErrors, l.checkDeprecated(ctx, b.provenance)...)
+
+	customErrors, err := l.runValidators(ctx, b.cfg, b.value)
+	if err != nil {
+		customErrors = append(customErrors, FieldError{Code: ErrCodeLoad, Message: err.Error()})
+	}
+	allErrors = append(allErrors, customErrors...)
+
+	l.applyMaskRule(b.provenance)
+	storeProvenance(b.cfg, &Provenance{Fields: b.provenance, Ignored: b.ignored})
+
+	l.logValidation(ctx, allErrors)
+	if len(allErrors) > 0 {
+		return b.cfg, &ValidationError{FieldErrors: allErrors}
+	}
+	return b.cfg, nil
+}
+
+// applyMaskRule sets the WithMaskRule rule on partially masked fields.
+func (l *Loader[T]) applyMaskRule(provenanceFields []FieldProvenance) {
+	if l.maskRule == nil {
+		return
+	}
+	for i := range provenanceFields {
+		if provenanceFields[i].mask != nil {
+			provenanceFields[i].mask = l.maskRule
+		}
+	}
+}
+
+// firstError logs and returns a ValidationError with only the first of fieldErrors (FailFast).
+func (l *Loader[T]) firstError(ctx context.Context, fieldErrors []FieldError) error {
+	fieldErrors = fieldErrors[:1]
+	l.logValidation(ctx, fieldErrors)
+	return &ValidationError{FieldErrors: fieldErrors}
+}
+
+// boundConfig is a new config bound from the merged sources, before validation.
+type boundConfig[T any] struct {
+	cfg        *T
+	value      reflect.Value
+	provenance []FieldProvenance
+	ignored    []IgnoredKey
+	errors     []FieldError // Binding errors, reported together with validation errors
+}
+
+// bindOptions adjusts how bind handles errors and what it records.
+type bindOptions struct {
+	trace   *mergeTrace    // Records every value offered for one key during the merge (Explain), nil if unused
+	partial bool           // Collect source, secret file, and unknown-key errors and keep binding (LoadPartial)
+	mode    ValidationMode // FailFast stops binding at the first error (Load only)
+}
+
+// bind checks the struct definition, loads and merges all sources, detects unknown keys,
+// and binds the result to a new config. Schema, source, and unknown-key errors are returned
+// as errors, unless opts.partial is set; binding errors are collected in the result.
+func (l *Loader[T]) bind(ctx context.Context, opts bindOptions) (*boundConfig[T], error) {
+	trace := opts.trace
+
+	// Step 0: Reject struct definitions that nest too deeply, where several fields share
+	// a key path, or where a default violates its own field's constraints
+	cfgType := reflect.TypeOf((*T)(nil)).Elem()
+	if schemaErrors := checkSchema(cfgType, l.effectiveMaxDepth(), l.ptrStructs); len(schemaErrors) > 0 {
+		l.logValidation(ctx, schemaErrors)
+		return nil, &ValidationError{FieldErrors: schemaErrors}
+	}
+
+	// Step 1: Load from all sources and merge
+	mergedData := make(map[string]mergedEntry)
+	chainEntries := make(map[string]map[string]mergedEntry) // Key -> source name -> entry, for fallback chains
+
+	shapes := newShapeTracker() // Detects scalar vs map conflicts between sources
+
+	strictKeys := make(map[string]bool) // Keys provided by a layer other than a lenient source
+
+	var partialErrors []FieldError // Errors collected instead of returned (opts.partial)
+
+	// Defaults embedded in the config type (DefaultProvider) form the lowest layer
+	embedded, err := embeddedDefaults[T]()
+	if err != nil {
+		if !opts.partial {
+			return nil, err
+		}
+		partialErrors = append(partialErrors, FieldError{Code: ErrCodeLoad, Message: err.Error()})
+	}
+	for key, value := range embedded {
+		mergedData[key] = mergedEntry{value: value, sourceName: embeddedDefaultSource, sourceKey: embeddedDefaultSource}
+		trace.offer(key, mergedData[key])
+		strictKeys[key] = true
+		shapes.add(key, value, -3, embeddedDefaultSource)
+	}
+
+	// Loader defaults sit above embedded defaults
+	for key, value := range l.defaults {
+		mergedData[strings.ToLower(key)] = mergedEntry{value: value, sourceName: loaderDefaultSource, sourceKey: loaderDefaultSource}
+		trace.offer(strings.ToLower(key), mergedData[strings.ToLower(key)])
+		strictKeys[strings.ToLower(key)] = true
+		shapes.add(strings.ToLower(key), value, -1, loaderDefaultSource)
+	}
+
+	// The base config sits above loader defaults
+	for key, value := range l.base {
+		mergedData[key] = mergedEntry{value: value, sourceName: baseConfigSource, sourceKey: baseConfigSource}
+		trace.offer(key, mergedData[key])
+		strictKeys[key] = true
+		shapes.add(key, value, -2, baseConfigSource)
+	}
+
+	for i, source := range l.sources {
+		if _, ok := source.(*derivedSource); ok {
+			continue // Runs once everything else is merged (see applyDerived)
+		}
+
+		start := time.Now()
+		loaded, err := l.loadSource(ctx, source)
+		if err != nil {
+			l.logDebug(ctx, "source load failed", "source", source.Name(), "error", err)
+			if opts.partial {
+				partialErrors = append(partialErrors, FieldError{
+					Code:    ErrCodeLoad,
+					Message: fmt.Sprintf("load source %s: %v", source.Name(), err),
+				})
+				continue
+			}
+			return nil, fmt.Errorf("load source %s: %w", source.Name(), err)
+		}
+		l.logDebug(ctx, "source loaded", "source", source.Name(), "keys", len(loaded.data), "duration", time.Since(start))
+
+		// Merge data into mergedData map
+		// Later sources override earlier ones
+		for key, value := range loaded.data {
+			// Normalize key to lowercase dot-separated path
+			normalizedKey := strings.ToLower(key)
+
+			if s, ok := value.(string); ok && s == "" && l.emptyUnset {
+				l.logDebug(ctx, "empty value treated as unset", "key", normalizedKey, "source", source.Name())
+				continue
+			}
+
+			// Determine source key for provenance
+			sourceKey := source.Name()
+			if loaded.originalKeys != nil {
+				origKey, ok := loaded.originalKeys[key]
+				if !ok {
+					origKey, ok = loaded.originalKeys[normalizedKey]
+				}
+				if ok {
+					// For env vars, use the full variable name (e.g., "env:APP_DATABASE__PASSWORD")
+					// For files, just use the filename (e.g., "file:config.yaml")
+					if strings.HasPrefix(source.Name(), "env") {
+						sourceKey = "env:" + origKey
+					}
+					// Sources can report a full provenance key per entry, e.g. the file of each
+					// key in sourcek8s ("file:/etc/config/port") or the SSM parameter name
+					// ("ssm:/myapp/database/host"). It survives wrappers such as RetrySource.
+					if isProvenanceKey(origKey) {
+						sourceKey = origKey
+					}
+				}
+			}
+
+			shapes.add(normalizedKey, value, i, source.Name())
+			if !l.sourceOpts[i].lenient {
+				strictKeys[normalizedKey] = true
+			}
+
+			if previous, ok := mergedData[normalizedKey]; ok {
+				l.logDebug(ctx, "key overridden", "key", normalizedKey, "previous", previous.sourceName, "source", source.Name())
+			}
+
+			mergedData[normalizedKey] = mergedEntry{
+				value:      value,
+				sourceName: source.Name(),
+				sourceKey:  sourceKey,
+				layer:      l.sourceOpts[i].tag,
+				line:       loaded.lines[key],
+				secret:     loaded.secrets[key],
+			}
+			trace.offer(normalizedKey, mergedData[normalizedKey])
+
+			if _, ok := l.fallbacks[normalizedKey]; ok {
+				if chainEntries[normalizedKey] == nil {
+					chainEntries[normalizedKey] = make(map[string]mergedEntry)
+				}
+				chainEntries[normalizedKey][source.Name()] = mergedData[normalizedKey]
+			}
+		}
+	}
+
+	// Overrides from the context (WithContextOverrides) rank above every source
+	overrides := contextOverrides(ctx)
+	for key, value := range overrides {
+		shapes.add(key, value, len(l.sources), contextOverrideSource)
+	}
+
+	// Sources disagreeing on whether a key is a map or a scalar would otherwise
+	// surface as confusing conversion errors during binding
+	if conflictErrors := shapes.errors(); len(conflictErrors) > 0 {
+		l.logValidation(ctx, conflictErrors)
+		return nil, &ValidationError{FieldErrors: conflictErrors}
+	}
+
+	// Apply per-key fallback chains over the global order
+	for key, entries := range chainEntries {
+		for _, name := range l.fallbacks[key] {
+			if entry, ok := entries[name]; ok {
+				l.logDebug(ctx, "fallback chain applied", "key", key, "source", name)
+				mergedData[key] = entry
+				break
+			}
+		}
+	}
+
+	// Profile-qualified keys (key@profile) override their base key (WithProfile)
+	if l.profiles {
+		if profileErrors := l.applyProfile(ctx, cfgType, mergedData, strictKeys, trace); len(profileErrors) > 0 {
+			if !opts.partial {
+				l.logValidation(ctx, profileErrors)
+				return nil, &ValidationError{FieldErrors: profileErrors}
+			}
+			partialErrors = append(partialErrors, profileErrors...)
+		}
+	}
+
+	for key, value := range overrides {
+		if previous, ok := mergedData[key]; ok {
+			l.logDebug(ctx, "key overridden", "key", key, "previous", previous.sourceName, "source", contextOverrideSource)
+		}
+		mergedData[key] = mergedEntry{value: value, sourceName: contextOverrideSource, sourceKey: contextOverrideSource}
+		trace.offer(key, mergedData[key])
+		strictKeys[key] = true
+	}
+
+	// Read secrets from files named by "<key>_file" (e.g. APP_DB_PASSWORD_FILE=/run/secrets/db)
+	if secretErrors := l.resolveSecretFiles(ctx, mergedData); len(secretErrors) > 0 {
+		if !opts.partial {
+			l.logValidation(ctx, secretErrors)
+			return nil, &ValidationError{FieldErrors: secretErrors}
+		}
+		partialErrors = append(partialErrors, secretErrors...)
+	}
+
+	// Derived sources compute keys from the values merged so far
+	l.applyDerived(ctx, mergedData, strictKeys, trace)
+
+	// Resolve ${key} references between values (WithInterpolation)
+	if l.interp {
+		if interpErrors := interpolate(cfgType, mergedData, l.missingRef); len(interpErrors) > 0 {
+			if !opts.partial {
+				l.logValidation(ctx, interpErrors)
+				return nil, &ValidationError{FieldErrors: interpErrors}
+			}
+			partialErrors = append(partialErrors, interpErrors...)
+		}
+	}
+	trace.decide(mergedData)
+
+	// Step 2: Detect unknown keys (errors in strict mode, unless exempted by IgnoreKeys
+	// or provided only by lenient sources)
+	var ignoredKeys []IgnoredKey
+	if l.strict || len(l.ignoreKeys) > 0 || l.hasLenientSource() {
+		// Get all valid field keys from the struct
+		var cfg T
+		validKeys := collectValidKeys(reflect.TypeOf(cfg), "", l.ptrStructs)
+		mapKeys := collectMapKeys(reflect.TypeOf(cfg), "", l.ptrStructs)
+
+		// Check for unknown keys
+		var unknownKeyErrors []FieldError
+		for key, entry := range mergedData {
+			if isValidKey(key, validKeys, mapKeys) {
+				continue
+			}
+			if l.isIgnoredKey(key) || !strictKeys[key] {
+				ignoredKeys = append(ignoredKeys, IgnoredKey{KeyPath: key, SourceName: entry.sourceName})
+				continue
+			}
+			if l.strict {
+				unknownKeyErrors = append(unknownKeyErrors, FieldError{
+					FieldPath: key,
+					Code:      ErrCodeUnknownKey,
+					Message:   "unknown configuration key (strict mode)",
+				})
+			}
+		}
+		sort.Slice(ignoredKeys, func(i, j int) bool {
+			return ignoredKeys[i].KeyPath < ignoredKeys[j].KeyPath
+		})
+		for _, ignored := range ignoredKeys {
+			l.logDebug(ctx, "key ignored", "key", ignored.KeyPath, "source", ignored.SourceName)
+		}
+
+		if len(unknownKeyErrors) > 0 && opts.partial {
+			sort.Slice(unknownKeyErrors, func(i, j int) bool {
+				return unknownKeyErrors[i].FieldPath < unknownKeyErrors[j].FieldPath
+			})
+			partialErrors = append(partialErrors, unknownKeyErrors...)
+		} else if len(unknownKeyErrors) > 0 {
+			if l.valMode == FailFast {
+				// Map iteration order is random; report the first key in sorted order
+				sort.Slice(unknownKeyErrors, func(i, j int) bool {
+					return unknownKeyErrors[i].FieldPath < unknownKeyErrors[j].FieldPath
+				})
+				unknownKeyErrors = unknownKeyErrors[:1]
+			}
+			l.logValidation(ctx, unknownKeyErrors)
+			return nil, &ValidationError{FieldErrors: unknownKeyErrors}
+		}
+	}
+
+	// Step 3: Create zero instance of T
+	cfg := new(T)
+	cfgValue := reflect.ValueOf(cfg).Elem()
+
+	// Step 4: Bind struct fields from merged data
+	var provenanceFields []FieldProvenance
+	bindErrors := bindStruct(cfgValue, mergedData, &provenanceFields, "", "", l.ptrStructs, opts.mode)
+
+	return &boundConfig[T]{
+		cfg:        cfg,
+		value:      cfgValue,
+		provenance: provenanceFields,
+		ignored:    ignoredKeys,
+		errors:     append(partialErrors, bindErrors...),
+	}, nil
+}
+
+// loadedSource is the result of loading one source.
+type loadedSource struct {
+	data         map[string]any
+	originalKeys map[string]string // Normalized -> original key, nil if not tracked
+	lines        map[string]int    // Key -> line, nil if not tracked
+	secrets      map[string]bool   // Keys the source marked secret, nil if none
+}
+
+// isProvenanceKey reports whether an original key is a full provenance key of the form
+// "<scheme>:<location>", where scheme is lowercase letters (e.g. "file:/etc/config/port").
+func isProvenanceKey(key string) bool {
+	scheme, _, ok := strings.Cut(key, ":")
+	if !ok || scheme == "" {
+		return false
+	}
+	for _, r := range scheme {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// wrappingSource is implemented by sources that wrap another source (RetrySource, CacheSource, ...),
+// so that the inner source's positions and secrets both pass through.
+type wrappingSource interface {
+	loadAll(ctx context.Context) (loadedSource, error)
+}
+
+// loadFrom loads source through the richest interface it implements.
+func loadFrom(ctx context.Context, source Source) (loaded loadedSource, err error) {
+	switch src := source.(type) {
+	case wrappingSource:
+		return src.loadAll(ctx)
+	case SourceWithPositions:
+		loaded.data, loaded.originalKeys, loaded.lines, err = src.LoadWithPositions(ctx)
+	case SourceWithSecrets:
+		loaded.data, loaded.originalKeys, loaded.secrets, err = src.LoadWithSecrets(ctx)
+	case SourceWithKeys:
+		loaded.data, loaded.originalKeys, err = src.LoadWithKeys(ctx)
+	default:
+		loaded.data, err = source.Load(ctx)
+	}
+	return loaded, err
+}
+
+// loadSource loads one source, using SourceWithPositions, SourceWithSecrets, or SourceWithKeys
+// when implemented for better provenance. With WithTimeout, the source runs in its own goroutine
+// so that Load returns at the deadline even if the source ignores its context.
+func (l *Loader[T]) loadSource(ctx context.Context, source Source) (loadedSource, error) {
+	load := func() (loadedSource, error) {
+		return loadFrom(ctx, source)
+	}
+
+	if l.timeout <= 0 {
+		return load()
+	}
+
+	type result struct {
+		loaded loadedSource
+		err    error
+	}
+	done := make(chan result, 1) // Buffered so an abandoned source does not block forever
+	go func() {
+		loaded, err := load()
+		done <- result{loaded: loaded, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.loaded, r.err
+	case <-ctx.Done():
+		return loadedSource{}, ctx.Err()
+	}
+}
+
+// runValidators runs the custom validators and returns their field errors in registration order.
+// Warnings reported with Warn are delivered afterwards, also in registration order.
+// A validator returning a non-ValidationError aborts with that error. With FailFast or
+// WithValidatorStopOnError, validators after the first one reporting field errors are skipped.
+func (l *Loader[T]) runValidators(ctx context.Context, cfg *T, cfgValue reflect.Value) ([]FieldError, error) {
+	stopOnError := l.valStop || l.valMode == FailFast
+
+	results := make([]validatorResult, len(l.validators))
+	fingerprints := make([]string, len(l.validators))
+	pending := make([]int, 0, len(l.validators))
+
+	// Resolve cached results first; only the remaining validators run
+	var values map[string]any // Flattened values for the validation cache, computed lazily
+	for i, validator := range l.validators {
+		if keyed, ok := validator.(KeyedValidator[T]); ok && l.valCache != nil {
+			if values == nil {
+				values = flattenValues(cfgValue)
+			}
+			fingerprints[i] = validatorFingerprint(values, keyed.DependsOn())
+			if cached, ok := l.valCache.lookup(i, fingerprints[i]); ok {
+				l.logDebug(ctx, "validator skipped (cached)", "validator", i)
+				results[i] = cached
+				continue
+			}
+		}
+		pending = append(pending, i)
+	}
+
+	errs := make([]error, len(l.validators))
+	stopped := false // The remaining validators were skipped (stopOnError)
+	if l.concurrent && len(pending) > 1 && !l.valStop {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		var once sync.Once
+		var firstErr error
+		for _, i := range pending {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				var err error
+				if results[i], err = l.runValidator(ctx, i, cfg); err != nil {
+					// Report the error that triggered cancellation, not the ones it caused
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}(i)
+		}
+		wg.Wait()
+		if firstErr != nil {
+			return nil, firstErr
+		}
+	} else {
+		for _, i := range pending {
+			if results[i], errs[i] = l.runValidator(ctx, i, cfg); errs[i] != nil {
+				break
+			}
+			if stopOnError && len(results[i].fieldErrors) > 0 {
+				stopped = true
+				break
+			}
+		}
+	}
+
+	ran := len(l.validators) // Validators whose results count
+	var fieldErrors []FieldError
+	for i := range l.validators {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		fieldErrors = append(fieldErrors, results[i].fieldErrors...)
+		if stopOnError && len(results[i].fieldErrors) > 0 {
+			ran = i + 1
+			break
+		}
+	}
+	for _, result := range results[:ran] {
+		for _, w := range result.warnings {
+			l.warn(ctx, w)
+		}
+	}
+
+	// Only cache results once every validator has succeeded (and has run)
+	if l.valCache != nil && !stopped {
+		for _, i := range pending {
+			if _, ok := l.validators[i].(KeyedValidator[T]); ok {
+				l.valCache.store(i, fingerprints[i], results[i])
+			}
+		}
+	}
+
+	return fieldErrors, nil
+}
+
+// runValidator runs validator i and returns its field errors and the warnings it reported.
+func (l *Loader[T]) runValidator(ctx context.Context, i int, cfg *T) (validatorResult, error) {
+	collector := &warningCollector{}
+	err := l.validators[i].Validate(context.WithValue(ctx, warningCollectorKey{}, collector), cfg)
+	result := validatorResult{warnings: collector.collected()}
+	if err == nil {
+		return result, nil
+	}
+
+	// Check if it's a ValidationError
+	if valErr, ok := err.(*ValidationError); ok {
+		result.fieldErrors = valErr.FieldErrors
+		return result, nil
+	}
+
+	// Wrap other errors as validation errors
+	l.logDebug(ctx, "validator failed", "validator", i)
+	return validatorResult{}, fmt.Errorf("validator %d failed: %w", i, err)
+}
+
+// checkDeprecated reports fields tagged `deprecated` whose value came from a source (defaults and the base config are ignored).
+// Findings are returned as errors when WithDeprecationError is enabled, otherwise emitted as warnings.
+func (l *Loader[T]) checkDeprecated(ctx context.Context, provenanceFields []FieldProvenance) []FieldError {
+	deprecated := collectDeprecatedKeys(reflect.TypeOf((*T)(nil)).Elem(), "", l.ptrStructs)
+	if len(deprecated) == 0 {
+		return nil
+	}
+
+	var fieldErrors []FieldError
+	for _, field := range provenanceFields {
+		hint, ok := deprecated[field.KeyPath]
+		if !ok || !field.Explicit {
+			continue
+		}
+
+		message := fmt.Sprintf("key %q is deprecated", field.KeyPath)
+		if hint != "" {
+			message += ": " + hint
+		}
+
+		if l.deprecErr {
+			fieldErrors = append(fieldErrors, FieldError{FieldPath: field.FieldPath, Code: ErrCodeDeprecated, Message: message})
+		} else {
+			l.warn(ctx, FieldWarning{FieldPath: field.FieldPath, Code: ErrCodeDeprecated, Message: message})
+		}
+	}
+	return fieldErrors
+}
+
+// warn delivers a warning to the warning handler and the logger, if configured.
+// Only the field path and code are logged since messages may contain values.
+func (l *Loader[T]) warn(ctx context.Context, w FieldWarning) {
+	if l.logger != nil {
+		l.logger.WarnContext(ctx, "config warning", "field", w.FieldPath, "code", w.Code)
+	}
+	if l.onWarning != nil {
+		l.onWarning(w)
+	}
+}
+
+// ConfigHash returns a stable fingerprint of the config from the last successful Load
+// (including Watch reloads), or "" if nothing was loaded yet. See configHash.
+func (l *Loader[T]) ConfigHash() string {
+	l.hashMu.Lock()
+	defer l.hashMu.Unlock()
+	return l.hash
+}
+
+// isIgnoredKey reports whether key matches one of the IgnoreKeys entries.
+func (l *Loader[T]) isIgnoredKey(key string) bool {
+	for _, ignored := range l.ignoreKeys {
+		if key == ignored || strings.HasPrefix(key, ignored+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLenientSource reports whether any source was added with WithLenientSource.
+func (l *Loader[T]) hasLenientSource() bool {
+	for _, opts := range l.sourceOpts {
+		if opts.lenient {
+			return true
+		}
+	}
+	return false
+}
+
+// Sources returns the names of the registered sources in precedence order (later override earlier).
+func (l *Loader[T]) Sources() []string {
+	names := make([]string, len(l.sources))
+	for i, source := range l.sources {
+		names[i] = source.Name()
+	}
+	return names
+}
+
+// HasSource reports whether a source with the given name is registered.
+func (l *Loader[T]) HasSource(name string) bool {
+	for _, source := range l.sources {
+		if source.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// logDebug logs a debug message if a logger is configured.
+func (l *Loader[T]) logDebug(ctx context.Context, msg string, args ...any) {
+	if l.logger != nil {
+		l.logger.DebugContext(ctx, msg, args...)
+	}
+}
+
+// logValidation logs the validation outcome.
+// Only field paths and codes are logged since messages may contain values.
+func (l *Loader[T]) logValidation(ctx context.Context, fieldErrors []FieldError) {
+	if l.logger == nil {
+		return
+	}
+	if len(fieldErrors) == 0 {
+		l.logDebug(ctx, "validation passed")
+		return
+	}
+	for _, fe := range fieldErrors {
+		l.logDebug(ctx, "validation error", "field", fe.FieldPath, "code", fe.Code)
+	}
+	l.logDebug(ctx, "validation failed", "errors", len(fieldErrors))
+}
+
+// checkDiffGate snapshots cfg and checks it against the configured diff gate.
+func (l *Loader[T]) checkDiffGate(cfg *T) error {
+	snapshot, err := CreateSnapshot(cfg)
+	if err != nil {
+		return err
 	}
+	return l.diffGate.check(snapshot)
 }
 
-// WithSource adds a source. Sources are processed in order (later override earlier).
-func (l *Loader[T]) WithSource(src Source) *Loader[T] {
-	l.sources = append(l.sources, src)
-	return l
+// Reload loads the configuration again and returns it with the changes since previous,
+// for pull-style reloads such as an admin endpoint. Secret values in the diff are redacted
+// as in DiffConfigs. On failure, the error is returned and previous is left as is.
+// A nil previous reports every key as added.
+func (l *Loader[T]) Reload(ctx context.Context, previous *T) (*T, *ConfigDiff, error) {
+	cfg, err := l.Load(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	diff, err := DiffConfigs(previous, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, diff, nil
 }
 
-// WithValidator adds a custom validator (executed after tag-based validation).
-func (l *Loader[T]) WithValidator(v Validator[T]) *Loader[T] {
-	l.validators = append(l.validators, v)
-	return l
+// Watch monitors sources for changes and auto-reloads configuration.
+// Returns: snapshots channel, errors channel, initial load error.
+// Changes are debounced (100ms). Built-in sources don't support watching yet.
+func (l *Loader[T]) Watch(ctx context.Context) (<-chan Snapshot[T], <-chan error, error) {
+	// Load initial configuration
+	initialCfg, err := l.Load(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("initial load failed: %w", err)
+	}
+
+	// Create channels for snapshots and errors
+	snapshotCh := make(chan Snapshot[T])
+	errorCh := make(chan error)
+
+	// Start watch goroutine
+	go l.watchLoop(ctx, initialCfg, snapshotCh, errorCh)
+
+	return snapshotCh, errorCh, nil
 }
 
-// Strict controls whether unknown keys cause errors. Default: true.
-func (l *Loader[T]) Strict(strict bool) *Loader[T] {
-	l.strict = strict
-	return l
+// Start watches like Watch and invokes the OnReload and OnReloadError callbacks instead of
+// returning channels. Callbacks run serially on the calling goroutine, so a callback is never
+// invoked while another is still running. Start blocks until ctx is cancelled and then returns
+// nil; it returns an error right away if the initial load fails.
+func (l *Loader[T]) Start(ctx context.Context) error {
+	snapshots, errs, err := l.Watch(ctx)
+	if err != nil {
+		return err
+	}
+
+	for snapshots != nil || errs != nil {
+		select {
+		case snapshot, ok := <-snapshots:
+			if !ok {
+				snapshots = nil
+				continue
+			}
+			if l.onReload != nil {
+				l.onReload(snapshot)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if l.onReloadEr != nil {
+				l.onReloadEr(err)
+			}
+		}
+	}
+
+	// The watch loop also ends when no source supports watching
+	<-ctx.Done()
+	return nil
 }
 
-// Load loads, merges, binds, and validates configuration from all sources.
-// Returns populated config or ValidationError with all field errors.
-func (l *Loader[T]) Load(ctx context.Context) (*T, error) {
-	// Step 1: Load from all sources and merge
-	mergedData := make(map[string]mergedEntry)
+// WatchInto watches like Watch and stores each validated configuration in ptr, so readers
+// using ptr.Load() only ever see a fully loaded config. The initial config is stored before
+// WatchInto returns. Failed reloads leave ptr unchanged and are sent on the returned channel,
+// which must be drained and is closed when watching stops.
+func (l *Loader[T]) WatchInto(ctx context.Context, ptr *atomic.Pointer[T]) (<-chan error, error) {
+	if ptr == nil {
+		return nil, errors.New("rigging: WatchInto requires a non-nil pointer")
+	}
 
-	for _, source := range l.sources {
-		var data map[string]any
-		var originalKeys map[string]string
-		var err error
+	snapshots, errs, err := l.Watch(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		// Check if source implements SourceWithKeys for better provenance
-		if sourceWithKeys, ok := source.(SourceWithKeys); ok {
-			data, originalKeys, err = sourceWithKeys.LoadWithKeys(ctx)
-		} else {
-			data, err = source.Load(ctx)
-			originalKeys = nil
+	// Watch always emits the initial snapshot first
+	initial, ok := <-snapshots
+	if !ok {
+		return nil, fmt.Errorf("initial load failed: %w", ctx.Err())
+	}
+	ptr.Store(initial.Config)
+
+	errorCh := make(chan error)
+	go func() {
+		defer close(errorCh)
+		for snapshots != nil || errs != nil {
+			select {
+			case snapshot, ok := <-snapshots:
+				if !ok {
+					snapshots = nil
+					continue
+				}
+				ptr.Store(snapshot.Config)
+			case err, ok := <-errs:
+				if !ok {
+					errs = nil
+					continue
+				}
+				select {
+				case errorCh <- err:
+				case <-ctx.Done():
+				}
+			}
 		}
+	}()
 
-		if err != nil {
-			return nil, fmt.Errorf("load source %s: %w", source.Name(), err)
+	return errorCh, nil
+}
+
+// collectValidKeys recursively collects all valid configuration keys from a struct type.
+// It returns a map of valid keys for use in strict mode validation.
+func collectValidKeys(t reflect.Type, prefix string, pointerStructs bool) map[string]bool {
+	validKeys := make(map[string]bool)
+	walkKeys(t, prefix, pointerStructs, func(keyPath string, _ reflect.StructField) {
+		validKeys[keyPath] = true
+	})
+	return validKeys
+}
+
+// effectiveMaxDepth returns the configured nesting limit, defaulted and capped at maxWalkDepth.
+func (l *Loader[T]) effectiveMaxDepth() int {
+	if l.maxDepth <= 0 {
+		return defaultMaxDepth
+	}
+	return min(l.maxDepth, maxWalkDepth)
+}
+
+// checkDepth reports the first struct field nested more than maxDepth levels deep.
+// It runs before the other walkers and stops at the first violation, so even types that
+// branch into themselves (Left, Right *Node) are rejected quickly.
+func checkDepth(t reflect.Type, maxDepth int, pointerStructs bool) []FieldError {
+	var walk func(t reflect.Type, parentFieldPath string, depth int) []FieldError
+	walk = func(t reflect.Type, parentFieldPath string, depth int) []FieldError {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil
 		}
 
-		// Merge data into mergedData map
-		// Later sources override earlier ones
-		for key, value := range data {
-			// Normalize key to lowercase dot-separated path
-			normalizedKey := strings.ToLower(key)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
 
-			// Determine source key for provenance
-			sourceKey := source.Name()
-			if originalKeys != nil {
-				if origKey, ok := originalKeys[normalizedKey]; ok {
-					// For env vars, use the full variable name (e.g., "env:APP_DATABASE__PASSWORD")
-					// For files, just use the filename (e.g., "file:config.yaml")
-					if strings.HasPrefix(source.Name(), "env") {
-						sourceKey = "env:" + origKey
-					}
-					// For files, sourceKey remains just source.Name() (e.g., "file:config.yaml")
+			// Embedded structs share the parent's level
+			if isPromotedStruct(field, parseTag(field.Tag.Get("conf"))) {
+				if errs := walk(field.Type, parentFieldPath, depth); errs != nil {
+					return errs
 				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if !isNestedStructType(field.Type, pointerStructs) {
+				continue
 			}
 
-			mergedData[normalizedKey] = mergedEntry{
-				value:      value,
-				sourceName: source.Name(),
-				sourceKey:  sourceKey,
+			fieldPath := field.Name
+			if parentFieldPath != "" {
+				fieldPath = parentFieldPath + "." + field.Name
+			}
+			if depth >= maxDepth {
+				return []FieldError{{
+					FieldPath: fieldPath,
+					Code:      ErrCodeConfigSchema,
+					Message:   fmt.Sprintf("struct nesting exceeds the maximum depth of %d (see WithMaxDepth)", maxDepth),
+				}}
+			}
+
+			fieldType := field.Type
+			if isOptionalType(fieldType) {
+				fieldType = fieldType.Field(0).Type
+			}
+			if errs := walk(fieldType, fieldPath, depth+1); errs != nil {
+				return errs
 			}
 		}
+		return nil
 	}
+	return walk(t, "", 0)
+}
 
-	// Step 2: In strict mode, detect unknown keys
-	if l.strict {
-		// Get all valid field keys from the struct
-		var cfg T
-		validKeys := collectValidKeys(reflect.TypeOf(cfg), "")
+// checkKeyCollisions reports leaf fields that resolve to the same key path, e.g. through
+// `name:` or `prefix:` tags. Such fields would silently share one value.
+func checkKeyCollisions(t reflect.Type, pointerStructs bool) []FieldError {
+	var errs []FieldError
+	owners := make(map[string]string) // Key path -> field path of the first field using it
+	walkFieldKeys(t, "", "", pointerStructs, func(keyPath, fieldPath string, field reflect.StructField) {
+		if isNestedStructType(field.Type, pointerStructs) {
+			return // Nested structs are checked through their leaf fields
+		}
+		if owner, ok := owners[keyPath]; ok {
+			errs = append(errs, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeConfigSchema,
+				Message:   fmt.Sprintf("key %q is already bound to field %s", keyPath, owner),
+			})
+			return
+		}
+		owners[keyPath] = fieldPath
+	})
+	return errs
+}
 
-		// Check for unknown keys
-		var unknownKeyErrors []FieldError
-		for key := range mergedData {
-			if !validKeys[key] {
-				unknownKeyErrors = append(unknownKeyErrors, FieldError{
-					FieldPath: key,
-					Code:      ErrCodeUnknownKey,
-					Message:   "unknown configuration key (strict mode)",
-				})
+// checkDefaults reports `default:` values that cannot be converted to their field's type
+// or that fail the field's own min, max, or oneof constraints.
+func checkDefaults(t reflect.Type, pointerStructs bool) []FieldError {
+	var errs []FieldError
+	walkFieldKeys(t, "", "", pointerStructs, func(_, fieldPath string, field reflect.StructField) {
+		tagCfg := parseTag(field.Tag.Get("conf"))
+		if !tagCfg.hasDefault || isNestedStructType(field.Type, pointerStructs) {
+			return
+		}
+
+		converted, err := convertFieldValue(tagCfg.defValue, field.Type, tagCfg)
+		if err != nil {
+			message := fmt.Sprintf("invalid default %q: %v", tagCfg.defValue, err)
+			if tagCfg.secret {
+				message = "invalid default ***redacted***"
 			}
+			errs = append(errs, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeConfigSchema,
+				Message:   message,
+			})
+			return
 		}
 
-		if len(unknownKeyErrors) > 0 {
-			return nil, &ValidationError{FieldErrors: unknownKeyErrors}
+		value := reflect.New(field.Type).Elem()
+		value.Set(reflect.ValueOf(converted))
+		if isOptionalType(field.Type) {
+			value = value.Field(0)
 		}
+		normalizeStrings(value, tagCfg)
+
+		tagCfg.required = false // A default always provides a value
+		for _, fe := range validateField(value, fieldPath, tagCfg) {
+			errs = append(errs, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeConfigSchema,
+				Message:   fmt.Sprintf("default %s violates its own %s constraint: %s", displayValue(tagCfg, fmt.Sprintf("%q", tagCfg.defValue)), fe.Code, fe.Message),
+			})
+		}
+	})
+	return errs
+}
+
+// isNestedStructType reports whether walkKeys recurses into fields of type t.
+// *Struct fields are nested structs only with pointerStructs (see WithPointerStructs).
+func isNestedStructType(t reflect.Type, pointerStructs bool) bool {
+	if isStructPointer(t) {
+		return pointerStructs
 	}
+	if isOptionalType(t) {
+		t = t.Field(0).Type
+		return t.Kind() == reflect.Struct
+	}
+	return t.Kind() == reflect.Struct && t.PkgPath() != "time"
+}
 
-	// Step 3: Create zero instance of T
-	cfg := new(T)
-	cfgValue := reflect.ValueOf(cfg).Elem()
+// keyShape records the first source that supplied a key path, and whether as a map or a scalar.
+type keyShape struct {
+	source int // Index in Loader.sources, -1 for WithDefaults, -2 for WithBaseConfig, len(sources) for context overrides
+	name   string
+	isMap  bool
+}
 
-	// Step 4: Bind struct fields from merged data
-	var provenanceFields []FieldProvenance
-	bindErrors := bindStruct(cfgValue, mergedData, &provenanceFields, "", "")
+// shapeTracker detects sources that supply incompatible shapes for the same key path:
+// a scalar in one source and a map (or keys below it) in another.
+type shapeTracker struct {
+	shapes    map[string]keyShape
+	conflicts map[string]FieldError
+}
 
-	// Step 5: Validate struct (tag-based validation)
-	validationErrors := validateStruct(cfgValue)
+func newShapeTracker() *shapeTracker {
+	return &shapeTracker{
+		shapes:    make(map[string]keyShape),
+		conflicts: make(map[string]FieldError),
+	}
+}
 
-	// Merge binding and validation errors
-	allErrors := append(bindErrors, validationErrors...)
+// add records a key supplied by a source. Every parent path of the key
+// (e.g. "database" for "database.host") is recorded as a map. A nil value
+// (e.g. an empty "database:" section in YAML) fits either shape and is not recorded.
+func (s *shapeTracker) add(key string, value any, source int, name string) {
+	if value != nil {
+		s.record(key, keyShape{source: source, name: name, isMap: reflect.TypeOf(value).Kind() == reflect.Map})
+	}
+	for i := strings.LastIndex(key, "."); i > 0; i = strings.LastIndex(key[:i], ".") {
+		s.record(key[:i], keyShape{source: source, name: name, isMap: true})
+	}
+}
 
-	// Step 6: Run custom validators
-	for i, validator := range l.validators {
-		err := validator.Validate(ctx, cfg)
-		if err != nil {
-			// Check if it's a ValidationError
-			if valErr, ok := err.(*ValidationError); ok {
-				allErrors = append(allErrors, valErr.FieldErrors...)
-			} else {
-				// Wrap other errors as validation errors
-				return nil, fmt.Errorf("validator %d failed: %w", i, err)
-			}
-		}
+// record compares shape with the first shape seen for key. Differences within one source are ignored.
+func (s *shapeTracker) record(key string, shape keyShape) {
+	first, ok := s.shapes[key]
+	if !ok {
+		s.shapes[key] = shape
+		return
+	}
+	if first.isMap == shape.isMap || first.source == shape.source {
+		return
+	}
+	if _, reported := s.conflicts[key]; reported {
+		return
 	}
 
-	// Step 7: Return error if any validation failed
-	if len(allErrors) > 0 {
-		return nil, &ValidationError{FieldErrors: allErrors}
+	mapSource, scalarSource := first.name, shape.name
+	if !first.isMap {
+		mapSource, scalarSource = shape.name, first.name
 	}
+	s.conflicts[key] = FieldError{
+		FieldPath: key,
+		Code:      ErrCodeTypeConflict,
+		Message:   fmt.Sprintf("source %q provides a map but source %q provides a scalar value", mapSource, scalarSource),
+	}
+}
 
-	// Step 8: Store provenance for the config instance
-	storeProvenance(cfg, &Provenance{Fields: provenanceFields})
+// errors returns the detected conflicts sorted by key path.
+func (s *shapeTracker) errors() []FieldError {
+	if len(s.conflicts) == 0 {
+		return nil
+	}
 
-	// Step 9: Return the loaded configuration
-	return cfg, nil
+	errs := make([]FieldError, 0, len(s.conflicts))
+	for _, fe := range s.conflicts {
+		errs = append(errs, fe)
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].FieldPath < errs[j].FieldPath
+	})
+	return errs
 }
 
-// Watch monitors sources for changes and auto-reloads configuration.
-// Returns: snapshots channel, errors channel, initial load error.
-// Changes are debounced (100ms). Built-in sources don't support watching yet.
-func (l *Loader[T]) Watch(ctx context.Context) (<-chan Snapshot[T], <-chan error, error) {
-	// Load initial configuration
-	initialCfg, err := l.Load(ctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("initial load failed: %w", err)
+// collectMapKeys collects the key paths of map fields.
+// Any key below a map field's key path (e.g. "features.beta" for "features") is valid.
+func collectMapKeys(t reflect.Type, prefix string, pointerStructs bool) []string {
+	var mapKeys []string
+	walkKeys(t, prefix, pointerStructs, func(keyPath string, field reflect.StructField) {
+		if field.Type.Kind() == reflect.Map {
+			mapKeys = append(mapKeys, keyPath)
+		}
+	})
+	return mapKeys
+}
+
+// boundValue returns the value of the field described by prov, redacted if secret.
+// Optional values are unwrapped; unset Optionals yield nil.
+func boundValue(cfgValue reflect.Value, prov FieldProvenance) any {
+	if prov.Secret {
+		return "***redacted***"
 	}
 
-	// Create channels for snapshots and errors
-	snapshotCh := make(chan Snapshot[T])
-	errorCh := make(chan error)
+	v := cfgValue
+	for _, name := range strings.Split(prov.FieldPath, ".") {
+		// FieldByName also resolves fields promoted from embedded structs
+		v = v.FieldByName(name)
+		if !v.IsValid() {
+			return nil
+		}
+	}
 
-	// Start watch goroutine
-	go l.watchLoop(ctx, initialCfg, snapshotCh, errorCh)
+	if isOptionalType(v.Type()) {
+		if !v.Field(1).Bool() {
+			return nil
+		}
+		v = v.Field(0)
+	}
+	return v.Interface()
+}
 
-	return snapshotCh, errorCh, nil
+// collectDeprecatedKeys returns the key paths of fields tagged `deprecated`, mapped to their hint.
+func collectDeprecatedKeys(t reflect.Type, prefix string, pointerStructs bool) map[string]string {
+	deprecated := make(map[string]string)
+	walkKeys(t, prefix, pointerStructs, func(keyPath string, field reflect.StructField) {
+		if tagCfg := parseTag(field.Tag.Get("conf")); tagCfg.deprecated {
+			deprecated[keyPath] = tagCfg.deprecMsg
+		}
+	})
+	return deprecated
 }
 
-// collectValidKeys recursively collects all valid configuration keys from a struct type.
-// It returns a map of valid keys for use in strict mode validation.
-func collectValidKeys(t reflect.Type, prefix string) map[string]bool {
-	validKeys := make(map[string]bool)
+// isValidKey reports whether key maps to a struct field or lies below a map field.
+func isValidKey(key string, validKeys map[string]bool, mapKeys []string) bool {
+	if validKeys[key] {
+		return true
+	}
+	for _, mapKey := range mapKeys {
+		if strings.HasPrefix(key, mapKey+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// walkKeys recursively walks a struct type and calls visit with the key path of every exported field.
+// Nested structs (including Optional[Struct], and *Struct with pointerStructs) are visited and then recursed into.
+func walkKeys(t reflect.Type, prefix string, pointerStructs bool, visit func(keyPath string, field reflect.StructField)) {
+	walkFieldKeys(t, prefix, "", pointerStructs, func(keyPath, _ string, field reflect.StructField) {
+		visit(keyPath, field)
+	})
+}
+
+// walkFieldKeys is walkKeys that also passes the field path (e.g. "Database.Host") of every field.
+// It does not descend more than maxWalkDepth levels. Walks of a whole config type (no prefix)
+// are computed once per type and replayed from cachedFields.
+func walkFieldKeys(t reflect.Type, prefix, parentFieldPath string, pointerStructs bool, visit func(keyPath, fieldPath string, field reflect.StructField)) {
+	if prefix == "" && parentFieldPath == "" {
+		for _, f := range cachedFields(t, pointerStructs) {
+			visit(f.keyPath, f.fieldPath, f.field)
+		}
+		return
+	}
+	walkFieldKeysUncached(t, prefix, parentFieldPath, pointerStructs, visit)
+}
+
+// walkFieldKeysUncached implements walkFieldKeys.
+func walkFieldKeysUncached(t reflect.Type, prefix, parentFieldPath string, pointerStructs bool, visit func(keyPath, fieldPath string, field reflect.StructField)) {
+	if fieldPathDepth(parentFieldPath) >= maxWalkDepth {
+		return
+	}
 
 	// Dereference pointer types
 	if t.Kind() == reflect.Ptr {
@@ -190,27 +1588,37 @@ func collectValidKeys(t reflect.Type, prefix string) map[string]bool {
 
 	// Only process struct types
 	if t.Kind() != reflect.Struct {
-		return validKeys
+		return
 	}
 
 	// Walk through all fields
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 
+		// Parse struct tag
+		tag := field.Tag.Get("conf")
+		tagCfg := parseTag(tag)
+
+		// Embedded structs contribute their keys to the parent scope
+		if isPromotedStruct(field, tagCfg) {
+			walkFieldKeys(field.Type, prefix, parentFieldPath, pointerStructs, visit)
+			continue
+		}
+
 		// Skip unexported fields
 		if !field.IsExported() {
 			continue
 		}
 
-		// Parse struct tag
-		tag := field.Tag.Get("conf")
-		tagCfg := parseTag(tag)
-
-		// Determine key path
+		// Determine key and field paths
 		keyPath := determineKeyPath(field.Name, tagCfg, prefix)
+		fieldPath := field.Name
+		if parentFieldPath != "" {
+			fieldPath = parentFieldPath + "." + field.Name
+		}
 
 		// Add this key as valid
-		validKeys[keyPath] = true
+		visit(keyPath, fieldPath, field)
 
 		// Handle nested structs
 		fieldType := field.Type
@@ -221,12 +1629,9 @@ func collectValidKeys(t reflect.Type, prefix string) map[string]bool {
 			innerType := fieldType.Field(0).Type
 			if innerType.Kind() == reflect.Struct {
 				// Recursively collect keys from nested struct
-				nestedKeys := collectValidKeys(innerType, keyPath)
-				for k := range nestedKeys {
-					validKeys[k] = true
-				}
+				walkFieldKeys(innerType, keyPath, fieldPath, pointerStructs, visit)
 			}
-		} else if fieldType.Kind() == reflect.Struct {
+		} else if fieldType.Kind() == reflect.Struct || (pointerStructs && isStructPointer(fieldType)) {
 			// Skip time.Time and time.Duration (they're structs but treated as primitives)
 			if fieldType.PkgPath() == "time" {
 				continue
@@ -239,14 +1644,9 @@ func collectValidKeys(t reflect.Type, prefix string) map[string]bool {
 			}
 
 			// Recursively collect keys from nested struct
-			nestedKeys := collectValidKeys(fieldType, nestedPrefix)
-			for k := range nestedKeys {
-				validKeys[k] = true
-			}
+			walkFieldKeys(fieldType, nestedPrefix, fieldPath, pointerStructs, visit)
 		}
 	}
-
-	return validKeys
 }
 
 // watchLoop is the main goroutine that monitors sources for changes and reloads configuration.
@@ -257,10 +1657,11 @@ func (l *Loader[T]) watchLoop(ctx context.Context, initialCfg *T, snapshotCh cha
 
 	// Emit initial snapshot
 	currentVersion := int64(1)
+	previousCfg := initialCfg
 	snapshotCh <- Snapshot[T]{
 		Config:   initialCfg,
 		Version:  currentVersion,
-		LoadedAt: time.Now(),
+		LoadedAt: l.now(),
 		Source:   "initial",
 	}
 
@@ -300,8 +1701,10 @@ func (l *Loader[T]) watchLoop(ctx context.Context, initialCfg *T, snapshotCh cha
 		return
 	}
 
-	// Create a debounce timer
+	// Debounce timer; reloads run in this goroutine when it fires, so they never overlap
 	var debounceTimer *time.Timer
+	var debounceC <-chan time.Time
+	var cause string
 	const debounceDelay = 100 * time.Millisecond
 
 	// Merge all change channels into one
@@ -361,55 +1764,80 @@ func (l *Loader[T]) watchLoop(ctx context.Context, initialCfg *T, snapshotCh cha
 	}()
 
 	// Main watch loop
+	changes := (<-chan ChangeEvent)(mergedChanges)
 	for {
+		if changes == nil && debounceC == nil {
+			return
+		}
+
 		select {
 		case <-ctx.Done():
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+			}
 			// Cancel all source watchers
 			for _, cancel := range cancelFuncs {
 				cancel()
 			}
 			return
 
-		case event, ok := <-mergedChanges:
+		case event, ok := <-changes:
 			if !ok {
-				// All change channels closed
-				return
+				// All change channels closed; finish a pending reload first
+				if debounceC == nil {
+					return
+				}
+				changes = nil
+				continue
 			}
 
-			// Capture the cause to avoid closure issues with loop variable
-			cause := event.Cause
-
-			// Debounce: reset timer on each event
+			// Debounce: restart the timer on each event; the reload reports the latest cause
+			cause = event.Cause
 			if debounceTimer != nil {
 				debounceTimer.Stop()
 			}
+			debounceTimer = time.NewTimer(debounceDelay)
+			debounceC = debounceTimer.C
 
-			debounceTimer = time.AfterFunc(debounceDelay, func() {
-				// Reload configuration
-				newCfg, err := l.Load(ctx)
-				if err != nil {
-					// Send error, keep previous config
-					select {
-					case errorCh <- fmt.Errorf("reload failed: %w", err):
-					case <-ctx.Done():
-					}
-					return
-				}
+		case <-debounceC:
+			debounceC = nil
 
-				// Increment version and emit new snapshot
-				currentVersion++
-				snapshot := Snapshot[T]{
-					Config:   newCfg,
-					Version:  currentVersion,
-					LoadedAt: time.Now(),
-					Source:   cause,
-				}
+			// Reload configuration
+			start := time.Now()
+			newCfg, err := l.Load(ctx)
+			l.metrics.ObserveReloadDuration(time.Since(start))
+			if err != nil {
+				l.metrics.IncReloadError(cause)
 
+				// Send error, keep previous config
 				select {
-				case snapshotCh <- snapshot:
+				case errorCh <- fmt.Errorf("reload failed: %w", err):
 				case <-ctx.Done():
 				}
-			})
+				continue
+			}
+
+			l.metrics.IncReload(cause)
+
+			// Increment version and emit new snapshot
+			currentVersion++
+			snapshot := Snapshot[T]{
+				Config:   newCfg,
+				Version:  currentVersion,
+				LoadedAt: l.now(),
+				Source:   cause,
+			}
+			if l.reloadDiff {
+				// T is always a struct here since Load succeeded
+				snapshot.Diff, _ = DiffConfigs(previousCfg, newCfg)
+			}
+			snapshot.ChangedKeys = changedKeys(previousCfg, newCfg)
+			previousCfg = newCfg
+
+			select {
+			case snapshotCh <- snapshot:
+			case <-ctx.Done():
+			}
 		}
 	}
 }
@@ -0,0 +1,101 @@
+package rigging
+
+import (
+	"context"
+	"testing"
+)
+
+// benchLoadConfig has 1000 fields whose keys are derived from field names,
+// so every field has its own key (e.g. section1.suba.field1).
+type benchLoadConfig struct {
+	Section1  benchLoadSection
+	Section2  benchLoadSection
+	Section3  benchLoadSection
+	Section4  benchLoadSection
+	Section5  benchLoadSection
+	Section6  benchLoadSection
+	Section7  benchLoadSection
+	Section8  benchLoadSection
+	Section9  benchLoadSection
+	Section10 benchLoadSection
+}
+
+type benchLoadSection struct {
+	SubA benchLoadSubSection
+	SubB benchLoadSubSection
+	SubC benchLoadSubSection
+	SubD benchLoadSubSection
+	SubE benchLoadSubSection
+}
+
+type benchLoadSubSection struct {
+	Field1  string
+	Field2  string
+	Field3  string
+	Field4  string
+	Field5  string
+	Field6  int
+	Field7  int
+	Field8  int
+	Field9  int
+	Field10 int
+	Field11 bool
+	Field12 bool
+	Field13 bool
+	Field14 bool
+	Field15 bool
+	Field16 string `conf:"secret"`
+	Field17 string
+	Field18 string
+	Field19 string
+	Field20 string
+}
+
+func newBenchLoadConfig() *benchLoadConfig {
+	section := func() benchLoadSection {
+		sub := func() benchLoadSubSection {
+			return benchLoadSubSection{
+				Field1: "value1", Field2: "value2", Field3: "value3",
+				Field4: "value4", Field5: "value5", Field6: 100, Field7: 200,
+				Field8: 300, Field9: 400, Field10: 500, Field11: true,
+				Field12: false, Field13: true, Field14: false, Field15: true,
+				Field16: "secret-value", Field17: "value17", Field18: "value18",
+				Field19: "value19", Field20: "value20",
+			}
+		}
+		return benchLoadSection{
+			SubA: sub(), SubB: sub(), SubC: sub(), SubD: sub(), SubE: sub(),
+		}
+	}
+	return &benchLoadConfig{
+		Section1: section(), Section2: section(), Section3: section(),
+		Section4: section(), Section5: section(), Section6: section(),
+		Section7: section(), Section8: section(), Section9: section(),
+		Section10: section(),
+	}
+}
+
+// benchSourceData returns the flattened values of cfg, as a source would provide them.
+func benchSourceData[T any](b *testing.B, cfg *T) map[string]any {
+	b.Helper()
+	snapshot, err := CreateSnapshot(cfg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return snapshot.Config
+}
+
+// BenchmarkLoad_LargeConfig loads a 1000-field config repeatedly, as Watch does on reloads.
+// Struct metadata is computed on the first Load and reused afterwards.
+func BenchmarkLoad_LargeConfig(b *testing.B) {
+	loader := NewLoader[benchLoadConfig]().
+		WithSource(&mockSource{data: benchSourceData(b, newBenchLoadConfig())})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := loader.Load(context.Background()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
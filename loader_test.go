@@ -1,10 +1,16 @@
 package rigging
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -69,6 +75,119 @@ func TestWithSource(t *testing.T) {
 	}
 }
 
+// TestSources verifies that Sources and HasSource report registered sources in order.
+func TestSources(t *testing.T) {
+	loader := NewLoader[struct{}]()
+	if got := loader.Sources(); len(got) != 0 {
+		t.Errorf("expected no sources, got %v", got)
+	}
+
+	loader.
+		WithSource(&mockSource{name: "file:config.yaml"}).
+		WithSource(&mockSource{name: "env"})
+
+	if got, want := loader.Sources(), []string{"file:config.yaml", "env"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sources() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"env", true},
+		{"file:config.yaml", true},
+		{"vault", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := loader.HasSource(tt.name); got != tt.want {
+			t.Errorf("HasSource(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestWithSourceAt verifies that priorities decide precedence regardless of registration order.
+func TestWithSourceAt(t *testing.T) {
+	type Config struct {
+		Host string
+		Port int
+	}
+
+	source := func(name, host string) *mockSource {
+		return &mockSource{name: name, data: map[string]any{"host": host}}
+	}
+
+	tests := []struct {
+		name      string
+		build     func(l *Loader[Config]) *Loader[Config]
+		wantOrder []string
+		wantHost  string
+	}{
+		{
+			name: "higher priority wins regardless of call order",
+			build: func(l *Loader[Config]) *Loader[Config] {
+				return l.WithSourceAt(10, source("override", "o")).WithSourceAt(1, source("base", "b"))
+			},
+			wantOrder: []string{"base", "override"},
+			wantHost:  "o",
+		},
+		{
+			name: "ties are won by the later source",
+			build: func(l *Loader[Config]) *Loader[Config] {
+				return l.WithSourceAt(5, source("first", "1")).WithSourceAt(5, source("second", "2"))
+			},
+			wantOrder: []string{"first", "second"},
+			wantHost:  "2",
+		},
+		{
+			name: "mixed with WithSource",
+			build: func(l *Loader[Config]) *Loader[Config] {
+				return l.
+					WithSourceAt(100, source("flags", "f")).
+					WithSource(source("file", "x")).                          // priority 1
+					WithSource(source("env", "e")).                           // priority 2
+					WithSourceAt(-1, source("builtin", "d"), WithTag("base")) // below everything
+			},
+			wantOrder: []string{"builtin", "file", "env", "flags"},
+			wantHost:  "f",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := tt.build(NewLoader[Config]())
+			if got := loader.Sources(); !reflect.DeepEqual(got, tt.wantOrder) {
+				t.Errorf("Sources() = %v, want %v", got, tt.wantOrder)
+			}
+
+			cfg, err := loader.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			if cfg.Host != tt.wantHost {
+				t.Errorf("Host = %q, want %q", cfg.Host, tt.wantHost)
+			}
+		})
+	}
+
+	t.Run("tags stay with their source", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithSourceAt(2, &mockSource{name: "secrets", data: map[string]any{"host": "s"}}, WithTag("secrets")).
+			WithSourceAt(1, &mockSource{name: "file", data: map[string]any{"host": "f", "port": 80}}, WithTag("base")).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		prov, _ := GetProvenance(cfg)
+		if p := findProvenance(prov.Fields, "Host"); p == nil || p.Layer != "secrets" {
+			t.Errorf("Host provenance = %+v, want layer secrets", p)
+		}
+		if p := findProvenance(prov.Fields, "Port"); p == nil || p.Layer != "base" {
+			t.Errorf("Port provenance = %+v, want layer base", p)
+		}
+	})
+}
+
 // TestWithValidator verifies that WithValidator adds validators and returns the loader for chaining.
 func TestWithValidator(t *testing.T) {
 	loader := NewLoader[struct{}]()
@@ -413,6 +532,167 @@ func TestLoad_StrictMode(t *testing.T) {
 	}
 }
 
+// TestLoad_IgnoreKeys verifies that ignored keys are exempt from strict mode and reported in provenance.
+func TestLoad_IgnoreKeys(t *testing.T) {
+	type Config struct {
+		Host string
+	}
+
+	tests := []struct {
+		name        string
+		ignore      []string
+		data        map[string]any
+		wantErr     string // Unknown key expected in the error, empty for success
+		wantIgnored []string
+	}{
+		{
+			name:        "exact key",
+			ignore:      []string{"version"},
+			data:        map[string]any{"host": "localhost", "version": 2},
+			wantIgnored: []string{"version"},
+		},
+		{
+			name:        "prefix matches nested keys",
+			ignore:      []string{"Meta"},
+			data:        map[string]any{"host": "localhost", "meta.owner": "team-a", "meta.tags.env": "prod"},
+			wantIgnored: []string{"meta.owner", "meta.tags.env"},
+		},
+		{
+			name:    "prefix does not match sibling keys",
+			ignore:  []string{"meta"},
+			data:    map[string]any{"host": "localhost", "metadata": "x"},
+			wantErr: "metadata",
+		},
+		{
+			name:    "other unknown keys are still rejected",
+			ignore:  []string{"meta"},
+			data:    map[string]any{"host": "localhost", "meta.owner": "team-a", "hots": "typo"},
+			wantErr: "hots",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewLoader[Config]().
+				WithSource(&mockSource{name: "file", data: tt.data}).
+				IgnoreKeys(tt.ignore...).
+				Load(context.Background())
+
+			if tt.wantErr != "" {
+				valErr, ok := err.(*ValidationError)
+				if !ok {
+					t.Fatalf("expected ValidationError, got %v", err)
+				}
+				if len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].FieldPath != tt.wantErr {
+					t.Errorf("expected unknown key %q, got %+v", tt.wantErr, valErr.FieldErrors)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+
+			prov, ok := GetProvenance(cfg)
+			if !ok {
+				t.Fatal("provenance not found")
+			}
+			if len(prov.Ignored) != len(tt.wantIgnored) {
+				t.Fatalf("Ignored = %+v, want keys %v", prov.Ignored, tt.wantIgnored)
+			}
+			for i, key := range tt.wantIgnored {
+				if prov.Ignored[i].KeyPath != key || prov.Ignored[i].SourceName != "file" {
+					t.Errorf("Ignored[%d] = %+v, want key %q from file", i, prov.Ignored[i], key)
+				}
+			}
+		})
+	}
+
+	t.Run("reported in non-strict mode", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"meta.owner": "team-a", "other": "x"}}).
+			Strict(false).
+			IgnoreKeys("meta").
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		prov, _ := GetProvenance(cfg)
+		if len(prov.Ignored) != 1 || prov.Ignored[0].KeyPath != "meta.owner" {
+			t.Errorf("Ignored = %+v, want only meta.owner", prov.Ignored)
+		}
+	})
+}
+
+func TestLoad_WithLenientSource(t *testing.T) {
+	type Config struct {
+		Host string
+		Port int
+	}
+
+	tests := []struct {
+		name        string
+		strict      map[string]any
+		lenient     map[string]any
+		wantErr     string // Unknown key expected in the error, empty for success
+		wantIgnored []string
+	}{
+		{
+			name:        "extra keys from lenient source pass",
+			strict:      map[string]any{"host": "localhost"},
+			lenient:     map[string]any{"port": 8080, "vault.lease": "1h", "vault.path": "secret/app"},
+			wantIgnored: []string{"vault.lease", "vault.path"},
+		},
+		{
+			name:    "typo in strict source fails",
+			strict:  map[string]any{"host": "localhost", "prot": 8080},
+			lenient: map[string]any{"vault.lease": "1h"},
+			wantErr: "prot",
+		},
+		{
+			name:    "key also provided by strict source is checked",
+			strict:  map[string]any{"hots": "a"},
+			lenient: map[string]any{"hots": "b"},
+			wantErr: "hots",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewLoader[Config]().
+				WithSource(&mockSource{name: "file", data: tt.strict}).
+				WithLenientSource(&mockSource{name: "vault", data: tt.lenient}).
+				Load(context.Background())
+
+			if tt.wantErr != "" {
+				valErr, ok := err.(*ValidationError)
+				if !ok {
+					t.Fatalf("expected ValidationError, got %v", err)
+				}
+				if len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].FieldPath != tt.wantErr {
+					t.Errorf("expected unknown key %q, got %+v", tt.wantErr, valErr.FieldErrors)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if cfg.Port != 8080 {
+				t.Errorf("Port = %d, want 8080 from the lenient source", cfg.Port)
+			}
+
+			prov, _ := GetProvenance(cfg)
+			if len(prov.Ignored) != len(tt.wantIgnored) {
+				t.Fatalf("Ignored = %+v, want keys %v", prov.Ignored, tt.wantIgnored)
+			}
+			for i, key := range tt.wantIgnored {
+				if prov.Ignored[i].KeyPath != key || prov.Ignored[i].SourceName != "vault" {
+					t.Errorf("Ignored[%d] = %+v, want key %q from vault", i, prov.Ignored[i], key)
+				}
+			}
+		})
+	}
+}
+
 // TestLoad_Provenance verifies that provenance is stored for loaded config.
 func TestLoad_Provenance(t *testing.T) {
 	type Config struct {
@@ -515,6 +795,110 @@ func TestLoad_SourceError(t *testing.T) {
 	}
 }
 
+// TestLoad_MapField verifies that map fields bind from flattened keys and validate required keys.
+func TestLoad_MapField(t *testing.T) {
+	type Config struct {
+		Features map[string]bool `conf:"requiredkeys:beta,search,max:3"`
+	}
+
+	t.Run("flattened keys bind into map", func(t *testing.T) {
+		loader := NewLoader[Config]().WithSource(&mockSource{
+			name: "file:config.yaml",
+			data: map[string]any{
+				"features.beta":   true,
+				"features.search": "false",
+			},
+		})
+
+		cfg, err := loader.Load(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(cfg.Features, map[string]bool{"beta": true, "search": false}) {
+			t.Errorf("Features = %v", cfg.Features)
+		}
+
+		prov, ok := GetProvenance(cfg)
+		if !ok || len(prov.Fields) != 1 || prov.Fields[0].SourceName != "file:config.yaml" {
+			t.Errorf("unexpected provenance: %+v", prov)
+		}
+	})
+
+	t.Run("missing required key is reported", func(t *testing.T) {
+		loader := NewLoader[Config]().WithSource(&mockSource{
+			data: map[string]any{
+				"features": map[string]any{"beta": true},
+			},
+		})
+
+		_, err := loader.Load(context.Background())
+		valErr, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("expected *ValidationError, got %v", err)
+		}
+
+		if len(valErr.FieldErrors) != 1 {
+			t.Fatalf("expected 1 error, got %v", valErr.FieldErrors)
+		}
+		fe := valErr.FieldErrors[0]
+		if fe.Code != ErrCodeRequiredKeys || !strings.Contains(fe.Message, "search") {
+			t.Errorf("unexpected error: %+v", fe)
+		}
+	})
+}
+
+// TestLoad_WithLogger verifies that load events are logged without exposing values.
+func TestLoad_WithLogger(t *testing.T) {
+	type Config struct {
+		Host     string
+		Password string `conf:"secret"`
+		Port     int    `conf:"min:1024"`
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	loader := NewLoader[Config]().
+		WithSource(&mockSource{name: "file", data: map[string]any{"host": "localhost", "port": 80}}).
+		WithSource(&mockSource{name: "env", data: map[string]any{"host": "example.com", "password": "hunter2"}}).
+		WithLogger(logger)
+
+	_, err := loader.Load(context.Background())
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		"source loaded", "source=file", "source=env", "keys=2",
+		"key overridden", "key=host", "previous=file",
+		"field bound", "field=Password",
+		"validation error", "field=Port", "code=min",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected log output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	for _, value := range []string{"hunter2", "example.com", "localhost"} {
+		if strings.Contains(output, value) {
+			t.Errorf("log output must not contain value %q, got:\n%s", value, output)
+		}
+	}
+}
+
+// TestLoad_WithoutLogger verifies that Load is silent by default.
+func TestLoad_WithoutLogger(t *testing.T) {
+	loader := NewLoader[struct{ Host string }]()
+	if loader.logger != nil {
+		t.Error("logger should be nil by default")
+	}
+	if _, err := loader.Load(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 // watchableSource is a test helper that implements the Source interface with Watch support.
 type watchableSource struct {
 	name     string
@@ -680,6 +1064,53 @@ func TestWatch_ReloadOnChange(t *testing.T) {
 	}
 }
 
+func TestWatch_WithClock(t *testing.T) {
+	type Config struct {
+		Host string
+	}
+
+	source := newWatchableSource("test", map[string]any{"host": "localhost"})
+	defer source.close()
+
+	var mu sync.Mutex
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(time.Minute)
+		return now
+	}
+
+	loader := NewLoader[Config]().WithSource(source).WithClock(clock)
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	snapshots, errors, err := loader.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	for i, want := range []time.Time{
+		time.Date(2024, 1, 15, 12, 1, 0, 0, time.UTC),
+		time.Date(2024, 1, 15, 12, 2, 0, 0, time.UTC),
+	} {
+		if i > 0 {
+			source.updateData(map[string]any{"host": "example.com"})
+			source.triggerChange("test-change")
+		}
+		select {
+		case snapshot := <-snapshots:
+			if !snapshot.LoadedAt.Equal(want) {
+				t.Errorf("snapshot %d LoadedAt = %v, want %v", snapshot.Version, snapshot.LoadedAt, want)
+			}
+		case err := <-errors:
+			t.Fatalf("unexpected error: %v", err)
+		case <-time.After(1 * time.Second):
+			t.Fatal("timeout waiting for snapshot")
+		}
+	}
+}
+
 // TestWatch_ValidationError verifies that validation errors are sent to error channel.
 func TestWatch_ValidationError(t *testing.T) {
 	type Config struct {
@@ -735,85 +1166,494 @@ func TestWatch_ValidationError(t *testing.T) {
 	}
 }
 
-// TestWatch_ContextCancellation verifies that channels are closed when context is cancelled.
-func TestWatch_ContextCancellation(t *testing.T) {
-	type Config struct {
-		Host string
+func TestLoader_LoadPartial(t *testing.T) {
+	type Database struct {
+		Host string `conf:"required"`
+		Port int    `conf:"min:1024"`
 	}
+	type Config struct {
+		Name     string
+		Workers  int
+		Timeout  time.Duration
+		Database Database
+	}
+
+	t.Run("binds what it can", func(t *testing.T) {
+		cfg, valErr := NewLoader[Config]().
+			Strict(true).
+			WithSource(&mockSource{name: "file:config.yaml", data: map[string]any{
+				"name":          "api",
+				"workers":       "many",
+				"timeout":       "5s",
+				"database.port": 80,
+				"extra":         true,
+			}}).
+			WithSource(&mockSource{name: "vault", err: errors.New("connection refused")}).
+			LoadPartial(context.Background())
+
+		if cfg == nil {
+			t.Fatal("LoadPartial() returned nil config")
+		}
+		if cfg.Name != "api" || cfg.Timeout != 5*time.Second || cfg.Database.Port != 80 {
+			t.Errorf("cfg = %+v, want Name, Timeout, and Database.Port bound", cfg)
+		}
+		if cfg.Workers != 0 {
+			t.Errorf("Workers = %d, want zero value", cfg.Workers)
+		}
 
-	source := newWatchableSource("test", map[string]any{
-		"host": "localhost",
+		if valErr == nil {
+			t.Fatal("LoadPartial() returned nil error")
+		}
+		var codes []string
+		for _, fe := range valErr.FieldErrors {
+			codes = append(codes, fe.FieldPath+":"+fe.Code)
+		}
+		want := []string{":load", "extra:unknown_key", "Workers:invalid_type", "Database.Host:required", "Database.Port:min"}
+		if !reflect.DeepEqual(codes, want) {
+			t.Errorf("errors = %v, want %v", codes, want)
+		}
+
+		prov, ok := GetProvenance(cfg)
+		if !ok {
+			t.Fatal("GetProvenance() found no provenance")
+		}
+		var fields []string
+		for _, field := range prov.Fields {
+			fields = append(fields, field.FieldPath)
+		}
+		sort.Strings(fields)
+		if want := []string{"Database.Port", "Name", "Timeout"}; !reflect.DeepEqual(fields, want) {
+			t.Errorf("provenance fields = %v, want %v", fields, want)
+		}
 	})
-	defer source.close()
 
-	loader := NewLoader[Config]().WithSource(source)
-	ctx, cancel := context.WithCancel(context.Background())
+	t.Run("valid config", func(t *testing.T) {
+		cfg, valErr := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"database.host": "db", "database.port": 5432}}).
+			LoadPartial(context.Background())
+		if valErr != nil {
+			t.Fatalf("LoadPartial() error = %v", valErr)
+		}
+		if cfg.Database.Host != "db" {
+			t.Errorf("Database.Host = %q, want %q", cfg.Database.Host, "db")
+		}
+	})
 
-	snapshots, errors, err := loader.Watch(ctx)
-	if err != nil {
-		t.Fatalf("Watch failed: %v", err)
-	}
+	t.Run("invalid schema", func(t *testing.T) {
+		type Bad struct {
+			A string `conf:"name:key"`
+			B string `conf:"name:key"`
+		}
+		cfg, valErr := NewLoader[Bad]().LoadPartial(context.Background())
+		if cfg == nil {
+			t.Fatal("LoadPartial() returned nil config")
+		}
+		if valErr == nil || valErr.FieldErrors[0].Code != ErrCodeConfigSchema {
+			t.Errorf("LoadPartial() error = %v, want %s", valErr, ErrCodeConfigSchema)
+		}
+	})
+}
 
-	// Receive initial snapshot
-	select {
-	case <-snapshots:
-		// Initial snapshot received
-	case err := <-errors:
-		t.Fatalf("unexpected error: %v", err)
-	case <-time.After(1 * time.Second):
-		t.Fatal("timeout waiting for initial snapshot")
+func TestLoader_MissingRequired(t *testing.T) {
+	type Database struct {
+		Host     string `conf:"required"`
+		Password string `conf:"required,secret"`
+	}
+	type Config struct {
+		Name     string        `conf:"required"`
+		Region   string        `conf:"required,default:eu-west-1"`
+		Port     int           `conf:"required,min:1024"`
+		Token    Optional[int] `conf:"required"`
+		Debug    bool
+		Database Database
+	}
+
+	tests := []struct {
+		name string
+		data map[string]any
+		want []string
+	}{
+		{
+			name: "nothing supplied",
+			data: map[string]any{},
+			want: []string{"Database.Host", "Database.Password", "Name", "Port", "Token"},
+		},
+		{
+			name: "some supplied",
+			data: map[string]any{"name": "api", "database.host": "db.internal"},
+			want: []string{"Database.Password", "Port", "Token"},
+		},
+		{
+			name: "zero and invalid values count as supplied",
+			data: map[string]any{
+				"name": "", "port": 80, "token": 0,
+				"database.host": "db.internal", "database.password": "secret",
+			},
+			want: nil,
+		},
 	}
 
-	// Cancel context
-	cancel()
-
-	// Both channels should be closed
-	// Give some time for goroutines to clean up
-	time.Sleep(50 * time.Millisecond)
-
-	// Check that both channels are closed by trying to receive
-	select {
-	case _, ok := <-snapshots:
-		if ok {
-			t.Error("snapshot channel should be closed after context cancellation")
-		}
-	case <-time.After(500 * time.Millisecond):
-		t.Error("timeout: snapshot channel not closed")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing, err := NewLoader[Config]().
+				WithSource(&mockSource{name: "file", data: tt.data}).
+				MissingRequired(context.Background())
+			if err != nil {
+				t.Fatalf("MissingRequired failed: %v", err)
+			}
+			if !reflect.DeepEqual(missing, tt.want) {
+				t.Errorf("MissingRequired() = %v, want %v", missing, tt.want)
+			}
+		})
 	}
 
-	select {
-	case _, ok := <-errors:
-		if ok {
-			t.Error("error channel should be closed after context cancellation")
+	t.Run("source error", func(t *testing.T) {
+		sourceErr := errors.New("unavailable")
+		_, err := NewLoader[Config]().
+			WithSource(&mockSource{name: "file", err: sourceErr}).
+			MissingRequired(context.Background())
+		if !errors.Is(err, sourceErr) {
+			t.Errorf("expected source error, got %v", err)
 		}
-	case <-time.After(500 * time.Millisecond):
-		t.Error("timeout: error channel not closed")
-	}
+	})
 }
 
-// TestWatch_NoWatchableSource verifies behavior when no sources support watching.
-func TestWatch_NoWatchableSource(t *testing.T) {
+func TestLoader_WithValidationMode(t *testing.T) {
 	type Config struct {
-		Host string
-	}
-
-	source := &mockSource{
-		data: map[string]any{
-			"host": "localhost",
+		Port    int    `conf:"min:1024"`
+		Timeout int    `conf:"max:60"`
+		Host    string `conf:"required"`
+		Workers int
+	}
+
+	tests := []struct {
+		name         string
+		mode         *ValidationMode // nil keeps the default
+		data         map[string]any
+		wantFields   []string
+		wantValCalls int
+	}{
+		{
+			name:         "collect all by default",
+			data:         map[string]any{"port": 80, "timeout": 120, "workers": "many"},
+			wantFields:   []string{"Workers", "Port", "Timeout", "Host", "custom.first", "custom.second"},
+			wantValCalls: 2,
+		},
+		{
+			name:       "fail fast on binding",
+			mode:       ptrTo(FailFast),
+			data:       map[string]any{"port": 80, "timeout": 120, "workers": "many"},
+			wantFields: []string{"Workers"},
+		},
+		{
+			name:       "fail fast on tag validation",
+			mode:       ptrTo(FailFast),
+			data:       map[string]any{"port": 80, "timeout": 120},
+			wantFields: []string{"Port"},
+		},
+		{
+			name:         "fail fast on custom validators",
+			mode:         ptrTo(FailFast),
+			data:         map[string]any{"port": 8080, "host": "localhost"},
+			wantFields:   []string{"custom.first"},
+			wantValCalls: 1,
+		},
+		{
+			name:       "fail fast on unknown keys",
+			mode:       ptrTo(FailFast),
+			data:       map[string]any{"zzz": 1, "aaa": 2, "mmm": 3},
+			wantFields: []string{"aaa"},
 		},
 	}
 
-	loader := NewLoader[Config]().WithSource(source)
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valCalls := 0
+			failing := func(field string) Validator[Config] {
+				return ValidatorFunc[Config](func(ctx context.Context, cfg *Config) error {
+					valCalls++
+					return &ValidationError{FieldErrors: []FieldError{{FieldPath: field, Code: "custom", Message: "invalid"}}}
+				})
+			}
 
-	snapshots, errors, err := loader.Watch(ctx)
-	if err != nil {
-		t.Fatalf("Watch failed: %v", err)
+			loader := NewLoader[Config]().
+				WithSource(&mockSource{data: tt.data}).
+				WithValidator(failing("custom.first")).
+				WithValidator(failing("custom.second"))
+			if tt.mode != nil {
+				loader.WithValidationMode(*tt.mode)
+			}
+
+			_, err := loader.Load(context.Background())
+			valErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			var fields []string
+			for _, fe := range valErr.FieldErrors {
+				fields = append(fields, fe.FieldPath)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("error fields = %v, want %v", fields, tt.wantFields)
+			}
+			if valCalls != tt.wantValCalls {
+				t.Errorf("custom validators called %d times, want %d", valCalls, tt.wantValCalls)
+			}
+		})
 	}
+}
 
-	// Should receive initial snapshot
-	select {
+// ptrTo returns a pointer to v.
+func ptrTo[V any](v V) *V {
+	return &v
+}
+
+// TestWatchInto verifies that only validated configs are stored and reload errors are forwarded.
+func TestWatchInto(t *testing.T) {
+	type Config struct {
+		Host string `conf:"required"`
+		Port int    `conf:"min:1024"`
+	}
+
+	source := newWatchableSource("test", map[string]any{"host": "localhost", "port": 8080})
+	defer source.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var current atomic.Pointer[Config]
+	errs, err := NewLoader[Config]().WithSource(source).WatchInto(ctx, &current)
+	if err != nil {
+		t.Fatalf("WatchInto failed: %v", err)
+	}
+	if cfg := current.Load(); cfg == nil || cfg.Port != 8080 {
+		t.Fatalf("initial config = %+v, want port 8080 stored before WatchInto returns", cfg)
+	}
+
+	// A valid reload replaces the config
+	source.updateData(map[string]any{"host": "localhost", "port": 9090})
+	source.triggerChange("valid-change")
+	deadline := time.Now().Add(time.Second)
+	for current.Load().Port != 9090 {
+		if time.Now().After(deadline) {
+			t.Fatalf("config not replaced after valid reload, got %+v", current.Load())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	good := current.Load()
+
+	// An invalid reload is reported and leaves the config unchanged
+	source.updateData(map[string]any{"port": 80})
+	source.triggerChange("invalid-change")
+	select {
+	case err := <-errs:
+		if err == nil || !strings.Contains(err.Error(), "reload failed") {
+			t.Errorf("expected reload error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for reload error")
+	}
+	if current.Load() != good {
+		t.Errorf("config replaced by invalid reload: %+v", current.Load())
+	}
+
+	cancel()
+	select {
+	case _, ok := <-errs:
+		if ok {
+			t.Error("expected error channel to be closed after cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for error channel to close")
+	}
+}
+
+// TestStart verifies that Start invokes the reload callbacks serially until ctx is cancelled.
+func TestStart(t *testing.T) {
+	type Config struct {
+		Port int `conf:"min:1024"`
+	}
+
+	source := newWatchableSource("test", map[string]any{"port": 8080})
+	defer source.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var running atomic.Int32
+	enter := func() {
+		if running.Add(1) > 1 {
+			t.Error("callbacks ran concurrently")
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+	}
+
+	events := make(chan string, 10)
+	loader := NewLoader[Config]().
+		WithSource(source).
+		OnReload(func(snapshot Snapshot[Config]) {
+			enter()
+			events <- fmt.Sprintf("v%d port=%d", snapshot.Version, snapshot.Config.Port)
+		}).
+		OnReloadError(func(err error) {
+			enter()
+			events <- "error"
+		})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- loader.Start(ctx)
+	}()
+
+	next := func() string {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("timeout waiting for callback")
+			return ""
+		}
+	}
+
+	if got := next(); got != "v1 port=8080" {
+		t.Errorf("first callback = %q, want the initial snapshot", got)
+	}
+
+	source.updateData(map[string]any{"port": 80})
+	source.triggerChange("invalid-change")
+	if got := next(); got != "error" {
+		t.Errorf("callback = %q, want error", got)
+	}
+
+	source.updateData(map[string]any{"port": 9090})
+	source.triggerChange("valid-change")
+	if got := next(); got != "v2 port=9090" {
+		t.Errorf("callback = %q, want v2 port=9090", got)
+	}
+
+	select {
+	case err := <-done:
+		t.Fatalf("Start returned before cancellation: %v", err)
+	default:
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Start returned %v after cancellation, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Start did not return after cancellation")
+	}
+}
+
+func TestStart_InitialLoadFailure(t *testing.T) {
+	type Config struct {
+		Host string `conf:"required"`
+	}
+
+	called := false
+	err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{}}).
+		OnReload(func(Snapshot[Config]) { called = true }).
+		Start(context.Background())
+	if err == nil {
+		t.Fatal("expected initial load error")
+	}
+	if called {
+		t.Error("OnReload called although the initial load failed")
+	}
+}
+
+func TestWatchInto_NilPointer(t *testing.T) {
+	type Config struct{ Host string }
+	if _, err := NewLoader[Config]().WatchInto(context.Background(), nil); err == nil {
+		t.Fatal("expected error for nil pointer")
+	}
+}
+
+// TestWatch_ContextCancellation verifies that channels are closed when context is cancelled.
+func TestWatch_ContextCancellation(t *testing.T) {
+	type Config struct {
+		Host string
+	}
+
+	source := newWatchableSource("test", map[string]any{
+		"host": "localhost",
+	})
+	defer source.close()
+
+	loader := NewLoader[Config]().WithSource(source)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	snapshots, errors, err := loader.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	// Receive initial snapshot
+	select {
+	case <-snapshots:
+		// Initial snapshot received
+	case err := <-errors:
+		t.Fatalf("unexpected error: %v", err)
+	case <-time.After(1 * time.Second):
+		t.Fatal("timeout waiting for initial snapshot")
+	}
+
+	// Cancel context
+	cancel()
+
+	// Both channels should be closed
+	// Give some time for goroutines to clean up
+	time.Sleep(50 * time.Millisecond)
+
+	// Check that both channels are closed by trying to receive
+	select {
+	case _, ok := <-snapshots:
+		if ok {
+			t.Error("snapshot channel should be closed after context cancellation")
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("timeout: snapshot channel not closed")
+	}
+
+	select {
+	case _, ok := <-errors:
+		if ok {
+			t.Error("error channel should be closed after context cancellation")
+		}
+	case <-time.After(500 * time.Millisecond):
+		t.Error("timeout: error channel not closed")
+	}
+}
+
+// TestWatch_NoWatchableSource verifies behavior when no sources support watching.
+func TestWatch_NoWatchableSource(t *testing.T) {
+	type Config struct {
+		Host string
+	}
+
+	source := &mockSource{
+		data: map[string]any{
+			"host": "localhost",
+		},
+	}
+
+	loader := NewLoader[Config]().WithSource(source)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	snapshots, errors, err := loader.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	// Should receive initial snapshot
+	select {
 	case snapshot := <-snapshots:
 		if snapshot.Config.Host != "localhost" {
 			t.Errorf("expected Host=localhost, got %s", snapshot.Config.Host)
@@ -962,6 +1802,84 @@ done:
 	}
 }
 
+// slowWatchSource takes delay to load, returns an increasing counter, and records overlapping loads.
+type slowWatchSource struct {
+	*watchableSource
+	delay time.Duration
+
+	mu        sync.Mutex
+	loads     int
+	active    int
+	maxActive int
+}
+
+func (s *slowWatchSource) Load(ctx context.Context) (map[string]any, error) {
+	s.mu.Lock()
+	s.loads++
+	counter := s.loads
+	s.active++
+	if s.active > s.maxActive {
+		s.maxActive = s.active
+	}
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	s.active--
+	s.mu.Unlock()
+	return map[string]any{"counter": counter}, nil
+}
+
+// TestWatch_SlowReloadsDoNotOverlap verifies that reloads slower than the debounce delay
+// run one at a time and each snapshot's changes are relative to the previous snapshot.
+func TestWatch_SlowReloadsDoNotOverlap(t *testing.T) {
+	type Config struct {
+		Counter int
+	}
+
+	source := &slowWatchSource{watchableSource: newWatchableSource("slow", nil), delay: 150 * time.Millisecond}
+	defer source.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	snapshots, errs, err := NewLoader[Config]().WithSource(source).Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	<-snapshots // Initial snapshot
+
+	// The second change arrives while the first reload is still running
+	source.triggerChange("first")
+	time.Sleep(120 * time.Millisecond)
+	source.triggerChange("second")
+
+	previous := 1
+	for i := 0; i < 2; i++ {
+		select {
+		case snapshot := <-snapshots:
+			if snapshot.Config.Counter <= previous {
+				t.Errorf("snapshot %d: Counter = %d, want > %d", snapshot.Version, snapshot.Config.Counter, previous)
+			}
+			if !reflect.DeepEqual(snapshot.ChangedKeys, []string{"counter"}) {
+				t.Errorf("snapshot %d: ChangedKeys = %v, want [counter]", snapshot.Version, snapshot.ChangedKeys)
+			}
+			previous = snapshot.Config.Counter
+		case err := <-errs:
+			t.Fatalf("unexpected error: %v", err)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timeout waiting for snapshot %d", i+2)
+		}
+	}
+
+	source.mu.Lock()
+	defer source.mu.Unlock()
+	if source.maxActive != 1 {
+		t.Errorf("max concurrent loads = %d, want 1", source.maxActive)
+	}
+}
+
 // TestWatch_InitialLoadFailure verifies that Watch returns error if initial load fails.
 func TestWatch_InitialLoadFailure(t *testing.T) {
 	type Config struct {
@@ -1062,7 +1980,7 @@ func TestCollectValidKeys_SimpleStruct(t *testing.T) {
 		Port int
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	expectedKeys := []string{"host", "port"}
 	if len(validKeys) != len(expectedKeys) {
@@ -1083,7 +2001,7 @@ func TestCollectValidKeys_WithPrefix(t *testing.T) {
 		Port int
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "app")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "app", false)
 
 	expectedKeys := []string{"app.host", "app.port"}
 	if len(validKeys) != len(expectedKeys) {
@@ -1108,7 +2026,7 @@ func TestCollectValidKeys_NestedStruct(t *testing.T) {
 		Database Database `conf:"prefix:db"`
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	// Should have the database field itself plus nested keys
 	expectedKeys := []string{"database", "db.host", "db.port"}
@@ -1131,7 +2049,7 @@ func TestCollectValidKeys_UnexportedFields(t *testing.T) {
 		internal string // unexported
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	if len(validKeys) != 1 {
 		t.Fatalf("expected 1 key, got %d: %v", len(validKeys), validKeys)
@@ -1158,7 +2076,7 @@ func TestCollectValidKeys_PointerType(t *testing.T) {
 	}
 
 	// Pass pointer type
-	validKeys := collectValidKeys(reflect.TypeOf(&Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(&Config{}), "", false)
 
 	expectedKeys := []string{"host", "port"}
 	if len(validKeys) != len(expectedKeys) {
@@ -1175,19 +2093,19 @@ func TestCollectValidKeys_PointerType(t *testing.T) {
 // TestCollectValidKeys_NonStructType verifies that collectValidKeys returns empty map for non-struct types.
 func TestCollectValidKeys_NonStructType(t *testing.T) {
 	// Test with int
-	validKeys := collectValidKeys(reflect.TypeOf(42), "")
+	validKeys := collectValidKeys(reflect.TypeOf(42), "", false)
 	if len(validKeys) != 0 {
 		t.Errorf("expected 0 keys for int type, got %d", len(validKeys))
 	}
 
 	// Test with string
-	validKeys = collectValidKeys(reflect.TypeOf("test"), "")
+	validKeys = collectValidKeys(reflect.TypeOf("test"), "", false)
 	if len(validKeys) != 0 {
 		t.Errorf("expected 0 keys for string type, got %d", len(validKeys))
 	}
 
 	// Test with slice
-	validKeys = collectValidKeys(reflect.TypeOf([]int{}), "")
+	validKeys = collectValidKeys(reflect.TypeOf([]int{}), "", false)
 	if len(validKeys) != 0 {
 		t.Errorf("expected 0 keys for slice type, got %d", len(validKeys))
 	}
@@ -1200,7 +2118,7 @@ func TestCollectValidKeys_CustomName(t *testing.T) {
 		Port int    `conf:"name:port_number"`
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	expectedKeys := []string{"hostname", "port_number"}
 	if len(validKeys) != len(expectedKeys) {
@@ -1230,7 +2148,7 @@ func TestCollectValidKeys_TimeTypes(t *testing.T) {
 		Name      string
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	// All three should be valid keys (time types are treated as primitives)
 	expectedKeys := []string{"timestamp", "timeout", "name"}
@@ -1262,7 +2180,7 @@ func TestCollectValidKeys_DeeplyNestedStruct(t *testing.T) {
 		Database Database `conf:"prefix:db"`
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	expectedKeys := []string{
 		"database",
@@ -1295,7 +2213,7 @@ func TestCollectValidKeys_OptionalType(t *testing.T) {
 		Database Optional[Database] `conf:"prefix:db"`
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	// Should have the database field itself plus nested keys from Optional[Database]
 	// Note: For Optional types, the prefix tag is ignored and keyPath is used instead
@@ -1324,24 +2242,38 @@ func TestCollectValidKeys_PointerFields(t *testing.T) {
 		Database *Database `conf:"prefix:db"`
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
-
-	// Current implementation: pointer fields to structs are treated as leaf values (not recursed)
-	// This documents the actual behavior - pointer fields are not dereferenced
-	expectedKeys := []string{"name", "timeout", "database"}
-	if len(validKeys) != len(expectedKeys) {
-		t.Fatalf("expected %d keys, got %d: %v", len(expectedKeys), len(validKeys), validKeys)
+	tests := []struct {
+		name           string
+		pointerStructs bool
+		expectedKeys   []string
+	}{
+		{
+			// Pointer fields, including pointers to structs, are leaf values (not recursed)
+			name:         "default",
+			expectedKeys: []string{"name", "timeout", "database"},
+		},
+		{
+			// Pointer to struct fields are recursed into like non-pointer struct fields;
+			// other pointer fields are leaf values
+			name:           "pointer structs",
+			pointerStructs: true,
+			expectedKeys:   []string{"name", "timeout", "database", "db.host", "db.port"},
+		},
 	}
 
-	for _, key := range expectedKeys {
-		if !validKeys[key] {
-			t.Errorf("expected key %q to be valid", key)
-		}
-	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", tt.pointerStructs)
+			if len(validKeys) != len(tt.expectedKeys) {
+				t.Fatalf("expected %d keys, got %d: %v", len(tt.expectedKeys), len(validKeys), validKeys)
+			}
 
-	// Pointer to struct fields are NOT recursed into (unlike non-pointer struct fields)
-	if validKeys["db.host"] || validKeys["db.port"] {
-		t.Error("pointer to struct fields should not be recursed into")
+			for _, key := range tt.expectedKeys {
+				if !validKeys[key] {
+					t.Errorf("expected key %q to be valid", key)
+				}
+			}
+		})
 	}
 }
 
@@ -1354,7 +2286,7 @@ func TestCollectValidKeys_SliceAndMapFields(t *testing.T) {
 		Ports    []int
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	// Slices and maps should be treated as leaf values (not recursed into)
 	expectedKeys := []string{"hosts", "tags", "metadata", "ports"}
@@ -1376,7 +2308,7 @@ func TestCollectValidKeys_EmptyStructTag(t *testing.T) {
 		Port int
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	expectedKeys := []string{"host", "port"}
 	if len(validKeys) != len(expectedKeys) {
@@ -1401,7 +2333,7 @@ func TestCollectValidKeys_NameTakesPrecedenceOverPrefix(t *testing.T) {
 		Database Database `conf:"prefix:db"`
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	// The name tag should take precedence, so we get "db_host" not "db.host"
 	expectedKeys := []string{"database", "db_host", "db.port"}
@@ -1428,7 +2360,7 @@ func TestCollectValidKeys_AllUnexportedFields(t *testing.T) {
 		port int    // unexported
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	if len(validKeys) != 0 {
 		t.Fatalf("expected 0 keys for struct with only unexported fields, got %d: %v", len(validKeys), validKeys)
@@ -1439,7 +2371,7 @@ func TestCollectValidKeys_AllUnexportedFields(t *testing.T) {
 func TestCollectValidKeys_EmptyStruct(t *testing.T) {
 	type Config struct{}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	if len(validKeys) != 0 {
 		t.Fatalf("expected 0 keys for empty struct, got %d: %v", len(validKeys), validKeys)
@@ -1457,7 +2389,7 @@ func TestCollectValidKeys_PrefixWithDots(t *testing.T) {
 		Server Server `conf:"prefix:app.server"`
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	// Prefix with dots should be preserved
 	expectedKeys := []string{"server", "app.server.host", "app.server.port"}
@@ -1481,7 +2413,7 @@ func TestCollectValidKeys_CaseSensitivity(t *testing.T) {
 		UserName string
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	// All keys should be lowercase
 	expectedKeys := []string{"httpport", "apikey", "dbhost", "username"}
@@ -1520,7 +2452,7 @@ func TestCollectValidKeys_NestedOptionalTypes(t *testing.T) {
 		Database Optional[Database] `conf:"prefix:db"`
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	// Optional types should be unwrapped and recursed
 	expectedKeys := []string{
@@ -1561,7 +2493,7 @@ func TestCollectValidKeys_MixedFieldTypes(t *testing.T) {
 		DurationField time.Duration
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	expectedKeys := []string{
 		"stringfield",
@@ -1595,7 +2527,7 @@ func TestCollectValidKeys_PrefixOnNonStructField(t *testing.T) {
 		Port int    `conf:"prefix:server"` // prefix should be ignored for non-struct
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	// Prefix should be ignored for non-struct fields
 	expectedKeys := []string{"host", "port"}
@@ -1626,7 +2558,7 @@ func TestCollectValidKeys_NestedStructWithoutPrefix(t *testing.T) {
 		Database Database // no prefix tag
 	}
 
-	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "")
+	validKeys := collectValidKeys(reflect.TypeOf(Config{}), "", false)
 
 	// Without prefix tag, nested keys should use parent field name as prefix
 	expectedKeys := []string{"database", "database.host", "database.port"}
@@ -1640,3 +2572,1280 @@ func TestCollectValidKeys_NestedStructWithoutPrefix(t *testing.T) {
 		}
 	}
 }
+
+func TestLoad_FormatBytes(t *testing.T) {
+	type Config struct {
+		MaxBody  int64  `conf:"format:bytes"`
+		CacheMax uint64 `conf:"format:bytes,default:64MiB"`
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{"maxbody": "10MB"}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if cfg.MaxBody != 10000000 {
+		t.Errorf("MaxBody = %d, want 10000000", cfg.MaxBody)
+	}
+	if cfg.CacheMax != 64<<20 {
+		t.Errorf("CacheMax = %d, want %d", cfg.CacheMax, 64<<20)
+	}
+
+	_, err = NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{"maxbody": "10 bananas"}}).
+		Load(context.Background())
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("expected ValidationError for unknown unit, got %v", err)
+	}
+}
+
+func TestLoad_FormatPercent(t *testing.T) {
+	type Config struct {
+		SampleRate float64 `conf:"format:percent,min:0,max:1"`
+	}
+
+	tests := []struct {
+		name     string
+		value    any
+		want     float64
+		wantCode string
+	}{
+		{name: "percentage", value: "25%", want: 0.25},
+		{name: "ratio", value: "0.25", want: 0.25},
+		{name: "above max", value: "150%", wantCode: ErrCodeMax},
+		{name: "invalid", value: "abc%", wantCode: ErrCodeInvalidType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewLoader[Config]().
+				WithSource(&mockSource{data: map[string]any{"samplerate": tt.value}}).
+				Load(context.Background())
+
+			if tt.wantCode != "" {
+				var valErr *ValidationError
+				if !errors.As(err, &valErr) {
+					t.Fatalf("expected ValidationError, got %v", err)
+				}
+				if len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].Code != tt.wantCode {
+					t.Errorf("expected single %s error, got %+v", tt.wantCode, valErr.FieldErrors)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			if cfg.SampleRate != tt.want {
+				t.Errorf("SampleRate = %v, want %v", cfg.SampleRate, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_FormatJSON(t *testing.T) {
+	type Config struct {
+		Labels  map[string]string `conf:"format:json"`
+		Hosts   []string          `conf:"format:json"`
+		Ports   []int             `conf:"format:json"`
+		Backend struct {
+			Host string
+			Port int `conf:"min:1"`
+		} `conf:"format:json"`
+	}
+
+	data := map[string]any{
+		"labels":  `{"team":"core","env":"prod"}`,
+		"hosts":   `["a,1","b"]`,
+		"ports":   "[80, 443]",
+		"backend": `{"Host":"db.internal","Port":5432}`,
+	}
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{name: "env", data: data}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Labels, map[string]string{"team": "core", "env": "prod"}) {
+		t.Errorf("Labels = %v", cfg.Labels)
+	}
+	if !reflect.DeepEqual(cfg.Hosts, []string{"a,1", "b"}) {
+		t.Errorf("Hosts = %q, want JSON elements (commas kept)", cfg.Hosts)
+	}
+	if !reflect.DeepEqual(cfg.Ports, []int{80, 443}) {
+		t.Errorf("Ports = %v", cfg.Ports)
+	}
+	if cfg.Backend.Host != "db.internal" || cfg.Backend.Port != 5432 {
+		t.Errorf("Backend = %+v", cfg.Backend)
+	}
+	prov, _ := GetProvenance(cfg)
+	if p := findProvenance(prov.Fields, "Backend.Host"); p == nil || p.SourceName != "env" {
+		t.Errorf("Backend.Host provenance = %+v, want source env", p)
+	}
+
+	// Structured values from file sources keep working
+	cfg, err = NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{"hosts": []any{"x", "y"}, "labels": map[string]any{"a": "b"}}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Hosts, []string{"x", "y"}) || cfg.Labels["a"] != "b" {
+		t.Errorf("unexpected config from structured values: %+v", cfg)
+	}
+
+	tests := []struct {
+		name      string
+		data      map[string]any
+		wantField string
+		wantCode  string
+	}{
+		{name: "invalid map JSON", data: map[string]any{"labels": `{"team":`}, wantField: "Labels", wantCode: ErrCodeInvalidType},
+		{name: "wrong element type", data: map[string]any{"ports": `["http"]`}, wantField: "Ports", wantCode: ErrCodeInvalidType},
+		{name: "invalid struct JSON", data: map[string]any{"backend": "host=db"}, wantField: "Backend", wantCode: ErrCodeInvalidType},
+		{name: "nested fields are validated", data: map[string]any{"backend": `{"host":"db","port":-1}`}, wantField: "Backend.Port", wantCode: ErrCodeMin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLoader[Config]().
+				WithSource(&mockSource{data: tt.data}).
+				Load(context.Background())
+
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].FieldPath != tt.wantField || valErr.FieldErrors[0].Code != tt.wantCode {
+				t.Errorf("expected %s error on %s, got %+v", tt.wantCode, tt.wantField, valErr.FieldErrors)
+			}
+		})
+	}
+}
+
+// sleepySource takes delay to load. It ignores its context unless honorContext is set.
+type sleepySource struct {
+	name         string
+	delay        time.Duration
+	honorContext bool
+}
+
+func (s *sleepySource) Load(ctx context.Context) (map[string]any, error) {
+	if !s.honorContext {
+		time.Sleep(s.delay)
+		return map[string]any{}, nil
+	}
+	select {
+	case <-time.After(s.delay):
+		return map[string]any{}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *sleepySource) Watch(ctx context.Context) (<-chan ChangeEvent, error) {
+	return nil, ErrWatchNotSupported
+}
+
+func (s *sleepySource) Name() string {
+	return s.name
+}
+
+func TestLoad_WithTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		timeout     time.Duration
+		ctxTimeout  time.Duration // Caller deadline, 0 for none
+		source      Source
+		wantErr     bool
+		wantMaxTime time.Duration
+	}{
+		{
+			name:        "source ignoring its context",
+			timeout:     50 * time.Millisecond,
+			source:      &sleepySource{name: "slow-vault", delay: 2 * time.Second},
+			wantErr:     true,
+			wantMaxTime: time.Second,
+		},
+		{
+			name:        "source honoring its context",
+			timeout:     50 * time.Millisecond,
+			source:      &sleepySource{name: "slow-vault", delay: 2 * time.Second, honorContext: true},
+			wantErr:     true,
+			wantMaxTime: time.Second,
+		},
+		{
+			name:        "shorter caller deadline applies",
+			timeout:     10 * time.Second,
+			ctxTimeout:  50 * time.Millisecond,
+			source:      &sleepySource{name: "slow-vault", delay: 2 * time.Second, honorContext: true},
+			wantErr:     true,
+			wantMaxTime: time.Second,
+		},
+		{
+			name:        "fast source",
+			timeout:     time.Second,
+			source:      &sleepySource{name: "fast", delay: time.Millisecond},
+			wantMaxTime: time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.ctxTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.ctxTimeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			_, err := NewLoader[struct{}]().
+				WithSource(&mockSource{name: "env"}).
+				WithSource(tt.source).
+				WithTimeout(tt.timeout).
+				Load(ctx)
+			elapsed := time.Since(start)
+
+			if elapsed > tt.wantMaxTime {
+				t.Errorf("Load took %v, want at most %v", elapsed, tt.wantMaxTime)
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Load() unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "slow-vault") {
+				t.Errorf("error should name the slow source: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoad_WithConcurrentValidators(t *testing.T) {
+	const delay = 100 * time.Millisecond
+
+	slowValidator := func(field string) Validator[struct{}] {
+		return ValidatorFunc[struct{}](func(ctx context.Context, cfg *struct{}) error {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return &ValidationError{FieldErrors: []FieldError{{FieldPath: field, Code: "unreachable", Message: field + " is unreachable"}}}
+		})
+	}
+
+	start := time.Now()
+	_, err := NewLoader[struct{}]().
+		WithConcurrentValidators(true).
+		WithValidator(slowValidator("Database")).
+		WithValidator(slowValidator("Cache")).
+		Load(context.Background())
+	elapsed := time.Since(start)
+
+	var valErr *ValidationError
+	if !errors.As(err, &valErr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	if len(valErr.FieldErrors) != 2 {
+		t.Fatalf("expected 2 field errors, got %d: %+v", len(valErr.FieldErrors), valErr.FieldErrors)
+	}
+	// Errors keep registration order
+	if valErr.FieldErrors[0].FieldPath != "Database" || valErr.FieldErrors[1].FieldPath != "Cache" {
+		t.Errorf("unexpected error order: %+v", valErr.FieldErrors)
+	}
+	if elapsed >= 2*delay {
+		t.Errorf("validators did not run concurrently: took %v, want < %v", elapsed, 2*delay)
+	}
+
+	t.Run("non-validation error cancels the others", func(t *testing.T) {
+		failing := ValidatorFunc[struct{}](func(ctx context.Context, cfg *struct{}) error {
+			return errors.New("boom")
+		})
+
+		start := time.Now()
+		_, err := NewLoader[struct{}]().
+			WithConcurrentValidators(true).
+			WithValidator(slowValidator("Database")).
+			WithValidator(failing).
+			Load(context.Background())
+
+		if err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Fatalf("expected validator error, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed >= delay {
+			t.Errorf("slow validator was not cancelled: took %v", elapsed)
+		}
+	})
+}
+
+func TestLoad_EmbeddedStruct(t *testing.T) {
+	type Base struct {
+		Port int `conf:"required,min:1"`
+	}
+	type Config struct {
+		Base
+		Host string
+	}
+
+	t.Run("promoted keys bind in strict mode", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"port": 8080, "host": "localhost"}}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Port != 8080 || cfg.Host != "localhost" {
+			t.Errorf("got %+v, want Port=8080 Host=localhost", cfg)
+		}
+	})
+
+	t.Run("embedded type name is not a key", func(t *testing.T) {
+		_, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"port": 8080, "base.port": 9090}}).
+			Load(context.Background())
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.FieldErrors[0].Code != ErrCodeUnknownKey {
+			t.Fatalf("expected unknown key error for base.port, got %v", err)
+		}
+	})
+
+	t.Run("validation uses promoted field path", func(t *testing.T) {
+		_, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"host": "localhost"}}).
+			Load(context.Background())
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+		if len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].FieldPath != "Port" {
+			t.Errorf("expected required error for Port, got %+v", valErr.FieldErrors)
+		}
+	})
+
+	t.Run("unexported embedded struct is promoted", func(t *testing.T) {
+		type base struct {
+			Port  int    `conf:"required,min:1"`
+			Token string `conf:"secret"`
+		}
+		type Config struct {
+			base
+			Host string
+		}
+
+		_, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"host": "localhost"}}).
+			Load(context.Background())
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].FieldPath != "Port" {
+			t.Fatalf("expected required error for Port, got %v", err)
+		}
+
+		cfg, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"port": 8080, "token": "hunter2", "host": "localhost"}}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Port != 8080 || cfg.Token != "hunter2" {
+			t.Errorf("got %+v, want Port=8080 Token=hunter2", cfg)
+		}
+		if got := RedactedString(cfg); !strings.Contains(got, "port: 8080") || !strings.Contains(got, "token: ***redacted***") {
+			t.Errorf("RedactedString() = %q, want promoted fields", got)
+		}
+	})
+}
+
+func TestLoad_Deprecated(t *testing.T) {
+	type Config struct {
+		Port       int    `conf:"default:8080"`
+		ListenPort int    `conf:"deprecated:use port instead"`
+		Legacy     string `conf:"deprecated,default:on"`
+	}
+
+	data := map[string]any{"port": 9090, "listenport": 9091}
+
+	tests := []struct {
+		name             string
+		deprecationError bool
+		wantErr          bool
+		wantWarnings     int
+	}{
+		{name: "warn only by default", deprecationError: false, wantErr: false, wantWarnings: 1},
+		{name: "escalated to error", deprecationError: true, wantErr: true, wantWarnings: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []FieldWarning
+			cfg, err := NewLoader[Config]().
+				WithSource(&mockSource{data: data}).
+				WithWarningHandler(func(w FieldWarning) { warnings = append(warnings, w) }).
+				WithDeprecationError(tt.deprecationError).
+				Load(context.Background())
+
+			if len(warnings) != tt.wantWarnings {
+				t.Fatalf("got %d warnings, want %d: %+v", len(warnings), tt.wantWarnings, warnings)
+			}
+
+			if tt.wantErr {
+				var valErr *ValidationError
+				if !errors.As(err, &valErr) {
+					t.Fatalf("expected ValidationError, got %v", err)
+				}
+				if len(valErr.FieldErrors) != 1 {
+					t.Fatalf("expected 1 field error, got %+v", valErr.FieldErrors)
+				}
+				fe := valErr.FieldErrors[0]
+				if fe.FieldPath != "ListenPort" || fe.Code != ErrCodeDeprecated || !strings.Contains(fe.Message, "use port instead") {
+					t.Errorf("unexpected field error: %+v", fe)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			if cfg.ListenPort != 9091 {
+				t.Errorf("ListenPort = %d, want 9091", cfg.ListenPort)
+			}
+			w := warnings[0]
+			if w.FieldPath != "ListenPort" || w.Code != ErrCodeDeprecated || w.Message != `key "listenport" is deprecated: use port instead` {
+				t.Errorf("unexpected warning: %+v", w)
+			}
+		})
+	}
+}
+
+func TestLoad_ValidatorWarnings(t *testing.T) {
+	type Config struct {
+		PoolSize int
+	}
+
+	poolCheck := ValidatorFunc[Config](func(ctx context.Context, cfg *Config) error {
+		if cfg.PoolSize > 100 {
+			Warn(ctx, FieldWarning{FieldPath: "PoolSize", Code: "unusual", Message: "pool size is unusually high"})
+		}
+		if cfg.PoolSize > 1000 {
+			return &ValidationError{FieldErrors: []FieldError{{FieldPath: "PoolSize", Code: "too_large", Message: "pool size too large"}}}
+		}
+		return nil
+	})
+	alwaysWarn := ValidatorFunc[Config](func(ctx context.Context, cfg *Config) error {
+		Warn(ctx, FieldWarning{FieldPath: "PoolSize", Code: "second", Message: "second validator"})
+		return nil
+	})
+
+	tests := []struct {
+		name         string
+		poolSize     int
+		concurrent   bool
+		wantErr      bool
+		wantWarnings []string // Codes in delivery order
+	}{
+		{name: "no finding", poolSize: 10, wantWarnings: []string{"second"}},
+		{name: "warning does not fail load", poolSize: 500, wantWarnings: []string{"unusual", "second"}},
+		{name: "warnings in registration order when concurrent", poolSize: 500, concurrent: true, wantWarnings: []string{"unusual", "second"}},
+		{name: "warnings delivered alongside errors", poolSize: 5000, wantErr: true, wantWarnings: []string{"unusual", "second"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []FieldWarning
+			cfg, err := NewLoader[Config]().
+				WithSource(&mockSource{data: map[string]any{"poolsize": tt.poolSize}}).
+				WithValidator(poolCheck).
+				WithValidator(alwaysWarn).
+				WithConcurrentValidators(tt.concurrent).
+				WithWarningHandler(func(w FieldWarning) { warnings = append(warnings, w) }).
+				Load(context.Background())
+
+			if tt.wantErr {
+				var valErr *ValidationError
+				if !errors.As(err, &valErr) {
+					t.Fatalf("expected ValidationError, got %v", err)
+				}
+			} else if err != nil || cfg == nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("got warnings %+v, want codes %v", warnings, tt.wantWarnings)
+			}
+			for i, code := range tt.wantWarnings {
+				if warnings[i].Code != code {
+					t.Errorf("warnings[%d].Code = %q, want %q", i, warnings[i].Code, code)
+				}
+			}
+		})
+	}
+
+	t.Run("cached validators repeat their warnings", func(t *testing.T) {
+		var warnings []FieldWarning
+		loader := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"poolsize": 500}}).
+			WithValidator(ValidatorWithKeys[Config](poolCheck, "poolsize")).
+			WithValidationCache(true).
+			WithWarningHandler(func(w FieldWarning) { warnings = append(warnings, w) })
+
+		for i := 0; i < 2; i++ {
+			if _, err := loader.Load(context.Background()); err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+		}
+		if len(warnings) != 2 {
+			t.Errorf("expected the warning on both loads, got %+v", warnings)
+		}
+	})
+
+	t.Run("outside a validator", func(t *testing.T) {
+		Warn(context.Background(), FieldWarning{Code: "ignored"}) // Must not panic
+	})
+}
+
+func TestLoad_WithBindHook(t *testing.T) {
+	type Config struct {
+		Host     string
+		Port     int    `conf:"default:8080"`
+		Password string `conf:"secret"`
+		Timeout  Optional[time.Duration]
+		Database struct {
+			Name string
+		}
+	}
+
+	type boundField struct {
+		value  any
+		source string
+	}
+	got := make(map[string]boundField)
+
+	_, err := NewLoader[Config]().
+		WithSource(&mockSource{name: "file", data: map[string]any{"host": "localhost", "database.name": "app"}}).
+		WithSource(&mockSource{name: "secrets", data: map[string]any{"password": "hunter2", "timeout": "5s"}}).
+		WithBindHook(func(fieldPath string, value any, source string) {
+			got[fieldPath] = boundField{value: value, source: source}
+		}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	want := map[string]boundField{
+		"Host":          {value: "localhost", source: "file"},
+		"Port":          {value: 8080, source: "default"},
+		"Password":      {value: "***redacted***", source: "secrets"},
+		"Timeout":       {value: 5 * time.Second, source: "secrets"},
+		"Database.Name": {value: "app", source: "file"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("bind hook saw %+v, want %+v", got, want)
+	}
+}
+
+func TestLoad_WithFallbackChain(t *testing.T) {
+	type Config struct {
+		Host    string
+		Port    int
+		Timeout string
+	}
+
+	env := &mockSource{name: "env", data: map[string]any{"host": "env-host", "port": 1}}
+	file := &mockSource{name: "file", data: map[string]any{"host": "file-host", "port": 2, "timeout": "5s"}}
+	remote := &mockSource{name: "remote", data: map[string]any{"host": "remote-host", "port": 3}}
+
+	var provenance []FieldProvenance
+	cfg, err := NewLoader[Config]().
+		WithSource(env).
+		WithSource(file).
+		WithSource(remote).
+		WithFallbackChain("Host", []string{"env", "file"}).
+		WithFallbackChain("timeout", []string{"env", "remote"}). // No listed source provides it
+		WithBindHook(func(fieldPath string, value any, source string) {
+			provenance = append(provenance, FieldProvenance{FieldPath: fieldPath, SourceName: source})
+		}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	// Host follows its chain, Port follows the global order (last source wins)
+	if cfg.Host != "env-host" {
+		t.Errorf("Host = %q, want env-host", cfg.Host)
+	}
+	if cfg.Port != 3 {
+		t.Errorf("Port = %d, want 3", cfg.Port)
+	}
+	if cfg.Timeout != "5s" {
+		t.Errorf("Timeout = %q, want 5s", cfg.Timeout)
+	}
+
+	if p := findProvenance(provenance, "Host"); p == nil || p.SourceName != "env" {
+		t.Errorf("Host provenance = %+v, want source env", p)
+	}
+}
+
+func TestLoad_WithDefaults(t *testing.T) {
+	type Config struct {
+		Host    string `conf:"default:tag-host"`
+		Port    int    `conf:"default:1"`
+		Timeout string `conf:"default:1s"`
+		Region  string
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithDefaults(map[string]any{"port": 2, "Timeout": "2s", "region": "eu-west-1"}).
+		WithSource(&mockSource{name: "env", data: map[string]any{"timeout": "3s"}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	prov, _ := GetProvenance(cfg)
+	tests := []struct {
+		field  string
+		got    any
+		want   any
+		source string
+	}{
+		{field: "Host", got: cfg.Host, want: "tag-host", source: "default"},             // Tag default only
+		{field: "Port", got: cfg.Port, want: 2, source: "loader-default"},               // WithDefaults beats tag default
+		{field: "Timeout", got: cfg.Timeout, want: "3s", source: "env"},                 // Source beats WithDefaults
+		{field: "Region", got: cfg.Region, want: "eu-west-1", source: "loader-default"}, // No tag default
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.field, tt.got, tt.want)
+		}
+		if p := findProvenance(prov.Fields, tt.field); p == nil || p.SourceName != tt.source {
+			t.Errorf("%s provenance = %+v, want source %q", tt.field, p, tt.source)
+		}
+	}
+
+	t.Run("strict mode rejects unknown default keys", func(t *testing.T) {
+		_, err := NewLoader[Config]().
+			WithDefaults(map[string]any{"unknown": 1}).
+			Load(context.Background())
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.FieldErrors[0].Code != ErrCodeUnknownKey {
+			t.Errorf("expected unknown key error, got %v", err)
+		}
+	})
+}
+
+func TestLoad_WithBaseConfig(t *testing.T) {
+	type Database struct {
+		Host string `conf:"default:tag-host"`
+		Port int    `conf:"default:1"`
+	}
+	type Config struct {
+		Database Database
+		Timeout  string `conf:"default:1s"`
+		Region   string
+		Tenant   string
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithDefaults(map[string]any{"timeout": "2s", "region": "eu-west-1"}).
+		WithBaseConfig(map[string]any{
+			"Database": map[string]any{"port": 5432}, // Nested maps are flattened
+			"timeout":  "4s",
+			"tenant":   "acme",
+		}).
+		WithSource(&mockSource{name: "env", data: map[string]any{"tenant": "globex"}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	prov, _ := GetProvenance(cfg)
+	tests := []struct {
+		field  string
+		got    any
+		want   any
+		source string
+	}{
+		{field: "Database.Host", got: cfg.Database.Host, want: "tag-host", source: "default"}, // Tag default only
+		{field: "Database.Port", got: cfg.Database.Port, want: 5432, source: "base"},          // Base config beats tag default
+		{field: "Timeout", got: cfg.Timeout, want: "4s", source: "base"},                      // Base config beats WithDefaults
+		{field: "Region", got: cfg.Region, want: "eu-west-1", source: "loader-default"},       // Not in base config
+		{field: "Tenant", got: cfg.Tenant, want: "globex", source: "env"},                     // Source beats base config
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.field, tt.got, tt.want)
+		}
+		if p := findProvenance(prov.Fields, tt.field); p == nil || p.SourceName != tt.source {
+			t.Errorf("%s provenance = %+v, want source %q", tt.field, p, tt.source)
+		}
+	}
+
+	t.Run("strict mode rejects unknown base keys", func(t *testing.T) {
+		_, err := NewLoader[Config]().
+			WithBaseConfig(map[string]any{"database": map[string]any{"hots": "typo"}}).
+			Load(context.Background())
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.FieldErrors[0].Code != ErrCodeUnknownKey || valErr.FieldErrors[0].FieldPath != "database.hots" {
+			t.Errorf("expected unknown key error for database.hots, got %v", err)
+		}
+	})
+
+	t.Run("calling again replaces the base config", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithBaseConfig(map[string]any{"tenant": "acme"}).
+			WithBaseConfig(map[string]any{"region": "us-east-1"}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Tenant != "" || cfg.Region != "us-east-1" {
+			t.Errorf("got Tenant=%q Region=%q, want only the second base config", cfg.Tenant, cfg.Region)
+		}
+	})
+}
+
+// nestedType returns a struct type nested depth levels deep: struct{ Next struct{ Next ... struct{ Value int } } }.
+func nestedType(depth int) reflect.Type {
+	t := reflect.StructOf([]reflect.StructField{{Name: "Value", Type: reflect.TypeOf(0)}})
+	for i := 0; i < depth; i++ {
+		t = reflect.StructOf([]reflect.StructField{{Name: "Next", Type: t}})
+	}
+	return t
+}
+
+func TestCheckDepth(t *testing.T) {
+	tests := []struct {
+		name     string
+		depth    int
+		maxDepth int
+		wantPath int // Number of "Next" segments in the reported field path, 0 for no error
+	}{
+		{name: "within default", depth: 10, maxDepth: defaultMaxDepth},
+		{name: "at default", depth: 32, maxDepth: defaultMaxDepth},
+		{name: "40 levels exceed default", depth: 40, maxDepth: defaultMaxDepth, wantPath: 33},
+		{name: "40 levels with raised limit", depth: 40, maxDepth: 40},
+		{name: "lowered limit", depth: 3, maxDepth: 2, wantPath: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := checkDepth(nestedType(tt.depth), tt.maxDepth, false)
+			if tt.wantPath == 0 {
+				if len(errs) != 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Code != ErrCodeConfigSchema {
+				t.Fatalf("expected one config_schema error, got %v", errs)
+			}
+			if want := strings.TrimSuffix(strings.Repeat("Next.", tt.wantPath), "."); errs[0].FieldPath != want {
+				t.Errorf("FieldPath = %q, want %q", errs[0].FieldPath, want)
+			}
+		})
+	}
+}
+
+type depthNode struct {
+	Name  string
+	Left  *depthNode
+	Right *depthNode
+}
+
+func TestLoad_MaxDepth(t *testing.T) {
+	type Leaf struct{ Port int }
+	type Middle struct{ Leaf Leaf }
+	type Config struct{ Middle Middle }
+
+	t.Run("self-referential type is rejected", func(t *testing.T) {
+		_, err := NewLoader[depthNode]().WithPointerStructs(true).Load(context.Background())
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.FieldErrors[0].Code != ErrCodeConfigSchema {
+			t.Fatalf("expected config_schema error, got %v", err)
+		}
+		if !strings.Contains(valErr.FieldErrors[0].Message, "maximum depth of 32") {
+			t.Errorf("unexpected message: %s", valErr.FieldErrors[0].Message)
+		}
+	})
+
+	t.Run("WithMaxDepth lowers the limit", func(t *testing.T) {
+		_, err := NewLoader[Config]().WithMaxDepth(1).Load(context.Background())
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.FieldErrors[0].FieldPath != "Middle.Leaf" {
+			t.Fatalf("expected config_schema error for Middle.Leaf, got %v", err)
+		}
+	})
+
+	t.Run("default allows ordinary nesting", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"middle.leaf.port": 8080}}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Middle.Leaf.Port != 8080 {
+			t.Errorf("Port = %d, want 8080", cfg.Middle.Leaf.Port)
+		}
+	})
+
+	t.Run("walkers stop at the hard limit", func(t *testing.T) {
+		deep := nestedType(maxWalkDepth + 10)
+
+		keys := collectValidKeys(deep, "", false)
+		if want := strings.TrimSuffix(strings.Repeat("next.", maxWalkDepth), "."); !keys[want] {
+			t.Errorf("expected key at the hard limit to be collected")
+		}
+		if len(keys) != maxWalkDepth {
+			t.Errorf("collected %d keys, want %d", len(keys), maxWalkDepth)
+		}
+
+		errs := bindStruct(reflect.New(deep).Elem(), map[string]mergedEntry{}, nil, "", "", false, CollectAll)
+		if len(errs) != 1 || errs[0].Code != ErrCodeConfigSchema {
+			t.Errorf("expected config_schema error from bindStruct, got %v", errs)
+		}
+	})
+}
+
+func TestLoad_KeyCollision(t *testing.T) {
+	type Server struct {
+		Port int
+	}
+
+	t.Run("name tag collides with nested field", func(t *testing.T) {
+		type Config struct {
+			Server     Server
+			ServerPort int `conf:"name:server.port"`
+		}
+
+		_, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"server.port": 8080}}).
+			Load(context.Background())
+
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+		if len(valErr.FieldErrors) != 1 {
+			t.Fatalf("expected 1 error, got %+v", valErr.FieldErrors)
+		}
+		fe := valErr.FieldErrors[0]
+		if fe.Code != ErrCodeConfigSchema || fe.FieldPath != "ServerPort" || !strings.Contains(fe.Message, "Server.Port") {
+			t.Errorf("unexpected error: %+v", fe)
+		}
+	})
+
+	t.Run("prefix tags collide", func(t *testing.T) {
+		type Config struct {
+			Primary Server `conf:"prefix:server"`
+			Replica Server `conf:"prefix:server"`
+		}
+
+		_, err := NewLoader[Config]().Load(context.Background())
+
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].FieldPath != "Replica.Port" {
+			t.Fatalf("expected collision on Replica.Port, got %v", err)
+		}
+	})
+
+	t.Run("distinct keys pass", func(t *testing.T) {
+		type Config struct {
+			Primary Server `conf:"prefix:primary"`
+			Replica Server `conf:"prefix:replica"`
+		}
+
+		if _, err := NewLoader[Config]().Load(context.Background()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestLoad_StringNormalizationBeforeValidation(t *testing.T) {
+	type Config struct {
+		LogLevel string `conf:"trim,lower,oneof:debug,info"`
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{"loglevel": " INFO "}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if cfg.LogLevel != "info" {
+		t.Errorf("LogLevel = %q, want %q", cfg.LogLevel, "info")
+	}
+}
+
+func TestLoad_PointerStruct(t *testing.T) {
+	type Database struct {
+		Host string `conf:"required"`
+		Port int    `conf:"default:5432"`
+	}
+	type Config struct {
+		Name     string
+		Database *Database
+	}
+
+	t.Run("nil when no data is present", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithPointerStructs(true).
+			WithSource(&mockSource{data: map[string]any{"name": "api"}}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Database != nil {
+			t.Errorf("Database = %+v, want nil", cfg.Database)
+		}
+	})
+
+	t.Run("allocated, validated and dumped when data is present", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithPointerStructs(true).
+			WithSource(&mockSource{data: map[string]any{"database.host": "db.internal"}}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Database == nil || cfg.Database.Host != "db.internal" || cfg.Database.Port != 5432 {
+			t.Fatalf("Database = %+v, want host db.internal and default port", cfg.Database)
+		}
+
+		var buf bytes.Buffer
+		if err := DumpEffective(&buf, cfg); err != nil {
+			t.Fatalf("DumpEffective() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), `database.host: "db.internal"`) {
+			t.Errorf("expected nested pointer fields in dump, got:\n%s", buf.String())
+		}
+	})
+
+	t.Run("inner required fields apply once allocated", func(t *testing.T) {
+		_, err := NewLoader[Config]().
+			WithPointerStructs(true).
+			WithSource(&mockSource{data: map[string]any{"database.port": 1}}).
+			Load(context.Background())
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.FieldErrors[0].FieldPath != "Database.Host" {
+			t.Fatalf("expected required error for Database.Host, got %v", err)
+		}
+	})
+
+	t.Run("leaves without WithPointerStructs", func(t *testing.T) {
+		_, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"database.host": "db.internal"}}).
+			Load(context.Background())
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.FieldErrors[0].Code != ErrCodeUnknownKey {
+			t.Fatalf("expected unknown key error for database.host, got %v", err)
+		}
+	})
+}
+
+func TestLoad_InvalidDefaults(t *testing.T) {
+	tests := []struct {
+		name      string
+		load      func() error
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name: "default below min",
+			load: func() error {
+				type Config struct {
+					Port int `conf:"default:80,min:1024"`
+				}
+				_, err := NewLoader[Config]().Load(context.Background())
+				return err
+			},
+			wantField: "Port",
+			wantMsg:   "min",
+		},
+		{
+			name: "default not in oneof",
+			load: func() error {
+				type Config struct {
+					Server struct {
+						Mode string `conf:"default:fast,oneof:dev,prod"`
+					}
+				}
+				_, err := NewLoader[Config]().Load(context.Background())
+				return err
+			},
+			wantField: "Server.Mode",
+			wantMsg:   "oneof",
+		},
+		{
+			name: "default of the wrong type",
+			load: func() error {
+				type Config struct {
+					Timeout Optional[time.Duration] `conf:"default:soon"`
+				}
+				_, err := NewLoader[Config]().Load(context.Background())
+				return err
+			},
+			wantField: "Timeout",
+			wantMsg:   "invalid default",
+		},
+		{
+			name: "checked even when a source sets the field",
+			load: func() error {
+				type Config struct {
+					Port int `conf:"default:80,max:70"`
+				}
+				_, err := NewLoader[Config]().
+					WithSource(&mockSource{data: map[string]any{"port": 60}}).
+					Load(context.Background())
+				return err
+			},
+			wantField: "Port",
+			wantMsg:   "max",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var valErr *ValidationError
+			if err := tt.load(); !errors.As(err, &valErr) {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if len(valErr.FieldErrors) != 1 {
+				t.Fatalf("expected 1 error, got %+v", valErr.FieldErrors)
+			}
+			fe := valErr.FieldErrors[0]
+			if fe.Code != ErrCodeConfigSchema || fe.FieldPath != tt.wantField || !strings.Contains(fe.Message, tt.wantMsg) {
+				t.Errorf("unexpected error: %+v", fe)
+			}
+		})
+	}
+
+	t.Run("secret defaults are redacted", func(t *testing.T) {
+		type Config struct {
+			PIN   int    `conf:"secret,default:12x4"`
+			Token string `conf:"secret,default:tok_dev,oneof:a,b"`
+		}
+		_, err := NewLoader[Config]().Load(context.Background())
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		for _, secret := range []string{"12x4", "tok_dev"} {
+			if strings.Contains(err.Error(), secret) {
+				t.Errorf("error contains secret default %q: %v", secret, err)
+			}
+		}
+	})
+
+	t.Run("normalized defaults satisfy oneof", func(t *testing.T) {
+		type Config struct {
+			Level string `conf:"default:INFO,lower,oneof:debug,info"`
+		}
+		if _, err := NewLoader[Config]().Load(context.Background()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestLoad_TypeConflictAcrossSources(t *testing.T) {
+	type Config struct {
+		Port     int
+		Features map[string]bool
+		Database struct {
+			Host string
+		}
+	}
+
+	tests := []struct {
+		name      string
+		sources   []Source
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name: "map vs scalar for the same key",
+			sources: []Source{
+				&mockSource{name: "file", data: map[string]any{"features": map[string]any{"beta": true}}},
+				&mockSource{name: "env", data: map[string]any{"features": "beta"}},
+			},
+			wantField: "features",
+			wantMsg:   `source "file" provides a map but source "env" provides a scalar value`,
+		},
+		{
+			name: "scalar vs nested keys",
+			sources: []Source{
+				&mockSource{name: "env", data: map[string]any{"database": "postgres://db"}},
+				&mockSource{name: "file", data: map[string]any{"database.host": "db.internal"}},
+			},
+			wantField: "database",
+			wantMsg:   `source "file" provides a map but source "env" provides a scalar value`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := NewLoader[Config]()
+			for _, src := range tt.sources {
+				loader.WithSource(src)
+			}
+
+			_, err := loader.Load(context.Background())
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) || len(valErr.FieldErrors) != 1 {
+				t.Fatalf("expected one ValidationError, got %v", err)
+			}
+			fe := valErr.FieldErrors[0]
+			if fe.Code != ErrCodeTypeConflict || fe.FieldPath != tt.wantField || fe.Message != tt.wantMsg {
+				t.Errorf("unexpected error: %+v", fe)
+			}
+		})
+	}
+
+	t.Run("compatible shapes merge normally", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithSource(&mockSource{name: "file", data: map[string]any{"port": "8080", "features": map[string]any{"beta": true}}}).
+			WithSource(&mockSource{name: "env", data: map[string]any{"port": 9090, "features.search": "true"}}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Port != 9090 {
+			t.Errorf("Port = %d, want 9090 (later source wins)", cfg.Port)
+		}
+	})
+
+	t.Run("null fits either shape", func(t *testing.T) {
+		// An empty "database:" section in YAML, then nested keys from the environment
+		cfg, err := NewLoader[Config]().
+			WithSource(&mockSource{name: "file", data: map[string]any{"database": nil, "features": nil}}).
+			WithSource(&mockSource{name: "env", data: map[string]any{"database.host": "db.internal", "features.beta": "true"}}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Database.Host != "db.internal" || !cfg.Features["beta"] {
+			t.Errorf("cfg = %+v, want the environment values", cfg)
+		}
+	})
+}
+
+func TestLoader_WithTreatEmptyAsUnset(t *testing.T) {
+	type Config struct {
+		Host  string
+		Level string `conf:"default:info"`
+		Name  string
+	}
+
+	file := &mockSource{name: "file", data: map[string]any{"host": "file.internal"}}
+	env := &mockSource{name: "env", data: map[string]any{"host": "", "level": "", "name": "app"}}
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		wantHost  string
+		wantLevel string
+	}{
+		{name: "disabled", enabled: false, wantHost: "", wantLevel: ""},
+		{name: "enabled", enabled: true, wantHost: "file.internal", wantLevel: "info"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewLoader[Config]().
+				WithSource(file).
+				WithSource(env).
+				WithTreatEmptyAsUnset(tt.enabled).
+				Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Host != tt.wantHost || cfg.Level != tt.wantLevel || cfg.Name != "app" {
+				t.Errorf("cfg = %+v, want host %q, level %q, name app", cfg, tt.wantHost, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestLoader_WithValidatorStopOnError(t *testing.T) {
+	type Config struct {
+		DSN string
+	}
+
+	tests := []struct {
+		name       string
+		stop       bool
+		concurrent bool
+		wantFields []string
+		wantCalls  []string
+	}{
+		{
+			name:       "all validators run by default",
+			wantFields: []string{"DSN", "DSN.scheme", "DSN.reachable"},
+			wantCalls:  []string{"syntax", "reachable", "ok"},
+		},
+		{
+			name:       "first failing validator stops the rest",
+			stop:       true,
+			wantFields: []string{"DSN", "DSN.scheme"},
+			wantCalls:  []string{"syntax"},
+		},
+		{
+			name:       "stop on error runs serially",
+			stop:       true,
+			concurrent: true,
+			wantFields: []string{"DSN", "DSN.scheme"},
+			wantCalls:  []string{"syntax"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var calls []string
+			validator := func(name string, fields ...string) Validator[Config] {
+				return ValidatorFunc[Config](func(ctx context.Context, cfg *Config) error {
+					mu.Lock()
+					calls = append(calls, name)
+					mu.Unlock()
+					if len(fields) == 0 {
+						return nil
+					}
+					valErr := &ValidationError{}
+					for _, field := range fields {
+						valErr.FieldErrors = append(valErr.FieldErrors, FieldError{FieldPath: field, Code: "custom", Message: "invalid"})
+					}
+					return valErr
+				})
+			}
+
+			loader := NewLoader[Config]().
+				WithSource(&mockSource{data: map[string]any{"dsn": "not a dsn"}}).
+				WithValidator(validator("syntax", "DSN", "DSN.scheme")).
+				WithValidator(validator("reachable", "DSN.reachable")).
+				WithValidator(validator("ok")).
+				WithValidatorStopOnError(tt.stop).
+				WithConcurrentValidators(tt.concurrent)
+
+			_, err := loader.Load(context.Background())
+
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("Load() error = %v, want *ValidationError", err)
+			}
+			var fields []string
+			for _, fe := range valErr.FieldErrors {
+				fields = append(fields, fe.FieldPath)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("field errors = %v, want %v", fields, tt.wantFields)
+			}
+			if !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("validator calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
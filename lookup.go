@@ -0,0 +1,60 @@
+package rigging
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Lookup returns the effective value of a loaded config at a dotted key path (e.g. "database.host"),
+// for tooling that only knows the key path at runtime. Key paths are matched case-insensitively.
+// Values are formatted as in snapshots and secret fields (by tag or provenance) are redacted.
+// Fields that hold no value (an unset Optional, or fields inside an unset Optional or nil
+// pointer struct) yield (nil, true). Unknown key paths and nested struct paths
+// (which have no single value) return false.
+func Lookup(cfg any, keyPath string) (any, bool) {
+	v := reflect.ValueOf(cfg)
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil, false
+	}
+
+	provenanceMap := storedFieldProvenance(cfg)
+
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	keyPath = strings.ToLower(keyPath)
+	flat := make(map[string]any)
+	flattenStructFields(v, "", "", provenanceMap, flat)
+
+	var value any
+	found := false
+	for key, v := range flat {
+		// Keys from name/prefix tags keep their original case
+		if strings.ToLower(key) == keyPath {
+			value, found = v, true
+			break
+		}
+	}
+
+	known, secret := false, false
+	walkKeys(v.Type(), "", true, func(path string, field reflect.StructField) {
+		if path == keyPath && !isNestedStructType(field.Type, true) {
+			known = true
+			secret = parseTag(field.Tag.Get("conf")).secret
+		}
+	})
+
+	switch {
+	case !found && !known:
+		return nil, false
+	case !found:
+		return nil, true // Known field without a value
+	case secret && value != nil:
+		return "***redacted***", true
+	}
+	return value, true
+}
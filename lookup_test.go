@@ -0,0 +1,84 @@
+package rigging
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLookup(t *testing.T) {
+	type Database struct {
+		Host     string
+		Password string `conf:"secret"`
+	}
+	type Config struct {
+		Name     string
+		Timeout  time.Duration
+		Database Database
+		Replica  Optional[Database]
+		Retries  Optional[int]
+		Custom   string `conf:"name:Feature.Flag"`
+		APIKey   string `conf:"secret"`
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{
+			"name":              "api",
+			"timeout":           "5s",
+			"database.host":     "db.internal",
+			"database.password": "hunter2",
+			"feature.flag":      "on",
+		}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	cfg.APIKey = "set-after-load" // Redacted by tag, not provenance
+
+	tests := []struct {
+		keyPath   string
+		want      any
+		wantFound bool
+	}{
+		{keyPath: "name", want: "api", wantFound: true},
+		{keyPath: "timeout", want: "5s", wantFound: true},
+		{keyPath: "Database.Host", want: "db.internal", wantFound: true},
+		{keyPath: "database.password", want: "***redacted***", wantFound: true},
+		{keyPath: "apikey", want: "***redacted***", wantFound: true},
+		{keyPath: "feature.flag", want: "on", wantFound: true},
+		{keyPath: "retries", want: nil, wantFound: true},      // Unset Optional
+		{keyPath: "replica.host", want: nil, wantFound: true}, // Inside an unset Optional struct
+		{keyPath: "database", want: nil, wantFound: false},    // Nested struct
+		{keyPath: "unknown", want: nil, wantFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.keyPath, func(t *testing.T) {
+			got, found := Lookup(cfg, tt.keyPath)
+			if found != tt.wantFound || got != tt.want {
+				t.Errorf("Lookup(%q) = (%v, %v), want (%v, %v)", tt.keyPath, got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+
+	if _, found := Lookup(nil, "name"); found {
+		t.Error("Lookup(nil) should not find anything")
+	}
+}
+
+func TestLookup_StructValue(t *testing.T) {
+	type Config struct {
+		Hosts    []string
+		Labels   map[string]string
+		Password string `conf:"secret"`
+	}
+	cfg := Config{Hosts: []string{"a"}, Labels: map[string]string{"env": "prod"}, Password: "hunter2"}
+
+	// A struct value with slice or map fields has no stored provenance and must not panic
+	if got, found := Lookup(cfg, "password"); !found || got != "***redacted***" {
+		t.Errorf("Lookup(password) = (%v, %v), want (***redacted***, true)", got, found)
+	}
+	if _, found := Lookup(cfg, "hosts"); !found {
+		t.Error("Lookup(hosts) should find the slice field")
+	}
+}
@@ -0,0 +1,35 @@
+package rigging
+
+import "reflect"
+
+// MaskRule controls how values of fields tagged `mask:partial` are shown in DumpEffective,
+// snapshots, and flattened configs, e.g. "sk_l****1234". Values are only partially shown
+// when they have at least MinLength characters and at least half of them stay hidden;
+// otherwise they are fully redacted like other secrets.
+type MaskRule struct {
+	Prefix    int // Leading characters shown
+	Suffix    int // Trailing characters shown
+	MinLength int // Shorter values are fully redacted
+}
+
+// defaultMaskRule shows only the last four characters of values with 16 or more characters.
+var defaultMaskRule = MaskRule{Suffix: 4, MinLength: 16}
+
+// maskValue formats v for display with the rule applied. Strings are masked unquoted.
+func (r MaskRule) maskValue(v reflect.Value) string {
+	if v.IsValid() && v.Kind() == reflect.String {
+		return r.apply(v.String())
+	}
+	return r.apply(formatValueAsString(v))
+}
+
+// apply returns value with all but the allowed leading and trailing characters replaced
+// by "****", or "***redacted***" if the value is too short to reveal any of it.
+func (r MaskRule) apply(value string) string {
+	runes := []rune(value)
+	prefix, suffix := max(r.Prefix, 0), max(r.Suffix, 0)
+	if len(runes) < r.MinLength || (prefix+suffix)*2 > len(runes) {
+		return "***redacted***"
+	}
+	return string(runes[:prefix]) + "****" + string(runes[len(runes)-suffix:])
+}
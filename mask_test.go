@@ -0,0 +1,115 @@
+package rigging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMaskRule_Apply(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  MaskRule
+		value string
+		want  string
+	}{
+		{name: "default rule", rule: defaultMaskRule, value: "sk_live_abcdefgh1234", want: "****1234"},
+		{name: "short value is redacted", rule: defaultMaskRule, value: "abcd1234", want: "***redacted***"},
+		{name: "prefix and suffix", rule: MaskRule{Prefix: 8, Suffix: 4, MinLength: 16}, value: "sk_live_abcdefghijkl1234", want: "sk_live_****1234"},
+		{name: "at least half stays hidden", rule: MaskRule{Prefix: 8, Suffix: 4, MinLength: 16}, value: "sk_live_abcd1234", want: "***redacted***"},
+		{name: "counts characters, not bytes", rule: MaskRule{Suffix: 2, MinLength: 4}, value: "ünïcödé", want: "****dé"},
+		{name: "negative lengths show nothing", rule: MaskRule{Prefix: -1, Suffix: -1}, value: "secret", want: "****"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.apply(tt.value); got != tt.want {
+				t.Errorf("apply(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTag_MaskPartialImpliesSecret(t *testing.T) {
+	tagCfg := parseTag("mask:partial")
+	if !tagCfg.secret || tagCfg.mask != "partial" {
+		t.Errorf("parseTag(mask:partial) = %+v, want a secret with partial mask", tagCfg)
+	}
+	if tagCfg := parseTag("secret,mask:full"); tagCfg.mask != "full" || !tagCfg.secret {
+		t.Errorf("parseTag(secret,mask:full) = %+v", tagCfg)
+	}
+}
+
+func TestLoad_PartialMask(t *testing.T) {
+	type Config struct {
+		Token    string `conf:"mask:partial"`
+		ShortKey string `conf:"mask:partial"`
+		Password string `conf:"secret"`
+	}
+	data := map[string]any{
+		"token":    "sk_live_abcdefghijkl1234",
+		"shortkey": "abc123",
+		"password": "hunter2hunter2hunter2",
+	}
+
+	t.Run("default rule", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().WithSource(&mockSource{data: data}).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := DumpEffective(&buf, cfg); err != nil {
+			t.Fatalf("DumpEffective failed: %v", err)
+		}
+		output := buf.String()
+		for _, want := range []string{"token: ****1234", "shortkey: ***redacted***", "password: ***redacted***"} {
+			if !strings.Contains(output, want) {
+				t.Errorf("dump missing %q:\n%s", want, output)
+			}
+		}
+		if strings.Contains(output, "abcdefghijkl") || strings.Contains(output, "abc123") {
+			t.Errorf("dump reveals masked characters:\n%s", output)
+		}
+
+		prov, _ := GetProvenance(cfg)
+		if field := findProvenance(prov.Fields, "Token"); field == nil || !field.Secret {
+			t.Errorf("Token provenance = %+v, want Secret", field)
+		}
+	})
+
+	t.Run("custom rule applies to JSON and snapshots", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithSource(&mockSource{data: data}).
+			WithMaskRule(MaskRule{Prefix: 8, Suffix: 4, MinLength: 16}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := DumpEffective(&buf, cfg, AsJSON()); err != nil {
+			t.Fatalf("DumpEffective failed: %v", err)
+		}
+		var dumped map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &dumped); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		if dumped["token"] != "sk_live_****1234" {
+			t.Errorf("JSON token = %v, want sk_live_****1234", dumped["token"])
+		}
+
+		snapshot, err := CreateSnapshot(cfg)
+		if err != nil {
+			t.Fatalf("CreateSnapshot failed: %v", err)
+		}
+		if got := snapshot.Config["token"]; got != "sk_live_****1234" {
+			t.Errorf("snapshot token = %v, want sk_live_****1234", got)
+		}
+		if got := snapshot.Config["password"]; got != "***redacted***" {
+			t.Errorf("snapshot password = %v, want full redaction", got)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package rigging
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Struct metadata is derived from types and tags only, so it is computed once and shared by
+// all loaders, loads, and reloads. Entries are keyed by the exact tag or type (each generic
+// instantiation is a distinct reflect.Type), so different types never share metadata.
+var (
+	tagCache    sync.Map // Tag string -> tagConfig
+	fieldCache  sync.Map // fieldsKey -> []fieldKey
+	schemaCache sync.Map // schemaKey -> []FieldError
+)
+
+// fieldKey is one field visited by walkFieldKeys.
+type fieldKey struct {
+	keyPath   string
+	fieldPath string
+	field     reflect.StructField
+}
+
+// fieldsKey identifies the fields walkFieldKeys visits for a config type.
+type fieldsKey struct {
+	t              reflect.Type
+	pointerStructs bool
+}
+
+// cachedFields returns the fields walkFieldKeys visits for t without a prefix, in visit order.
+func cachedFields(t reflect.Type, pointerStructs bool) []fieldKey {
+	key := fieldsKey{t: t, pointerStructs: pointerStructs}
+	if cached, ok := fieldCache.Load(key); ok {
+		return cached.([]fieldKey)
+	}
+
+	var fields []fieldKey
+	walkFieldKeysUncached(t, "", "", pointerStructs, func(keyPath, fieldPath string, field reflect.StructField) {
+		fields = append(fields, fieldKey{keyPath: keyPath, fieldPath: fieldPath, field: field})
+	})
+	fieldCache.Store(key, fields)
+	return fields
+}
+
+// schemaKey identifies the schema check results of a config type under a nesting limit.
+type schemaKey struct {
+	t              reflect.Type
+	maxDepth       int
+	pointerStructs bool
+}
+
+// checkSchema reports problems of the config type itself: excessive nesting (see checkDepth),
+// then key collisions and invalid defaults. Results are cached per type and options; the
+// returned slice is a copy the caller may keep.
+func checkSchema(t reflect.Type, maxDepth int, pointerStructs bool) []FieldError {
+	key := schemaKey{t: t, maxDepth: maxDepth, pointerStructs: pointerStructs}
+	cached, ok := schemaCache.Load(key)
+	if !ok {
+		errs := checkDepth(t, maxDepth, pointerStructs)
+		if len(errs) == 0 {
+			errs = append(checkKeyCollisions(t, pointerStructs), checkDefaults(t, pointerStructs)...)
+		}
+		cached, _ = schemaCache.LoadOrStore(key, errs)
+	}
+
+	errs := cached.([]FieldError)
+	if len(errs) == 0 {
+		return nil
+	}
+	return append([]FieldError(nil), errs...)
+}
@@ -0,0 +1,63 @@
+package rigging
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type metadataWrapper[V any] struct {
+	Value V      `conf:"name:item.value"`
+	Label string `conf:"default:none"`
+}
+
+func TestMetadataCache_GenericInstantiations(t *testing.T) {
+	data := map[string]any{"item.value": "42"}
+
+	// Load each type twice so the second load uses cached metadata
+	for i := 0; i < 2; i++ {
+		intCfg, err := NewLoader[metadataWrapper[int]]().WithSource(&mockSource{data: data}).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load(int) error = %v", err)
+		}
+		if intCfg.Value != 42 || intCfg.Label != "none" {
+			t.Errorf("int config = %+v, want {42 none}", intCfg)
+		}
+
+		strCfg, err := NewLoader[metadataWrapper[string]]().WithSource(&mockSource{data: data}).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load(string) error = %v", err)
+		}
+		if strCfg.Value != "42" || strCfg.Label != "none" {
+			t.Errorf("string config = %+v, want {42 none}", strCfg)
+		}
+
+		_, err = NewLoader[metadataWrapper[bool]]().WithSource(&mockSource{data: data}).Load(context.Background())
+		if err == nil {
+			t.Error("Load(bool) expected a conversion error")
+		}
+	}
+
+	intFields := cachedFields(reflect.TypeOf(metadataWrapper[int]{}), false)
+	strFields := cachedFields(reflect.TypeOf(metadataWrapper[string]{}), false)
+	if intFields[0].field.Type == strFields[0].field.Type {
+		t.Errorf("instantiations share field metadata: %v", intFields[0].field.Type)
+	}
+}
+
+func TestMetadataCache_SchemaErrors(t *testing.T) {
+	type Config struct {
+		A string `conf:"name:key"`
+		B string `conf:"name:key"`
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := NewLoader[Config]().Load(context.Background())
+		valErr, ok := err.(*ValidationError)
+		if !ok || len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].Code != ErrCodeConfigSchema {
+			t.Fatalf("Load() error = %v, want one %s error", err, ErrCodeConfigSchema)
+		}
+		// Changing the returned errors must not affect later loads
+		valErr.FieldErrors[0].Code = "changed"
+	}
+}
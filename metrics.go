@@ -0,0 +1,25 @@
+package rigging
+
+import "time"
+
+// Metrics receives reload counters and timings from Watch.
+// The interface is intentionally minimal so it can be adapted to Prometheus,
+// OpenTelemetry, or any other metrics library with a few lines of glue code.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// IncReload counts a successful reload triggered by source (the change cause).
+	IncReload(source string)
+
+	// IncReloadError counts a failed reload triggered by source (the change cause).
+	IncReloadError(source string)
+
+	// ObserveReloadDuration records how long a reload took, successful or not.
+	ObserveReloadDuration(d time.Duration)
+}
+
+// noopMetrics is the default Metrics implementation and discards everything.
+type noopMetrics struct{}
+
+func (noopMetrics) IncReload(string)                    {}
+func (noopMetrics) IncReloadError(string)               {}
+func (noopMetrics) ObserveReloadDuration(time.Duration) {}
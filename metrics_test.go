@@ -0,0 +1,97 @@
+package rigging
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics is a test helper that records every metrics call.
+type recordingMetrics struct {
+	mu        sync.Mutex
+	reloads   []string
+	errors    []string
+	durations []time.Duration
+}
+
+func (m *recordingMetrics) IncReload(source string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reloads = append(m.reloads, source)
+}
+
+func (m *recordingMetrics) IncReloadError(source string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors = append(m.errors, source)
+}
+
+func (m *recordingMetrics) ObserveReloadDuration(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.durations = append(m.durations, d)
+}
+
+func TestWithMetrics_NilRestoresNoop(t *testing.T) {
+	loader := NewLoader[struct{}]().WithMetrics(nil)
+	if _, ok := loader.metrics.(noopMetrics); !ok {
+		t.Errorf("expected noopMetrics, got %T", loader.metrics)
+	}
+}
+
+func TestWatch_Metrics(t *testing.T) {
+	type Config struct {
+		Port int
+	}
+
+	source := newWatchableSource("test", map[string]any{"port": 8080})
+	defer source.close()
+
+	metrics := &recordingMetrics{}
+	loader := NewLoader[Config]().WithSource(source).WithMetrics(metrics)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	snapshots, errs, err := loader.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+	<-snapshots // Initial snapshot
+
+	// Successful reload
+	source.updateData(map[string]any{"port": 9090})
+	source.triggerChange("file-changed")
+	select {
+	case <-snapshots:
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for reload")
+	}
+
+	// Failed reload
+	source.updateData(map[string]any{"port": "not-a-number"})
+	source.triggerChange("bad-change")
+	select {
+	case <-snapshots:
+		t.Fatal("expected reload error")
+	case <-errs:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for reload error")
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	if len(metrics.reloads) != 1 || metrics.reloads[0] != "file-changed" {
+		t.Errorf("reloads = %v, want [file-changed]", metrics.reloads)
+	}
+	if len(metrics.errors) != 1 || metrics.errors[0] != "bad-change" {
+		t.Errorf("errors = %v, want [bad-change]", metrics.errors)
+	}
+	if len(metrics.durations) != 2 {
+		t.Errorf("expected 2 duration observations, got %d", len(metrics.durations))
+	}
+}
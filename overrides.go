@@ -0,0 +1,41 @@
+package rigging
+
+import (
+	"context"
+	"strings"
+)
+
+// contextOverrideSource is the provenance source name of values set with WithContextOverrides.
+const contextOverrideSource = "context"
+
+// contextOverridesKey is the context key of the overrides set with WithContextOverrides.
+type contextOverridesKey struct{}
+
+// WithContextOverrides returns a copy of ctx carrying configuration overrides.
+// Load consults the context and merges the overrides above all sources, with provenance
+// source "context". Keys use dot notation (e.g. "database.host") and are matched
+// case-insensitively. Overrides already in ctx are kept unless the same key is set again.
+// This is opt-in: a context without overrides loads exactly as before.
+//
+// Example:
+//
+//	ctx := rigging.WithContextOverrides(context.Background(), map[string]any{"database.port": 5433})
+//	cfg, err := loader.Load(ctx)
+func WithContextOverrides(ctx context.Context, overrides map[string]any) context.Context {
+	existing := contextOverrides(ctx)
+	merged := make(map[string]any, len(existing)+len(overrides))
+	for key, value := range existing {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[strings.ToLower(key)] = value
+	}
+	return context.WithValue(ctx, contextOverridesKey{}, merged)
+}
+
+// contextOverrides returns the overrides carried by ctx, keyed by lowercased key path.
+// The returned map must not be modified.
+func contextOverrides(ctx context.Context) map[string]any {
+	overrides, _ := ctx.Value(contextOverridesKey{}).(map[string]any)
+	return overrides
+}
@@ -0,0 +1,134 @@
+package rigging
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLoad_ContextOverrides(t *testing.T) {
+	type Database struct {
+		Host string
+		Port int `conf:"min:1"`
+	}
+	type Config struct {
+		Name     string
+		Database Database `conf:"prefix:database"`
+	}
+
+	sourceData := map[string]any{"name": "api", "database.host": "db.internal", "database.port": 5432}
+
+	tests := []struct {
+		name       string
+		ctx        func() context.Context
+		strict     bool
+		want       Config
+		wantSource map[string]string // FieldPath -> SourceName
+		wantErr    string
+	}{
+		{
+			name: "no overrides",
+			ctx:  context.Background,
+			want: Config{Name: "api", Database: Database{Host: "db.internal", Port: 5432}},
+			wantSource: map[string]string{
+				"Name":          "file",
+				"Database.Port": "file",
+			},
+		},
+		{
+			name: "override wins over sources",
+			ctx: func() context.Context {
+				return WithContextOverrides(context.Background(), map[string]any{"database.port": 5433})
+			},
+			want: Config{Name: "api", Database: Database{Host: "db.internal", Port: 5433}},
+			wantSource: map[string]string{
+				"Name":          "file",
+				"Database.Port": "context",
+			},
+		},
+		{
+			name: "keys are case-insensitive",
+			ctx: func() context.Context {
+				return WithContextOverrides(context.Background(), map[string]any{"Database.Host": "localhost"})
+			},
+			want:       Config{Name: "api", Database: Database{Host: "localhost", Port: 5432}},
+			wantSource: map[string]string{"Database.Host": "context"},
+		},
+		{
+			name: "nested calls layer overrides",
+			ctx: func() context.Context {
+				ctx := WithContextOverrides(context.Background(), map[string]any{"name": "outer", "database.host": "outer-db"})
+				return WithContextOverrides(ctx, map[string]any{"name": "inner"})
+			},
+			want: Config{Name: "inner", Database: Database{Host: "outer-db", Port: 5432}},
+			wantSource: map[string]string{
+				"Name":          "context",
+				"Database.Host": "context",
+			},
+		},
+		{
+			name: "overrides are validated",
+			ctx: func() context.Context {
+				return WithContextOverrides(context.Background(), map[string]any{"database.port": -1})
+			},
+			wantErr: "Database.Port",
+		},
+		{
+			name: "unknown override key rejected in strict mode",
+			ctx: func() context.Context {
+				return WithContextOverrides(context.Background(), map[string]any{"database.hots": "typo"})
+			},
+			strict:  true,
+			wantErr: "database.hots",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewLoader[Config]().
+				WithSource(&mockSource{name: "file", data: sourceData}).
+				Strict(tt.strict).
+				Load(tt.ctx())
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error mentioning %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			if *cfg != tt.want {
+				t.Errorf("cfg = %+v, want %+v", *cfg, tt.want)
+			}
+
+			prov, ok := GetProvenance(cfg)
+			if !ok {
+				t.Fatal("provenance not found")
+			}
+			for fieldPath, source := range tt.wantSource {
+				fp := findProvenance(prov.Fields, fieldPath)
+				if fp == nil {
+					t.Fatalf("no provenance for %s", fieldPath)
+				}
+				if fp.SourceName != source {
+					t.Errorf("%s source = %q, want %q", fieldPath, fp.SourceName, source)
+				}
+			}
+		})
+	}
+}
+
+func TestWithContextOverrides_CopiesMap(t *testing.T) {
+	overrides := map[string]any{"name": "before"}
+	ctx := WithContextOverrides(context.Background(), overrides)
+	overrides["name"] = "after"
+
+	if got := contextOverrides(ctx)["name"]; got != "before" {
+		t.Errorf("override = %v, want %q", got, "before")
+	}
+	if got := contextOverrides(context.Background()); got != nil {
+		t.Errorf("expected no overrides on a plain context, got %v", got)
+	}
+}
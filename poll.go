@@ -0,0 +1,141 @@
+package rigging
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
+
+type pollSource struct {
+	inner    Source
+	interval time.Duration
+	clock    func() time.Time // Source of ChangeEvent.At (nil = time.Now)
+}
+
+// PollOption configures a PollSource.
+type PollOption func(*pollSource)
+
+// WithPollClock sets the function that provides ChangeEvent.At, e.g. a fixed time in tests.
+// Default: time.Now.
+func WithPollClock(now func() time.Time) PollOption {
+	return func(p *pollSource) {
+		p.clock = now
+	}
+}
+
+// PollSource wraps a source so that Watch works by polling: every interval the inner source is
+// loaded and a ChangeEvent with cause "poll-changed" is emitted when its content differs from
+// the previous poll. Failed polls are skipped. Load, LoadWithKeys, LoadWithPositions, LoadWithSecrets, and Name
+// are forwarded.
+func PollSource(inner Source, interval time.Duration, opts ...PollOption) Source {
+	p := &pollSource{inner: inner, interval: interval}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Load forwards to the inner source.
+func (p *pollSource) Load(ctx context.Context) (map[string]any, error) {
+	return p.inner.Load(ctx)
+}
+
+// LoadWithKeys forwards to the inner source, falling back to Load if it doesn't track original keys.
+func (p *pollSource) LoadWithKeys(ctx context.Context) (map[string]any, map[string]string, error) {
+	loaded, err := p.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, err
+}
+
+// LoadWithPositions forwards to the inner source, omitting lines if it doesn't report them.
+func (p *pollSource) LoadWithPositions(ctx context.Context) (map[string]any, map[string]string, map[string]int, error) {
+	loaded, err := p.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, loaded.lines, err
+}
+
+// LoadWithSecrets forwards to the inner source, omitting secret keys if it doesn't mark them.
+func (p *pollSource) LoadWithSecrets(ctx context.Context) (map[string]any, map[string]string, map[string]bool, error) {
+	loaded, err := p.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, loaded.secrets, err
+}
+
+// loadAll forwards to the inner source.
+func (p *pollSource) loadAll(ctx context.Context) (loadedSource, error) {
+	return loadFrom(ctx, p.inner)
+}
+
+// Watch polls the inner source until ctx is cancelled, then closes the channel.
+func (p *pollSource) Watch(ctx context.Context) (<-chan ChangeEvent, error) {
+	if p.interval <= 0 {
+		return nil, fmt.Errorf("poll %s: interval must be positive", p.inner.Name())
+	}
+
+	// The content at Watch time is the baseline; only later changes are reported
+	var last [sha256.Size]byte
+	hasLast := false
+	if data, err := p.inner.Load(ctx); err == nil {
+		last, hasLast = hashData(data), true
+	}
+
+	ch := make(chan ChangeEvent)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			data, err := p.inner.Load(ctx)
+			if err != nil {
+				continue
+			}
+
+			sum := hashData(data)
+			if hasLast && sum == last {
+				continue
+			}
+			changed := hasLast
+			last, hasLast = sum, true
+			if !changed {
+				continue
+			}
+
+			select {
+			case ch <- ChangeEvent{At: p.now(), Cause: "poll-changed"}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// Name forwards to the inner source.
+func (p *pollSource) Name() string {
+	return p.inner.Name()
+}
+
+// now returns the current time from the clock set with WithPollClock.
+func (p *pollSource) now() time.Time {
+	if p.clock != nil {
+		return p.clock()
+	}
+	return time.Now()
+}
+
+// hashData returns a content hash of source data that doesn't depend on map iteration order.
+func hashData(data map[string]any) [sha256.Size]byte {
+	encoded, err := canonicalJSON(data)
+	if err != nil {
+		// fmt prints maps with sorted keys
+		encoded = []byte(fmt.Sprint(data))
+	}
+	return sha256.Sum256(encoded)
+}
@@ -0,0 +1,156 @@
+package rigging
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mutableSource is a test helper whose data can be replaced between loads.
+type mutableSource struct {
+	mu   sync.Mutex
+	data map[string]any
+}
+
+func (m *mutableSource) Load(ctx context.Context) (map[string]any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return copyData(m.data), nil
+}
+
+func (m *mutableSource) set(data map[string]any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data = data
+}
+
+func (m *mutableSource) Watch(ctx context.Context) (<-chan ChangeEvent, error) {
+	return nil, ErrWatchNotSupported
+}
+
+func (m *mutableSource) Name() string {
+	return "mutable"
+}
+
+func TestPollSource_EmitsOnlyOnChange(t *testing.T) {
+	inner := &mutableSource{data: map[string]any{"host": "a", "port": 1}}
+	src := PollSource(inner, 10*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := src.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	// Unchanged content produces no event
+	select {
+	case event := <-ch:
+		t.Fatalf("unexpected event without change: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	inner.set(map[string]any{"host": "b", "port": 1})
+	select {
+	case event := <-ch:
+		if event.Cause != "poll-changed" {
+			t.Errorf("Cause = %q, want poll-changed", event.Cause)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for change event")
+	}
+
+	// Same content again produces no further event
+	select {
+	case event := <-ch:
+		t.Fatalf("unexpected second event: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("expected channel to be closed after cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancellation")
+	}
+}
+
+func TestPollSource_Clock(t *testing.T) {
+	inner := &mutableSource{data: map[string]any{"host": "a"}}
+	fixed := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	src := PollSource(inner, 10*time.Millisecond, WithPollClock(func() time.Time { return fixed }))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := src.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	inner.set(map[string]any{"host": "b"})
+	select {
+	case event := <-ch:
+		if !event.At.Equal(fixed) {
+			t.Errorf("At = %v, want %v", event.At, fixed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for change event")
+	}
+}
+
+func TestPollSource_Forwards(t *testing.T) {
+	inner := &mutableSource{data: map[string]any{"host": "a"}}
+	src := PollSource(inner, time.Second)
+
+	data, err := src.Load(context.Background())
+	if err != nil || data["host"] != "a" {
+		t.Errorf("Load() = %v, %v", data, err)
+	}
+	if src.Name() != "mutable" {
+		t.Errorf("Name() = %q, want mutable", src.Name())
+	}
+
+	if _, err := PollSource(inner, 0).Watch(context.Background()); err == nil {
+		t.Error("expected error for non-positive interval")
+	}
+}
+
+func TestPollSource_WithLoaderWatch(t *testing.T) {
+	type Config struct {
+		Host string
+	}
+
+	inner := &mutableSource{data: map[string]any{"host": "a"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	snapshots, _, err := NewLoader[Config]().
+		WithSource(PollSource(inner, 10*time.Millisecond)).
+		Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	<-snapshots // Initial
+
+	inner.set(map[string]any{"host": "b"})
+	select {
+	case snapshot := <-snapshots:
+		if snapshot.Config.Host != "b" || snapshot.Source != "poll-changed" {
+			t.Errorf("unexpected snapshot: host %q, source %q", snapshot.Config.Host, snapshot.Source)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+}
+
+func TestPollSource_ForwardsPositionsAndSecrets(t *testing.T) {
+	testWrapperForwards(t, func(inner Source) Source {
+		return PollSource(inner, time.Second)
+	})
+}
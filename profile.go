@@ -0,0 +1,84 @@
+package rigging
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithProfile activates a profile, so that keys qualified with it override their base key:
+// "database.host@production" (in YAML, `host@production:` under `database:`) replaces
+// "database.host" when the profile is "production". This lets one file carry
+// environment-specific overrides next to the shared values.
+//
+// A profile-qualified key is a key path followed by "@" and a profile name without dots.
+// The active profile's keys win over the base key from any source, but not over context
+// overrides; among themselves they follow the usual source precedence. Keys qualified with
+// other profiles are dropped. Profile names match case-insensitively, and an empty name
+// activates no profile. Keys below map fields are never profile-qualified, since map keys
+// may contain "@".
+//
+// In strict mode, a profile-qualified key whose base key maps to no field fails Load with
+// ErrCodeUnknownKey, whether its profile is active or not. Without WithProfile, "@" has no
+// special meaning.
+func (l *Loader[T]) WithProfile(name string) *Loader[T] {
+	l.profiles = true
+	l.profile = strings.ToLower(strings.TrimSpace(name))
+	return l
+}
+
+// splitProfileKey splits a profile-qualified key ("database.host@production") into its
+// base key and profile. It reports false for keys that are not profile-qualified.
+func splitProfileKey(key string) (string, string, bool) {
+	i := strings.LastIndexByte(key, '@')
+	if i <= 0 || i == len(key)-1 || strings.Contains(key[i+1:], ".") {
+		return "", "", false
+	}
+	return key[:i], key[i+1:], true
+}
+
+// applyProfile replaces base keys with the active profile's variants and removes all
+// profile-qualified keys from mergedData. It returns unknown-key errors in strict mode.
+func (l *Loader[T]) applyProfile(ctx context.Context, cfgType reflect.Type, mergedData map[string]mergedEntry, strictKeys map[string]bool, trace *mergeTrace) []FieldError {
+	validKeys := collectValidKeys(cfgType, "", l.ptrStructs)
+	mapKeys := collectMapKeys(cfgType, "", l.ptrStructs)
+
+	var qualified []string
+	for key := range mergedData {
+		if _, _, ok := splitProfileKey(key); ok && !isValidKey(key, nil, mapKeys) {
+			qualified = append(qualified, key)
+		}
+	}
+	sort.Strings(qualified) // Stable error order
+
+	var errs []FieldError
+	for _, key := range qualified {
+		base, profile, _ := splitProfileKey(key)
+		entry, strict := mergedData[key], strictKeys[key]
+		delete(mergedData, key)
+		delete(strictKeys, key)
+
+		if !isValidKey(base, validKeys, mapKeys) {
+			if l.strict && strict && !l.isIgnoredKey(base) {
+				errs = append(errs, FieldError{
+					FieldPath: key,
+					Code:      ErrCodeUnknownKey,
+					Message:   "profile override of unknown configuration key (strict mode)",
+				})
+			}
+			continue
+		}
+		if profile != l.profile {
+			continue
+		}
+
+		if previous, ok := mergedData[base]; ok {
+			l.logDebug(ctx, "key overridden", "key", base, "previous", previous.sourceName, "source", entry.sourceName, "profile", profile)
+		}
+		mergedData[base] = entry
+		strictKeys[base] = strictKeys[base] || strict
+		trace.offer(base, entry)
+	}
+	return errs
+}
@@ -0,0 +1,211 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestLoader_WithProfile(t *testing.T) {
+	type Database struct {
+		Host string
+		Port int
+	}
+	type Config struct {
+		Database Database
+		Labels   map[string]string
+	}
+
+	file := &mockSource{name: "file", data: map[string]any{
+		"database.host":            "localhost",
+		"database.port":            5432,
+		"database.host@production": "db.prod",
+		"database.port@staging":    6543,
+		"labels.team@example.com":  "platform",
+	}}
+
+	tests := []struct {
+		name       string
+		loader     func() *Loader[Config]
+		ctx        context.Context
+		wantHost   string
+		wantPort   int
+		wantSource string
+	}{
+		{
+			name: "no profile support",
+			loader: func() *Loader[Config] {
+				return NewLoader[Config]().WithSource(file).Strict(false)
+			},
+			wantHost:   "localhost",
+			wantPort:   5432,
+			wantSource: "file",
+		},
+		{
+			name: "active profile overrides base key",
+			loader: func() *Loader[Config] {
+				return NewLoader[Config]().WithSource(file).WithProfile("production")
+			},
+			wantHost:   "db.prod",
+			wantPort:   5432,
+			wantSource: "file",
+		},
+		{
+			name: "profile name is case-insensitive",
+			loader: func() *Loader[Config] {
+				return NewLoader[Config]().WithSource(file).WithProfile("Staging")
+			},
+			wantHost:   "localhost",
+			wantPort:   6543,
+			wantSource: "file",
+		},
+		{
+			name: "empty profile drops all variants",
+			loader: func() *Loader[Config] {
+				return NewLoader[Config]().WithSource(file).WithProfile("")
+			},
+			wantHost:   "localhost",
+			wantPort:   5432,
+			wantSource: "file",
+		},
+		{
+			name: "profile variant wins over later source",
+			loader: func() *Loader[Config] {
+				return NewLoader[Config]().
+					WithSource(file).
+					WithSource(&mockSource{name: "env", data: map[string]any{"database.host": "db.env"}}).
+					WithProfile("production")
+			},
+			wantHost:   "db.prod",
+			wantPort:   5432,
+			wantSource: "file",
+		},
+		{
+			name: "later source variant wins over earlier one",
+			loader: func() *Loader[Config] {
+				return NewLoader[Config]().
+					WithSource(file).
+					WithSource(&mockSource{name: "env", data: map[string]any{"database.host@production": "db.env"}}).
+					WithProfile("production")
+			},
+			wantHost:   "db.env",
+			wantPort:   5432,
+			wantSource: "env",
+		},
+		{
+			name: "context override wins over variant",
+			loader: func() *Loader[Config] {
+				return NewLoader[Config]().WithSource(file).WithProfile("production")
+			},
+			ctx:        WithContextOverrides(context.Background(), map[string]any{"database.host": "db.override"}),
+			wantHost:   "db.override",
+			wantPort:   5432,
+			wantSource: contextOverrideSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			cfg, err := tt.loader().Load(ctx)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Database.Host != tt.wantHost {
+				t.Errorf("Database.Host = %q, want %q", cfg.Database.Host, tt.wantHost)
+			}
+			if cfg.Database.Port != tt.wantPort {
+				t.Errorf("Database.Port = %d, want %d", cfg.Database.Port, tt.wantPort)
+			}
+			// Keys below map fields are never profile-qualified
+			if cfg.Labels["team@example.com"] != "platform" {
+				t.Errorf("Labels = %v, want team@example.com key", cfg.Labels)
+			}
+
+			prov, ok := GetProvenance(cfg)
+			if !ok {
+				t.Fatal("GetProvenance() found no provenance")
+			}
+			host := findProvenance(prov.Fields, "Database.Host")
+			if host == nil {
+				t.Fatal("no provenance for Database.Host")
+			}
+			if host.SourceName != tt.wantSource {
+				t.Errorf("Database.Host source = %q, want %q", host.SourceName, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestLoader_WithProfile_Strict(t *testing.T) {
+	type Config struct {
+		Host string
+	}
+
+	tests := []struct {
+		name     string
+		data     map[string]any
+		profile  string
+		strict   bool
+		wantPath string
+	}{
+		{
+			name:     "unknown base key of active profile",
+			data:     map[string]any{"hots@production": "db.prod"},
+			profile:  "production",
+			strict:   true,
+			wantPath: "hots@production",
+		},
+		{
+			name:     "unknown base key of inactive profile",
+			data:     map[string]any{"hots@staging": "db.staging"},
+			profile:  "production",
+			strict:   true,
+			wantPath: "hots@staging",
+		},
+		{
+			name:    "known base key",
+			data:    map[string]any{"host@staging": "db.staging"},
+			profile: "production",
+			strict:  true,
+		},
+		{
+			name:    "non-strict",
+			data:    map[string]any{"hots@production": "db.prod"},
+			profile: "production",
+			strict:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLoader[Config]().
+				WithSource(&mockSource{data: tt.data}).
+				WithProfile(tt.profile).
+				Strict(tt.strict).
+				Load(context.Background())
+
+			if tt.wantPath == "" {
+				if err != nil {
+					t.Fatalf("Load() error = %v", err)
+				}
+				return
+			}
+
+			var valErr *ValidationError
+			if !errors.As(err, &valErr) {
+				t.Fatalf("Load() error = %v, want *ValidationError", err)
+			}
+			if len(valErr.FieldErrors) != 1 {
+				t.Fatalf("FieldErrors = %v, want 1 error", valErr.FieldErrors)
+			}
+			fe := valErr.FieldErrors[0]
+			if fe.Code != ErrCodeUnknownKey || fe.FieldPath != tt.wantPath {
+				t.Errorf("FieldError = %+v, want %s at %q", fe, ErrCodeUnknownKey, tt.wantPath)
+			}
+		})
+	}
+}
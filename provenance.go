@@ -1,23 +1,47 @@
 package rigging
 
-import "sync"
+import (
+	"reflect"
+	"sync"
+)
 
 // Provenance contains source information for configuration fields.
 type Provenance struct {
-	Fields []FieldProvenance
+	Fields  []FieldProvenance
+	Ignored []IgnoredKey // Unknown keys exempted by IgnoreKeys or WithLenientSource, sorted by key path
+
+	// Modified reports that the config was changed after Load, so Fields may no longer
+	// describe its values. Only detected for configs loaded with WithFreeze(true).
+	Modified bool
+
+	checksum string // configHash at load time, set with WithFreeze
+}
+
+// IgnoredKey describes a key that was provided but skipped because of IgnoreKeys.
+type IgnoredKey struct {
+	KeyPath    string // Normalized key (e.g., "meta.owner")
+	SourceName string // Source that provided the key
 }
 
 // FieldProvenance describes where a field's value came from.
 type FieldProvenance struct {
-	FieldPath  string // Dot notation (e.g., "Database.Host")
-	KeyPath    string // Normalized key (e.g., "database.host")
-	SourceName string // Source identifier (e.g., "env:APP_PORT")
-	Secret     bool   // Whether field is secret
+	FieldPath    string // Dot notation (e.g., "Database.Host")
+	KeyPath      string // Normalized key (e.g., "database.host")
+	SourceName   string // Source identifier (e.g., "env:APP_PORT")
+	Secret       bool   // Whether field is secret
+	Layer        string // Tag of the winning source (see WithTag), empty if untagged
+	Line         int    // Line in the winning source (see SourceWithPositions), 0 if unknown
+	Explicit     bool   // Value came from a source or context override, not a default layer (tag default, WithDefaults, WithBaseConfig)
+	Interpolated bool   // Value contained ${key} references that were resolved (see WithInterpolation)
+
+	mask *MaskRule // Partial masking of the secret value (mask:partial), nil for full redaction
 }
 
 var provenanceStore sync.Map
 
 // GetProvenance returns provenance metadata for a loaded configuration.
+// The result is a copy, so changing it does not affect later calls.
+// For configs loaded with WithFreeze(true), Modified reports whether the config changed since Load.
 // Thread-safe.
 func GetProvenance[T any](cfg *T) (*Provenance, bool) {
 	if cfg == nil {
@@ -29,8 +53,38 @@ func GetProvenance[T any](cfg *T) (*Provenance, bool) {
 		return nil, false
 	}
 
-	prov, ok := value.(*Provenance)
-	return prov, ok
+	stored, ok := value.(*Provenance)
+	if !ok {
+		return nil, false
+	}
+
+	prov := &Provenance{
+		Fields:  append([]FieldProvenance(nil), stored.Fields...),
+		Ignored: append([]IgnoredKey(nil), stored.Ignored...),
+	}
+	if stored.checksum != "" {
+		hash, err := configHash(reflect.ValueOf(cfg).Elem())
+		prov.Modified = err != nil || hash != stored.checksum
+	}
+	return prov, true
+}
+
+// storedFieldProvenance returns the stored provenance of cfg by field path, or an empty map.
+// Only pointers are looked up: provenance is stored by config pointer, and a struct value
+// with slice or map fields is not a valid sync.Map key.
+func storedFieldProvenance(cfg any) map[string]*FieldProvenance {
+	fields := make(map[string]*FieldProvenance)
+	if reflect.ValueOf(cfg).Kind() != reflect.Ptr {
+		return fields
+	}
+	if value, ok := provenanceStore.Load(cfg); ok {
+		if prov, ok := value.(*Provenance); ok {
+			for i := range prov.Fields {
+				fields[prov.Fields[i].FieldPath] = &prov.Fields[i]
+			}
+		}
+	}
+	return fields
 }
 
 func storeProvenance[T any](cfg *T, prov *Provenance) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -119,6 +120,88 @@ func TestProvenance_StoreAndDelete(t *testing.T) {
 	}
 }
 
+func TestProvenance_DefensiveCopy(t *testing.T) {
+	type TestConfig struct {
+		Host string
+		Port int
+	}
+
+	cfg, err := NewLoader[TestConfig]().
+		WithSource(&mockSource{name: "env", data: map[string]any{"host": "localhost", "port": 8080, "meta.x": 1}}).
+		IgnoreKeys("meta").
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	prov, _ := GetProvenance(cfg)
+	prov.Fields[0].SourceName = "tampered"
+	prov.Fields = append(prov.Fields[:0], FieldProvenance{FieldPath: "Injected"})
+	prov.Ignored[0].KeyPath = "tampered"
+
+	again, _ := GetProvenance(cfg)
+	if len(again.Fields) != 2 {
+		t.Fatalf("expected 2 fields, got %+v", again.Fields)
+	}
+	for _, field := range again.Fields {
+		if field.SourceName != "env" {
+			t.Errorf("stored provenance was modified: %+v", field)
+		}
+	}
+	if again.Ignored[0].KeyPath != "meta.x" {
+		t.Errorf("stored ignored keys were modified: %+v", again.Ignored)
+	}
+}
+
+func TestProvenance_WithFreeze(t *testing.T) {
+	type TestConfig struct {
+		Host     string
+		Password string `conf:"secret"`
+		Tags     []string
+	}
+
+	load := func(freeze bool) *TestConfig {
+		t.Helper()
+		cfg, err := NewLoader[TestConfig]().
+			WithSource(&mockSource{data: map[string]any{"host": "localhost", "password": "p1", "tags": "a,b"}}).
+			WithFreeze(freeze).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load failed: %v", err)
+		}
+		return cfg
+	}
+
+	tests := []struct {
+		name         string
+		freeze       bool
+		mutate       func(cfg *TestConfig)
+		wantModified bool
+	}{
+		{name: "unchanged", freeze: true, mutate: func(cfg *TestConfig) {}},
+		{name: "field changed", freeze: true, mutate: func(cfg *TestConfig) { cfg.Host = "other" }, wantModified: true},
+		{name: "secret changed", freeze: true, mutate: func(cfg *TestConfig) { cfg.Password = "p2" }, wantModified: true},
+		{name: "slice element changed", freeze: true, mutate: func(cfg *TestConfig) { cfg.Tags[0] = "z" }, wantModified: true},
+		{name: "changed back", freeze: true, mutate: func(cfg *TestConfig) { cfg.Host = "x"; cfg.Host = "localhost" }},
+		{name: "not detected without freeze", freeze: false, mutate: func(cfg *TestConfig) { cfg.Host = "other" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := load(tt.freeze)
+			tt.mutate(cfg)
+
+			prov, ok := GetProvenance(cfg)
+			if !ok {
+				t.Fatal("expected provenance")
+			}
+			if prov.Modified != tt.wantModified {
+				t.Errorf("Modified = %v, want %v", prov.Modified, tt.wantModified)
+			}
+		})
+	}
+}
+
 func TestProvenance_SecretField(t *testing.T) {
 	type TestConfig struct {
 		Password string
@@ -491,6 +574,76 @@ func TestProvenance_DefaultValues(t *testing.T) {
 }
 
 // TestProvenance_NestedStructs verifies that provenance tracks nested struct fields.
+func TestProvenance_Explicit(t *testing.T) {
+	type Config struct {
+		FromSource   Optional[int] `conf:"default:1"`
+		FromTag      Optional[int] `conf:"default:2"`
+		FromDefaults Optional[int]
+		FromBase     Optional[int]
+		FromContext  Optional[int]
+		Unset        Optional[int]
+		Plain        string `conf:"default:x"`
+	}
+
+	ctx := WithContextOverrides(context.Background(), map[string]any{"fromcontext": 6})
+	cfg, err := NewLoader[Config]().
+		WithDefaults(map[string]any{"fromdefaults": 3}).
+		WithBaseConfig(map[string]any{"frombase": 4}).
+		WithSource(&mockSource{name: "env", data: map[string]any{"fromsource": 5}}).
+		Load(ctx)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	tests := []struct {
+		field        string
+		wantSource   string
+		wantExplicit bool
+	}{
+		{field: "FromSource", wantSource: "env", wantExplicit: true},
+		{field: "FromTag", wantSource: "default"},
+		{field: "FromDefaults", wantSource: "loader-default"},
+		{field: "FromBase", wantSource: "base"},
+		{field: "FromContext", wantSource: "context", wantExplicit: true},
+		{field: "Plain", wantSource: "default"},
+	}
+
+	prov, ok := GetProvenance(cfg)
+	if !ok {
+		t.Fatal("provenance not found")
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			fp := findProvenance(prov.Fields, tt.field)
+			if fp == nil {
+				t.Fatalf("no provenance for %s", tt.field)
+			}
+			if fp.SourceName != tt.wantSource || fp.Explicit != tt.wantExplicit {
+				t.Errorf("got source %q explicit %v, want %q %v", fp.SourceName, fp.Explicit, tt.wantSource, tt.wantExplicit)
+			}
+		})
+	}
+	if fp := findProvenance(prov.Fields, "Unset"); fp != nil {
+		t.Errorf("expected no provenance for an unset Optional, got %+v", fp)
+	}
+
+	t.Run("dump with sources", func(t *testing.T) {
+		var buf strings.Builder
+		if err := DumpEffective(&buf, cfg, AsJSON(), WithSources(), WithIndent("")); err != nil {
+			t.Fatalf("DumpEffective failed: %v", err)
+		}
+		for _, want := range []string{
+			`"fromSource":{"value":5,"source":"env","explicit":true}`,
+			`"fromTag":{"value":2,"source":"default","explicit":false}`,
+			`"unset":null`,
+		} {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("expected %s in %s", want, buf.String())
+			}
+		}
+	})
+}
+
 func TestProvenance_NestedStructs(t *testing.T) {
 	type Database struct {
 		Host     string `conf:"required"`
@@ -824,3 +977,175 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestProvenance_SourceLayerTag(t *testing.T) {
+	type Config struct {
+		Host     string
+		Port     int `conf:"default:8080"`
+		Password string
+		Database struct {
+			Name string
+		}
+	}
+
+	loader := NewLoader[Config]().
+		WithSource(&mockSource{name: "file", data: map[string]any{"host": "localhost", "database": map[string]any{"name": "app"}}}, WithTag("base")).
+		WithSource(&mockSource{name: "vault", data: map[string]any{"password": "s3cr3t"}}, WithTag("secrets")).
+		WithSource(&mockSource{name: "untagged", data: map[string]any{}})
+
+	cfg, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	prov, ok := GetProvenance(cfg)
+	if !ok {
+		t.Fatal("expected provenance")
+	}
+
+	tests := []struct {
+		fieldPath string
+		wantLayer string
+	}{
+		{"Host", "base"},
+		{"Password", "secrets"},
+		{"Database.Name", "base"},
+		{"Port", ""}, // Tag defaults have no layer
+	}
+
+	for _, tt := range tests {
+		fp := findProvenance(prov.Fields, tt.fieldPath)
+		if fp == nil {
+			t.Errorf("no provenance for %s", tt.fieldPath)
+			continue
+		}
+		if fp.Layer != tt.wantLayer {
+			t.Errorf("%s: Layer = %q, want %q", tt.fieldPath, fp.Layer, tt.wantLayer)
+		}
+	}
+
+	var buf strings.Builder
+	if err := DumpEffective(&buf, cfg, WithSources()); err != nil {
+		t.Fatalf("DumpEffective failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "(source: vault, layer: secrets)") {
+		t.Errorf("expected layer in dump output, got:\n%s", buf.String())
+	}
+}
+
+// mockSourceWithPositions implements SourceWithPositions for testing.
+type mockSourceWithPositions struct {
+	mockSourceWithKeys
+	lines map[string]int
+}
+
+func (m *mockSourceWithPositions) LoadWithPositions(ctx context.Context) (map[string]any, map[string]string, map[string]int, error) {
+	data, originalKeys, err := m.LoadWithKeys(ctx)
+	return data, originalKeys, m.lines, err
+}
+
+// mockSourceWithSecrets implements SourceWithSecrets for testing.
+type mockSourceWithSecrets struct {
+	mockSourceWithKeys
+	secrets map[string]bool
+}
+
+func (m *mockSourceWithSecrets) LoadWithSecrets(ctx context.Context) (map[string]any, map[string]string, map[string]bool, error) {
+	data, originalKeys, err := m.LoadWithKeys(ctx)
+	return data, originalKeys, m.secrets, err
+}
+
+// testWrapperForwards verifies that a source wrapped by wrap still reports
+// the inner source's lines and secret keys to the loader.
+func testWrapperForwards(t *testing.T, wrap func(Source) Source) {
+	t.Helper()
+
+	type Config struct {
+		Host  string
+		Token string
+	}
+
+	positioned := &mockSourceWithPositions{
+		mockSourceWithKeys: mockSourceWithKeys{name: "file:config.yaml", data: map[string]any{"host": "localhost"}},
+		lines:              map[string]int{"host": 3},
+	}
+	secret := &mockSourceWithSecrets{
+		mockSourceWithKeys: mockSourceWithKeys{name: "vault", data: map[string]any{"token": "s3cr3t"}},
+		secrets:            map[string]bool{"token": true},
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(wrap(positioned)).
+		WithSource(wrap(secret)).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	prov, _ := GetProvenance(cfg)
+	if fp := findProvenance(prov.Fields, "Host"); fp == nil || fp.Line != 3 {
+		t.Errorf("Host provenance = %+v, want line 3", fp)
+	}
+	if fp := findProvenance(prov.Fields, "Token"); fp == nil || !fp.Secret {
+		t.Errorf("Token provenance = %+v, want secret", fp)
+	}
+
+	var buf strings.Builder
+	if err := DumpEffective(&buf, cfg); err != nil {
+		t.Fatalf("DumpEffective failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "s3cr3t") {
+		t.Errorf("secret value leaked in dump:\n%s", buf.String())
+	}
+}
+
+// TestProvenance_Line verifies that the line reported by the winning source is recorded.
+func TestProvenance_Line(t *testing.T) {
+	type Config struct {
+		Host string
+		Port int
+		Name string
+	}
+
+	positioned := &mockSourceWithPositions{
+		mockSourceWithKeys: mockSourceWithKeys{
+			name: "file:config.yaml",
+			data: map[string]any{"host": "localhost", "port": 8080},
+		},
+		lines: map[string]int{"host": 3, "port": 7},
+	}
+	plain := &mockSource{name: "env", data: map[string]any{"port": 9090, "name": "app"}}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(positioned).
+		WithSource(plain).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	prov, ok := GetProvenance(cfg)
+	if !ok {
+		t.Fatal("expected provenance")
+	}
+
+	tests := []struct {
+		fieldPath string
+		wantLine  int
+	}{
+		{"Host", 3},
+		{"Port", 0}, // Overridden by a source without positions
+		{"Name", 0},
+	}
+
+	for _, tt := range tests {
+		fp := findProvenance(prov.Fields, tt.fieldPath)
+		if fp == nil {
+			t.Errorf("no provenance for %s", tt.fieldPath)
+			continue
+		}
+		if fp.Line != tt.wantLine {
+			t.Errorf("%s: Line = %d, want %d", tt.fieldPath, fp.Line, tt.wantLine)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryOptions configures RetrySource.
+type RetryOptions struct {
+	// MaxAttempts is the total number of Load attempts, including the first (default: 3).
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry; it doubles on each retry (default: 100ms).
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between attempts (default: 10s).
+	MaxDelay time.Duration
+
+	// Jitter randomizes each delay by up to ±Jitter of its value (0 to 1, default: 0).
+	Jitter float64
+}
+
+// temporary is implemented by errors that know whether they are transient.
+type temporary interface {
+	Temporary() bool
+}
+
+type retrySource struct {
+	inner Source
+	opts  RetryOptions
+}
+
+// RetrySource wraps a source so that Load is retried on error with exponential backoff.
+// Errors implementing Temporary() bool that return false, including wrapped ones, are not retried.
+// Retries stop when the context is cancelled. Original keys, positions, and secret keys
+// reported by the inner source are kept. Watch is forwarded to the inner source.
+func RetrySource(inner Source, opts RetryOptions) Source {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 3
+	}
+	if opts.BaseDelay <= 0 {
+		opts.BaseDelay = 100 * time.Millisecond
+	}
+	if opts.MaxDelay <= 0 {
+		opts.MaxDelay = 10 * time.Second
+	}
+	return &retrySource{inner: inner, opts: opts}
+}
+
+// Load loads from the inner source, retrying on error.
+func (r *retrySource) Load(ctx context.Context) (map[string]any, error) {
+	loaded, err := r.loadAll(ctx)
+	return loaded.data, err
+}
+
+// LoadWithKeys loads from the inner source with retries, preserving original keys if supported.
+func (r *retrySource) LoadWithKeys(ctx context.Context) (map[string]any, map[string]string, error) {
+	loaded, err := r.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, err
+}
+
+// LoadWithPositions loads from the inner source with retries, preserving lines if supported.
+func (r *retrySource) LoadWithPositions(ctx context.Context) (map[string]any, map[string]string, map[string]int, error) {
+	loaded, err := r.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, loaded.lines, err
+}
+
+// LoadWithSecrets loads from the inner source with retries, preserving secret keys if supported.
+func (r *retrySource) LoadWithSecrets(ctx context.Context) (map[string]any, map[string]string, map[string]bool, error) {
+	loaded, err := r.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, loaded.secrets, err
+}
+
+// loadAll loads from the inner source with retries.
+func (r *retrySource) loadAll(ctx context.Context) (loadedSource, error) {
+	var lastErr error
+	for attempt := 1; attempt <= r.opts.MaxAttempts; attempt++ {
+		var loaded loadedSource
+		loaded, lastErr = loadFrom(ctx, r.inner)
+		if lastErr == nil {
+			return loaded, nil
+		}
+
+		// Fail fast on errors that declare themselves permanent
+		var tmp temporary
+		if errors.As(lastErr, &tmp) && !tmp.Temporary() {
+			return loadedSource{}, lastErr
+		}
+
+		if attempt == r.opts.MaxAttempts {
+			break
+		}
+
+		timer := time.NewTimer(r.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return loadedSource{}, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	return loadedSource{}, lastErr
+}
+
+// backoff returns the delay after the given attempt (1-based).
+func (r *retrySource) backoff(attempt int) time.Duration {
+	delay := r.opts.BaseDelay
+	for i := 1; i < attempt && delay < r.opts.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.opts.MaxDelay {
+		delay = r.opts.MaxDelay
+	}
+
+	if r.opts.Jitter > 0 {
+		delta := float64(delay) * r.opts.Jitter
+		delay += time.Duration(delta * (2*rand.Float64() - 1))
+		if delay < 0 {
+			delay = 0
+		}
+	}
+
+	return delay
+}
+
+// Watch forwards to the inner source.
+func (r *retrySource) Watch(ctx context.Context) (<-chan ChangeEvent, error) {
+	return r.inner.Watch(ctx)
+}
+
+// Name returns "retry(<inner name>)".
+func (r *retrySource) Name() string {
+	return "retry(" + r.inner.Name() + ")"
+}
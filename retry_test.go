@@ -0,0 +1,148 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// flakySource is a test helper that fails a fixed number of times before succeeding.
+type flakySource struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *flakySource) Load(ctx context.Context) (map[string]any, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, f.err
+	}
+	return map[string]any{"host": "localhost"}, nil
+}
+
+func (f *flakySource) Watch(ctx context.Context) (<-chan ChangeEvent, error) {
+	return nil, ErrWatchNotSupported
+}
+
+func (f *flakySource) Name() string {
+	return "flaky"
+}
+
+// permanentError is a test error that reports itself as non-retryable.
+type permanentError struct{}
+
+func (permanentError) Error() string   { return "permanent failure" }
+func (permanentError) Temporary() bool { return false }
+
+func TestRetrySource_RetriesUntilSuccess(t *testing.T) {
+	inner := &flakySource{failures: 2, err: errors.New("connection refused")}
+	src := RetrySource(inner, RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond})
+
+	data, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data["host"] != "localhost" {
+		t.Errorf("host = %v, want localhost", data["host"])
+	}
+	if inner.calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", inner.calls)
+	}
+}
+
+func TestRetrySource_GivesUpAfterMaxAttempts(t *testing.T) {
+	wantErr := errors.New("connection refused")
+	inner := &flakySource{failures: 10, err: wantErr}
+	src := RetrySource(inner, RetryOptions{MaxAttempts: 4, BaseDelay: time.Millisecond, Jitter: 0.5})
+
+	_, err := src.Load(context.Background())
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected %v, got %v", wantErr, err)
+	}
+	if inner.calls != 4 {
+		t.Errorf("expected 4 attempts, got %d", inner.calls)
+	}
+}
+
+func TestRetrySource_NonRetryableFailsFast(t *testing.T) {
+	inner := &flakySource{failures: 10, err: permanentError{}}
+	src := RetrySource(inner, RetryOptions{MaxAttempts: 5, BaseDelay: time.Millisecond})
+
+	_, err := src.Load(context.Background())
+	if !errors.As(err, &permanentError{}) {
+		t.Fatalf("expected permanentError, got %v", err)
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", inner.calls)
+	}
+}
+
+func TestRetrySource_WrappedNonRetryableFailsFast(t *testing.T) {
+	inner := &flakySource{failures: 10, err: fmt.Errorf("fetch config: %w", permanentError{})}
+	src := RetrySource(inner, RetryOptions{MaxAttempts: 5, BaseDelay: time.Millisecond})
+
+	_, err := src.Load(context.Background())
+	if !errors.As(err, &permanentError{}) {
+		t.Fatalf("expected wrapped permanentError, got %v", err)
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected 1 attempt, got %d", inner.calls)
+	}
+}
+
+func TestRetrySource_ContextCancellation(t *testing.T) {
+	inner := &flakySource{failures: 10, err: errors.New("timeout")}
+	src := RetrySource(inner, RetryOptions{MaxAttempts: 5, BaseDelay: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := src.Load(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("retry should stop promptly when the context is cancelled")
+	}
+}
+
+func TestRetrySource_Backoff(t *testing.T) {
+	src := RetrySource(&flakySource{}, RetryOptions{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}).(*retrySource)
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{5, time.Second}, // Capped
+	}
+
+	for _, tt := range tests {
+		if got := src.backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestRetrySource_NameAndWatch(t *testing.T) {
+	src := RetrySource(&flakySource{}, RetryOptions{})
+
+	if src.Name() != "retry(flaky)" {
+		t.Errorf("Name() = %q, want %q", src.Name(), "retry(flaky)")
+	}
+	if _, err := src.Watch(context.Background()); !errors.Is(err, ErrWatchNotSupported) {
+		t.Errorf("Watch() error = %v, want ErrWatchNotSupported", err)
+	}
+}
+
+func TestRetrySource_ForwardsPositionsAndSecrets(t *testing.T) {
+	testWrapperForwards(t, func(inner Source) Source {
+		return RetrySource(inner, RetryOptions{})
+	})
+}
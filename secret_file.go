@@ -0,0 +1,80 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// secretFileSuffix marks a key that holds the path of a file containing a secret field's value
+// (e.g. "database.password_file", from APP_DATABASE__PASSWORD_FILE with sourceenv.KeyStyleSnake).
+const secretFileSuffix = "_file"
+
+// flatSecretFileSuffix is secretFileSuffix after sourceenv's default KeyStyleFlat (or
+// KeyStyleCamel) normalization, e.g. "database.passwordfile" from APP_DATABASE__PASSWORD_FILE.
+const flatSecretFileSuffix = "file"
+
+// resolveSecretFiles replaces "<key>_file" (or "<key>file") entries of secret fields with the
+// contents of the named file, recorded with source "file:<path>". "<key>file" is not a companion
+// key if a field is bound to it. A value provided directly for the key takes precedence.
+// The companion key is removed either way, so strict mode does not report it.
+// A missing file is an error for required fields and leaves optional fields unset.
+func (l *Loader[T]) resolveSecretFiles(ctx context.Context, data map[string]mergedEntry) []FieldError {
+	var fieldErrors []FieldError
+	cfgType := reflect.TypeOf((*T)(nil)).Elem()
+	validKeys := collectValidKeys(cfgType, "", l.ptrStructs)
+	walkFieldKeys(cfgType, "", "", l.ptrStructs, func(keyPath, fieldPath string, field reflect.StructField) {
+		tags := parseTag(field.Tag.Get("conf"))
+		if !tags.secret {
+			return
+		}
+
+		fileKey := ""
+		var entry mergedEntry
+		for _, candidate := range []string{keyPath + secretFileSuffix, keyPath + flatSecretFileSuffix} {
+			candidateEntry, ok := data[candidate]
+			if !ok || validKeys[candidate] {
+				continue
+			}
+			delete(data, candidate)
+			if fileKey == "" {
+				fileKey, entry = candidate, candidateEntry
+			}
+		}
+		if fileKey == "" {
+			return
+		}
+
+		if _, direct := data[keyPath]; direct {
+			l.logDebug(ctx, "secret file ignored, value set directly", "key", keyPath)
+			return
+		}
+
+		path := strings.TrimSpace(fmt.Sprint(entry.value))
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && !tags.required {
+				l.logDebug(ctx, "secret file not found", "key", keyPath, "path", path)
+				return
+			}
+			fieldErrors = append(fieldErrors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeSecretFile,
+				Message:   fmt.Sprintf("cannot read secret file named by %s: %v", fileKey, err),
+			})
+			return
+		}
+
+		source := "file:" + path
+		data[keyPath] = mergedEntry{
+			value:      strings.TrimRight(string(content), "\r\n"),
+			sourceName: source,
+			sourceKey:  source,
+			layer:      entry.layer,
+		}
+	})
+	return fieldErrors
+}
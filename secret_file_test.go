@@ -0,0 +1,130 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad_SecretFiles(t *testing.T) {
+	type Config struct {
+		Password string `conf:"secret,required"`
+		Database struct {
+			Token Optional[string] `conf:"secret"`
+		}
+		APIKey string `conf:"secret"`
+		Host   string
+	}
+
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password")
+	if err := os.WriteFile(passwordFile, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("tok\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	t.Run("values read from files", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithSource(&mockSource{name: "env", data: map[string]any{
+				"password_file":       passwordFile,
+				"database.token_file": tokenFile,
+				"apikey_file":         missing, // Optional secret: left unset
+				"host":                "localhost",
+			}}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Password != "s3cret" {
+			t.Errorf("Password = %q, want trailing newline trimmed", cfg.Password)
+		}
+		if token, _ := cfg.Database.Token.Get(); token != "tok" {
+			t.Errorf("Database.Token = %q, want tok", token)
+		}
+		if cfg.APIKey != "" {
+			t.Errorf("APIKey = %q, want empty", cfg.APIKey)
+		}
+
+		prov, _ := GetProvenance(cfg)
+		if p := findProvenance(prov.Fields, "Password"); p == nil || p.SourceName != "file:"+passwordFile || !p.Secret {
+			t.Errorf("Password provenance = %+v, want file:%s", p, passwordFile)
+		}
+	})
+
+	t.Run("direct value takes precedence", func(t *testing.T) {
+		cfg, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"password": "direct", "password_file": missing}}).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if cfg.Password != "direct" {
+			t.Errorf("Password = %q, want direct", cfg.Password)
+		}
+	})
+
+	t.Run("missing file for required secret", func(t *testing.T) {
+		_, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"password_file": missing}}).
+			Load(context.Background())
+
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+		if len(valErr.FieldErrors) != 1 {
+			t.Fatalf("expected 1 field error, got %+v", valErr.FieldErrors)
+		}
+		fe := valErr.FieldErrors[0]
+		if fe.FieldPath != "Password" || fe.Code != ErrCodeSecretFile || !strings.Contains(fe.Message, missing) {
+			t.Errorf("unexpected field error: %+v", fe)
+		}
+	})
+
+	t.Run("only secret fields", func(t *testing.T) {
+		_, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"password": "x", "host_file": passwordFile}}).
+			Load(context.Background())
+
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) || valErr.FieldErrors[0].Code != ErrCodeUnknownKey {
+			t.Fatalf("expected unknown key error for host_file, got %v", err)
+		}
+	})
+}
+
+func TestLoad_SecretFilesFlatKeys(t *testing.T) {
+	type Config struct {
+		Password string `conf:"secret"`
+		Cert     string `conf:"secret"`
+		CertFile string // A field of its own, not a companion key
+	}
+
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(passwordFile, []byte("s3cret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{
+			"passwordfile": passwordFile, // APP_PASSWORD_FILE with sourceenv's default key style
+			"certfile":     "/etc/tls/cert.pem",
+		}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if cfg.Password != "s3cret" {
+		t.Errorf("Password = %q, want s3cret", cfg.Password)
+	}
+	if cfg.Cert != "" || cfg.CertFile != "/etc/tls/cert.pem" {
+		t.Errorf("Cert = %q, CertFile = %q, want CertFile bound as a plain field", cfg.Cert, cfg.CertFile)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 )
@@ -49,6 +50,95 @@ type ConfigSnapshot struct {
 
 	// Provenance tracks the source of each configuration field.
 	Provenance []FieldProvenance `json:"provenance"`
+
+	// Metadata holds free-form annotations such as release tag, operator, or ticket (see WithMetadata).
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// Extra holds top-level fields this version doesn't know about (e.g., written by a newer
+	// version). They are preserved when the snapshot is written again.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// MarshalJSON encodes the snapshot, including any preserved Extra fields.
+// Known fields take precedence over Extra entries with the same name.
+func (s ConfigSnapshot) MarshalJSON() ([]byte, error) {
+	type plain ConfigSnapshot
+	data, err := json.Marshal(plain(s))
+	if err != nil || len(s.Extra) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range s.Extra {
+		if _, known := fields[key]; !known {
+			fields[key] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes the snapshot, keeping unknown top-level fields in Extra.
+func (s *ConfigSnapshot) UnmarshalJSON(data []byte) error {
+	type plain ConfigSnapshot
+	var snapshot plain
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for _, name := range snapshotJSONFields() {
+		delete(fields, name)
+	}
+	if len(fields) > 0 {
+		snapshot.Extra = fields
+	}
+
+	*s = ConfigSnapshot(snapshot)
+	return nil
+}
+
+// Get returns the value of the flattened key path (e.g. "database.host"). Matching is
+// case-insensitive, like WithExcludeFields.
+func (s *ConfigSnapshot) Get(keyPath string) (any, bool) {
+	if value, ok := s.Config[keyPath]; ok {
+		return value, true
+	}
+	for key, value := range s.Config {
+		if strings.EqualFold(key, keyPath) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// Keys returns the flattened key paths in sorted order.
+func (s *ConfigSnapshot) Keys() []string {
+	keys := make([]string, 0, len(s.Config))
+	for key := range s.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// snapshotJSONFields returns the JSON names of the known ConfigSnapshot fields.
+func snapshotJSONFields() []string {
+	t := reflect.TypeOf(ConfigSnapshot{})
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
 // SnapshotOption configures snapshot creation behavior.
@@ -56,7 +146,11 @@ type SnapshotOption func(*snapshotConfig)
 
 // snapshotConfig holds internal configuration for snapshot creation.
 type snapshotConfig struct {
-	excludeFields []string // Field paths to exclude
+	includeFields   []string // Field paths to keep (empty keeps all)
+	excludeFields   []string // Field paths to exclude
+	excludePrefixes []string // Key path prefixes whose subtrees are excluded
+	metadata        map[string]string
+	clock           func() time.Time // Source of the timestamp (nil = time.Now)
 }
 
 // WithExcludeFields excludes specified field paths from the snapshot.
@@ -67,10 +161,51 @@ func WithExcludeFields(paths ...string) SnapshotOption {
 	}
 }
 
+// WithIncludeFields keeps only the specified field paths in the snapshot (case-insensitive).
+// Includes are applied before exclusions, so a path that is both included and excluded is dropped.
+// Calling it without paths keeps all fields.
+func WithIncludeFields(paths ...string) SnapshotOption {
+	return func(cfg *snapshotConfig) {
+		cfg.includeFields = append(cfg.includeFields, paths...)
+	}
+}
+
+// WithMetadata attaches annotations to the snapshot (e.g., "release": "v1.4.2", "ticket": "OPS-123").
+// Values support the same template variables as WriteSnapshot paths ({{timestamp}}, {{hostname}}),
+// expanded with the snapshot's timestamp. Multiple calls are merged; later keys win.
+func WithMetadata(kv map[string]string) SnapshotOption {
+	return func(cfg *snapshotConfig) {
+		if cfg.metadata == nil {
+			cfg.metadata = make(map[string]string, len(kv))
+		}
+		for key, value := range kv {
+			cfg.metadata[key] = value
+		}
+	}
+}
+
+// WithExcludePrefixes excludes whole subtrees from the snapshot.
+// A key is dropped if it equals a prefix or starts with prefix + "." (e.g., "database"
+// drops "database.host" and "database.pool.size" but not "databases"). Matching is case-insensitive.
+func WithExcludePrefixes(prefixes ...string) SnapshotOption {
+	return func(cfg *snapshotConfig) {
+		cfg.excludePrefixes = append(cfg.excludePrefixes, prefixes...)
+	}
+}
+
+// WithClock sets the function that provides the snapshot's Timestamp (converted to UTC), e.g. a
+// fixed time for reproducible snapshots in tests. Metadata templates expand with the same time,
+// and so do WriteSnapshot paths. Default: time.Now.
+func WithClock(now func() time.Time) SnapshotOption {
+	return func(cfg *snapshotConfig) {
+		cfg.clock = now
+	}
+}
+
 // CreateSnapshot captures the current configuration state.
 // Returns a snapshot with flattened config, provenance, and metadata.
 // Secrets are automatically redacted using existing provenance data.
-// The snapshot's Timestamp is captured at creation time.
+// The snapshot's Timestamp is captured at creation time (see WithClock).
 func CreateSnapshot[T any](cfg *T, opts ...SnapshotOption) (*ConfigSnapshot, error) {
 	if cfg == nil {
 		return nil, ErrNilConfig
@@ -83,7 +218,11 @@ func CreateSnapshot[T any](cfg *T, opts ...SnapshotOption) (*ConfigSnapshot, err
 	}
 
 	// Capture timestamp at creation time
-	timestamp := time.Now().UTC()
+	now := time.Now
+	if snapCfg.clock != nil {
+		now = snapCfg.clock
+	}
+	timestamp := now().UTC()
 
 	// Get provenance data
 	var provFields []FieldProvenance
@@ -95,13 +234,25 @@ func CreateSnapshot[T any](cfg *T, opts ...SnapshotOption) (*ConfigSnapshot, err
 	flatConfig := flattenConfig(cfg)
 
 	// Apply field exclusions
+	flatConfig = applyInclusions(flatConfig, snapCfg.includeFields)
 	flatConfig = applyExclusions(flatConfig, snapCfg.excludeFields)
+	flatConfig = applyPrefixExclusions(flatConfig, snapCfg.excludePrefixes)
+
+	// Expand metadata templates
+	var metadata map[string]string
+	if len(snapCfg.metadata) > 0 {
+		metadata = make(map[string]string, len(snapCfg.metadata))
+		for key, value := range snapCfg.metadata {
+			metadata[key] = ExpandPathWithTime(value, timestamp)
+		}
+	}
 
 	return &ConfigSnapshot{
 		Version:    SnapshotVersion,
 		Timestamp:  timestamp,
 		Config:     flatConfig,
 		Provenance: provFields,
+		Metadata:   metadata,
 	}, nil
 }
 
@@ -147,6 +298,16 @@ func flattenStructFields(v reflect.Value, fieldPathPrefix string, keyPathPrefix
 		field := t.Field(i)
 		fieldValue := v.Field(i)
 
+		// Parse tag to get custom name or prefix
+		tag := field.Tag.Get("conf")
+		tagCfg := parseTag(tag)
+
+		// Embedded structs are flattened into the parent scope
+		if isPromotedStruct(field, tagCfg) {
+			flattenStructFields(fieldValue, fieldPathPrefix, keyPathPrefix, provenanceMap, result)
+			continue
+		}
+
 		// Skip unexported fields
 		if !field.IsExported() {
 			continue
@@ -158,10 +319,6 @@ func flattenStructFields(v reflect.Value, fieldPathPrefix string, keyPathPrefix
 			fieldPath = fieldPathPrefix + "." + field.Name
 		}
 
-		// Parse tag to get custom name or prefix
-		tag := field.Tag.Get("conf")
-		tagCfg := parseTag(tag)
-
 		// Get provenance info
 		var prov *FieldProvenance
 		if p, ok := provenanceMap[fieldPath]; ok {
@@ -182,6 +339,11 @@ func flattenStructFields(v reflect.Value, fieldPathPrefix string, keyPathPrefix
 			}
 		}
 
+		// Allocated *Struct fields are handled like value structs
+		if isStructPointer(field.Type) && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+
 		// Handle nested structs recursively
 		if fieldValue.Kind() == reflect.Struct && field.Type.String() != "time.Time" {
 			// Check if this is an Optional type
@@ -233,6 +395,58 @@ func applyExclusions(config map[string]any, exclude []string) map[string]any {
 	return result
 }
 
+// applyInclusions keeps only the included field paths in the config map.
+// Matching is case-insensitive. An empty include list keeps all fields.
+func applyInclusions(config map[string]any, include []string) map[string]any {
+	if len(include) == 0 {
+		return config
+	}
+
+	includeSet := make(map[string]bool)
+	for _, path := range include {
+		includeSet[strings.ToLower(path)] = true
+	}
+
+	result := make(map[string]any)
+	for key, value := range config {
+		if includeSet[strings.ToLower(key)] {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// applyPrefixExclusions filters out keys equal to or below any of the prefixes.
+// Matching is case-insensitive. Like applyExclusions, the input map is not modified.
+func applyPrefixExclusions(config map[string]any, prefixes []string) map[string]any {
+	if len(prefixes) == 0 {
+		return config
+	}
+
+	lowered := make([]string, len(prefixes))
+	for i, prefix := range prefixes {
+		lowered[i] = strings.ToLower(prefix)
+	}
+
+	result := make(map[string]any)
+	for key, value := range config {
+		if !hasKeyPrefix(strings.ToLower(key), lowered) {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// hasKeyPrefix reports whether key equals one of the prefixes or lies below it.
+func hasKeyPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if key == prefix || strings.HasPrefix(key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
 // ExpandPath expands template variables using current time.
 // For consistency with snapshot metadata, prefer WriteSnapshot which
 // uses the snapshot's internal timestamp for expansion.
@@ -240,17 +454,62 @@ func ExpandPath(template string) string {
 	return ExpandPathWithTime(template, time.Now())
 }
 
-// ExpandPathWithTime expands template variables using the provided timestamp.
-// Replaces all {{timestamp}} occurrences with the time formatted as 20060102-150405.
+// ExpandPathWithTime expands template variables using the provided timestamp:
+//   - {{timestamp}}: the time formatted as 20060102-150405 (UTC)
+//   - {{hostname}}: os.Hostname ("unknown" if it cannot be determined)
+//   - {{env:NAME}}: the value of environment variable NAME (empty if unset)
+//
+// Unknown {{...}} tokens are left untouched.
 // Returns the path unchanged if no template variables are present.
 func ExpandPathWithTime(template string, t time.Time) string {
 	timestamp := t.UTC().Format("20060102-150405")
-	return strings.ReplaceAll(template, "{{timestamp}}", timestamp)
+	expanded := strings.ReplaceAll(template, "{{timestamp}}", timestamp)
+	if strings.Contains(expanded, "{{hostname}}") {
+		expanded = strings.ReplaceAll(expanded, "{{hostname}}", hostname())
+	}
+	return expandEnvTokens(expanded)
+}
+
+// expandEnvTokens replaces {{env:NAME}} tokens with the value of environment variable NAME.
+// Substituted values are not expanded again.
+func expandEnvTokens(s string) string {
+	const tokenStart, tokenEnd = "{{env:", "}}"
+
+	var b strings.Builder
+	for {
+		start := strings.Index(s, tokenStart)
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start+len(tokenStart):], tokenEnd)
+		if end < 0 {
+			break
+		}
+		name := s[start+len(tokenStart) : start+len(tokenStart)+end]
+
+		b.WriteString(s[:start])
+		b.WriteString(os.Getenv(name))
+		s = s[start+len(tokenStart)+end+len(tokenEnd):]
+	}
+	if b.Len() == 0 {
+		return s
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// hostname returns the host name for {{hostname}} expansion.
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		return "unknown"
+	}
+	return name
 }
 
 // WriteSnapshot persists a snapshot to disk with atomic write semantics.
-// Supports {{timestamp}} template variable in path - uses snapshot.Timestamp
-// (not current time) to ensure filename matches internal metadata.
+// Supports {{timestamp}} and {{hostname}} template variables in path - {{timestamp}} uses
+// snapshot.Timestamp (not current time) to ensure filename matches internal metadata.
 // Returns ErrSnapshotTooLarge if serialized size exceeds 100MB.
 func WriteSnapshot(snapshot *ConfigSnapshot, pathTemplate string) error {
 	if snapshot == nil {
@@ -316,6 +575,7 @@ func WriteSnapshot(snapshot *ConfigSnapshot, pathTemplate string) error {
 }
 
 // ReadSnapshot loads a snapshot from disk.
+// Unknown top-level fields are kept in Extra so they survive a read-modify-write cycle.
 // Returns ErrUnsupportedVersion if snapshot version is not supported.
 // Returns appropriate errors for missing file or invalid JSON.
 func ReadSnapshot(path string) (*ConfigSnapshot, error) {
@@ -347,6 +607,10 @@ func ReadSnapshot(path string) (*ConfigSnapshot, error) {
 // formatFlatValue formats a field value for the flattened config map.
 // Secrets are redacted, other values are returned in their natural types.
 func formatFlatValue(v reflect.Value, prov *FieldProvenance) any {
+	if prov != nil && prov.mask != nil {
+		return prov.mask.maskValue(v)
+	}
+
 	// Check if this field is secret
 	if prov != nil && prov.Secret {
 		return "***redacted***"
@@ -355,6 +619,9 @@ func formatFlatValue(v reflect.Value, prov *FieldProvenance) any {
 	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
 		return nil
 	}
+	if elems, ok := optionalElems(v); ok {
+		return elems
+	}
 
 	// Handle different types
 	switch v.Kind() {
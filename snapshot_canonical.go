@@ -0,0 +1,132 @@
+package rigging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// CanonicalizeSnapshot encodes a snapshot as deterministic JSON bytes, suitable for computing
+// signatures or hashes. Object keys are sorted, numbers use a fixed formatting (so 8080 read back
+// as float64 encodes the same as int 8080), the timestamp is normalized to UTC, and no
+// insignificant whitespace or HTML escaping is emitted. Preserved Extra fields are included.
+func CanonicalizeSnapshot(s *ConfigSnapshot) ([]byte, error) {
+	if s == nil {
+		return nil, errors.New("rigging: snapshot is nil")
+	}
+
+	normalized := *s
+	normalized.Timestamp = normalized.Timestamp.UTC()
+
+	return canonicalJSON(normalized)
+}
+
+// canonicalJSON encodes v as JSON and re-encodes the result in canonical form.
+func canonicalJSON(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode generically so that every value is re-encoded the same way
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonicalJSON(&buf, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCanonicalJSON writes a generically decoded JSON value in canonical form.
+func writeCanonicalJSON(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalString(buf, key); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+			if err := writeCanonicalJSON(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonicalJSON(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case json.Number:
+		number, err := canonicalNumber(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(number)
+	case string:
+		return writeCanonicalString(buf, v)
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case nil:
+		buf.WriteString("null")
+	default:
+		return errors.New("rigging: unexpected value in snapshot JSON")
+	}
+	return nil
+}
+
+// writeCanonicalString writes a JSON string without HTML escaping.
+func writeCanonicalString(buf *bytes.Buffer, s string) error {
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return err
+	}
+	buf.Write(bytes.TrimSuffix(out.Bytes(), []byte("\n")))
+	return nil
+}
+
+// canonicalNumber formats a JSON number: integers in plain decimal form,
+// other values in the shortest representation that round-trips.
+func canonicalNumber(n json.Number) (string, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return strconv.FormatInt(i, 10), nil
+	}
+	if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		return strconv.FormatUint(u, 10), nil
+	}
+
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil {
+		return "", err
+	}
+	if math.Abs(f) < 1<<53 && f == math.Trunc(f) {
+		return strconv.FormatInt(int64(f), 10), nil
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64), nil
+}
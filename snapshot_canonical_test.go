@@ -0,0 +1,70 @@
+package rigging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCanonicalizeSnapshot_Deterministic(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	provenance := []FieldProvenance{{FieldPath: "Host", KeyPath: "host", SourceName: "env:APP_HOST"}}
+
+	// Build the maps in different insertion orders
+	config1 := map[string]any{}
+	for _, key := range []string{"a", "b", "c", "database.host", "database.port"} {
+		config1[key] = "v-" + key
+	}
+	config2 := map[string]any{}
+	for _, key := range []string{"database.port", "c", "database.host", "b", "a"} {
+		config2[key] = "v-" + key
+	}
+	config1["port"] = 8080
+	config2["port"] = float64(8080) // As decoded from JSON
+	config1["nested"] = map[string]any{"z": 1, "a": []any{true, nil}}
+	config2["nested"] = map[string]any{"a": []any{true, nil}, "z": int64(1)}
+
+	s1 := &ConfigSnapshot{Version: SnapshotVersion, Timestamp: ts, Config: config1, Provenance: provenance}
+	s2 := &ConfigSnapshot{Version: SnapshotVersion, Timestamp: ts.In(time.FixedZone("CET", 3600)), Config: config2, Provenance: provenance}
+
+	b1, err := CanonicalizeSnapshot(s1)
+	if err != nil {
+		t.Fatalf("CanonicalizeSnapshot() error = %v", err)
+	}
+	b2, err := CanonicalizeSnapshot(s2)
+	if err != nil {
+		t.Fatalf("CanonicalizeSnapshot() error = %v", err)
+	}
+
+	if !bytes.Equal(b1, b2) {
+		t.Errorf("canonical forms differ:\n%s\n%s", b1, b2)
+	}
+	if !json.Valid(b1) {
+		t.Errorf("canonical form is not valid JSON: %s", b1)
+	}
+}
+
+func TestCanonicalizeSnapshot_Format(t *testing.T) {
+	s := &ConfigSnapshot{
+		Version:   SnapshotVersion,
+		Timestamp: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Config:    map[string]any{"url": "http://a/?x=1&y=<2>", "ratio": 0.25, "port": 8080},
+	}
+
+	got, err := CanonicalizeSnapshot(s)
+	if err != nil {
+		t.Fatalf("CanonicalizeSnapshot() error = %v", err)
+	}
+
+	want := `{"config":{"port":8080,"ratio":0.25,"url":"http://a/?x=1&y=<2>"},"provenance":null,"timestamp":"2025-06-01T12:00:00Z","version":"1.0"}`
+	if string(got) != want {
+		t.Errorf("CanonicalizeSnapshot() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCanonicalizeSnapshot_Nil(t *testing.T) {
+	if _, err := CanonicalizeSnapshot(nil); err == nil {
+		t.Error("expected error for nil snapshot")
+	}
+}
@@ -0,0 +1,247 @@
+package rigging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrCriticalConfigChange is returned by Load when the diff gate detects an unapproved critical change.
+var ErrCriticalConfigChange = errors.New("rigging: critical configuration change without approval")
+
+// ChangeKind describes how a key differs between two snapshots.
+type ChangeKind string
+
+// Change kinds reported by DiffSnapshots.
+const (
+	ChangeAdded    ChangeKind = "added"    // Key only exists in the new snapshot
+	ChangeRemoved  ChangeKind = "removed"  // Key only exists in the old snapshot
+	ChangeModified ChangeKind = "modified" // Key exists in both with different values
+)
+
+// KeyChange describes a single key that differs between two snapshots.
+type KeyChange struct {
+	Key      string     `json:"key"`
+	Kind     ChangeKind `json:"kind"`
+	OldValue any        `json:"old_value,omitempty"`
+	NewValue any        `json:"new_value,omitempty"`
+	Severity Severity   `json:"severity,omitempty"` // Set by CompareSnapshots
+}
+
+// SnapshotDiff lists the differences between two snapshots, sorted by key.
+type SnapshotDiff struct {
+	Changes []KeyChange `json:"changes"`
+}
+
+// HasChanges reports whether the diff contains any change.
+func (d *SnapshotDiff) HasChanges() bool {
+	return d != nil && len(d.Changes) > 0
+}
+
+// DiffSnapshots compares the flattened config of two snapshots.
+// Values are taken from the snapshots as-is, so secrets stay redacted.
+// A nil snapshot is treated as empty.
+func DiffSnapshots(before, after *ConfigSnapshot) *SnapshotDiff {
+	var oldConfig, newConfig map[string]any
+	if before != nil {
+		oldConfig = before.Config
+	}
+	if after != nil {
+		newConfig = after.Config
+	}
+
+	return &SnapshotDiff{Changes: diffFlatConfigs(oldConfig, newConfig)}
+}
+
+// ConfigDiff lists the differences between two loaded configs, sorted by key.
+type ConfigDiff struct {
+	Changes []KeyChange `json:"changes"`
+}
+
+// HasChanges reports whether the diff contains any change.
+func (d *ConfigDiff) HasChanges() bool {
+	return d != nil && len(d.Changes) > 0
+}
+
+// DiffConfigs compares two configs by their flattened key paths.
+// Secret fields (tagged `secret` or recorded as secret in provenance) are reported with
+// redacted values, so a rotated secret shows up as modified without revealing either value.
+// A nil config is treated as empty.
+func DiffConfigs[T any](old, new *T) (*ConfigDiff, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("rigging: DiffConfigs requires a struct type, got %s", t)
+	}
+
+	secretKeys := make(map[string]bool)
+	walkKeys(t, "", true, func(keyPath string, field reflect.StructField) {
+		if parseTag(field.Tag.Get("conf")).secret {
+			secretKeys[keyPath] = true
+		}
+	})
+
+	// Compare raw values, then redact secrets
+	flatten := func(cfg *T) map[string]any {
+		if cfg == nil {
+			return nil
+		}
+		if prov, ok := GetProvenance(cfg); ok {
+			for _, field := range prov.Fields {
+				if field.Secret {
+					secretKeys[field.KeyPath] = true
+				}
+			}
+		}
+		return flattenValues(reflect.ValueOf(cfg))
+	}
+	oldConfig, newConfig := flatten(old), flatten(new)
+
+	changes := diffFlatConfigs(oldConfig, newConfig)
+	for i := range changes {
+		if secretKeys[changes[i].Key] {
+			changes[i].OldValue = redactChangeValue(changes[i].OldValue)
+			changes[i].NewValue = redactChangeValue(changes[i].NewValue)
+		}
+	}
+
+	return &ConfigDiff{Changes: changes}, nil
+}
+
+// changedKeys returns the sorted key paths whose values differ between two configs.
+func changedKeys[T any](old, new *T) []string {
+	changes := diffFlatConfigs(flattenValues(reflect.ValueOf(old)), flattenValues(reflect.ValueOf(new)))
+	if len(changes) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(changes))
+	for i, change := range changes {
+		keys[i] = change.Key
+	}
+	return keys
+}
+
+// redactChangeValue redacts a secret value in a KeyChange, keeping absent values (nil) as nil.
+func redactChangeValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	return "***redacted***"
+}
+
+// diffFlatConfigs compares two flattened config maps and returns changes sorted by key.
+func diffFlatConfigs(oldConfig, newConfig map[string]any) []KeyChange {
+	var changes []KeyChange
+
+	for key, oldValue := range oldConfig {
+		newValue, ok := newConfig[key]
+		if !ok {
+			changes = append(changes, KeyChange{Key: key, Kind: ChangeRemoved, OldValue: oldValue})
+			continue
+		}
+		if !flatValuesEqual(oldValue, newValue) {
+			changes = append(changes, KeyChange{Key: key, Kind: ChangeModified, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	for key, newValue := range newConfig {
+		if _, ok := oldConfig[key]; !ok {
+			changes = append(changes, KeyChange{Key: key, Kind: ChangeAdded, NewValue: newValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+// flatValuesEqual compares flattened values.
+// Snapshots read from disk hold JSON-decoded values (float64, []any), so values
+// are compared by their formatted representation when types differ.
+func flatValuesEqual(a, b any) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+// DiffGate refuses to start when critical keys changed relative to a baseline snapshot.
+// It is checked until a Load passes it (see Loader.WithStartupSnapshotDiffGate).
+type DiffGate struct {
+	// BaselinePath is the snapshot to compare against. A missing file disables the gate
+	// (e.g. on the first deploy).
+	BaselinePath string
+
+	// CriticalKeys lists key paths whose changes require approval.
+	// A key also matches every key below it (e.g. "database" matches "database.host").
+	// Secret values are redacted in snapshots, so a changed secret is not detected;
+	// only a secret key being added or removed is.
+	CriticalKeys []string
+
+	// ApprovalEnv names an environment variable that approves critical changes when set to a true value.
+	ApprovalEnv string
+
+	// ApprovalKey names a config key that approves critical changes when its loaded value is true.
+	ApprovalKey string
+}
+
+// check compares the snapshot against the baseline and returns ErrCriticalConfigChange
+// if any critical key changed without approval.
+func (g *DiffGate) check(current *ConfigSnapshot) error {
+	baseline, err := ReadSnapshot(g.BaselinePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read baseline snapshot: %w", err)
+	}
+
+	var critical []string
+	for _, change := range DiffSnapshots(baseline, current).Changes {
+		if g.isCritical(change.Key) {
+			critical = append(critical, change.Key)
+		}
+	}
+
+	if len(critical) == 0 || g.approved(current) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrCriticalConfigChange, strings.Join(critical, ", "))
+}
+
+// isCritical reports whether key matches one of the critical keys.
+func (g *DiffGate) isCritical(key string) bool {
+	key = strings.ToLower(key)
+	for _, critical := range g.CriticalKeys {
+		critical = strings.ToLower(critical)
+		if key == critical || strings.HasPrefix(key, critical+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// approved reports whether an approval marker is present in the environment or the config.
+func (g *DiffGate) approved(current *ConfigSnapshot) bool {
+	if g.ApprovalEnv != "" {
+		if ok, err := parseBool(os.Getenv(g.ApprovalEnv)); err == nil && ok {
+			return true
+		}
+	}
+
+	if g.ApprovalKey != "" {
+		if value, found := current.Config[strings.ToLower(g.ApprovalKey)]; found {
+			if ok, err := parseBool(fmt.Sprint(value)); err == nil && ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
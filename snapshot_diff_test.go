@@ -0,0 +1,397 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	before := &ConfigSnapshot{Config: map[string]any{
+		"host":     "localhost",
+		"port":     float64(8080), // As decoded from JSON
+		"debug":    true,
+		"password": "***redacted***",
+	}}
+	after := &ConfigSnapshot{Config: map[string]any{
+		"host":     "db.internal",
+		"port":     int64(8080),
+		"timeout":  "30s",
+		"password": "***redacted***",
+	}}
+
+	diff := DiffSnapshots(before, after)
+
+	want := []KeyChange{
+		{Key: "debug", Kind: ChangeRemoved, OldValue: true},
+		{Key: "host", Kind: ChangeModified, OldValue: "localhost", NewValue: "db.internal"},
+		{Key: "timeout", Kind: ChangeAdded, NewValue: "30s"},
+	}
+
+	if len(diff.Changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d: %+v", len(want), len(diff.Changes), diff.Changes)
+	}
+	for i, change := range diff.Changes {
+		if change != want[i] {
+			t.Errorf("change[%d] = %+v, want %+v", i, change, want[i])
+		}
+	}
+
+	if DiffSnapshots(after, after).HasChanges() {
+		t.Error("identical snapshots should have no changes")
+	}
+}
+
+func TestLoad_StartupSnapshotDiffGate(t *testing.T) {
+	type Config struct {
+		Database struct {
+			Host string
+		}
+		LogLevel string
+		Approved bool
+	}
+
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	baselineCfg, err := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{"database.host": "db-1", "loglevel": "info"}}).
+		Load(context.Background())
+	if err != nil {
+		t.Fatalf("load baseline: %v", err)
+	}
+	baseline, _ := CreateSnapshot(baselineCfg)
+	if err := WriteSnapshot(baseline, baselinePath); err != nil {
+		t.Fatalf("write baseline: %v", err)
+	}
+
+	gate := DiffGate{
+		BaselinePath: baselinePath,
+		CriticalKeys: []string{"database"},
+		ApprovalKey:  "approved",
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]any
+		wantErr bool
+	}{
+		{
+			name:    "non-critical change passes",
+			data:    map[string]any{"database.host": "db-1", "loglevel": "debug"},
+			wantErr: false,
+		},
+		{
+			name:    "critical change without approval blocks startup",
+			data:    map[string]any{"database.host": "db-2", "loglevel": "info"},
+			wantErr: true,
+		},
+		{
+			name:    "critical change with approval passes",
+			data:    map[string]any{"database.host": "db-2", "loglevel": "info", "approved": "true"},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewLoader[Config]().
+				WithSource(&mockSource{data: tt.data}).
+				WithStartupSnapshotDiffGate(gate).
+				Load(context.Background())
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrCriticalConfigChange) {
+				t.Fatalf("expected ErrCriticalConfigChange, got %v", err)
+			}
+			if cfg != nil {
+				t.Error("config should be nil when the gate blocks startup")
+			}
+		})
+	}
+
+	t.Run("missing baseline disables gate", func(t *testing.T) {
+		missing := gate
+		missing.BaselinePath = filepath.Join(t.TempDir(), "missing.json")
+
+		_, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"database.host": "db-3"}}).
+			WithStartupSnapshotDiffGate(missing).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("approval via environment variable", func(t *testing.T) {
+		t.Setenv("RIGGING_TEST_APPROVE", "yes")
+		envGate := gate
+		envGate.ApprovalKey = ""
+		envGate.ApprovalEnv = "RIGGING_TEST_APPROVE"
+
+		_, err := NewLoader[Config]().
+			WithSource(&mockSource{data: map[string]any{"database.host": "db-2"}}).
+			WithStartupSnapshotDiffGate(envGate).
+			Load(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestDiffConfigs(t *testing.T) {
+	type Config struct {
+		Host     string
+		Port     int
+		Password string `conf:"secret"`
+		Token    string `conf:"secret"`
+		Timeout  Optional[string]
+	}
+
+	old := &Config{Host: "localhost", Port: 8080, Password: "hunter2", Token: "t1"}
+	new := &Config{Host: "db.internal", Port: 8080, Password: "hunter3", Token: "t1", Timeout: Optional[string]{Value: "5s", Set: true}}
+
+	diff, err := DiffConfigs(old, new)
+	if err != nil {
+		t.Fatalf("DiffConfigs() error = %v", err)
+	}
+
+	want := []KeyChange{
+		{Key: "host", Kind: ChangeModified, OldValue: "localhost", NewValue: "db.internal"},
+		{Key: "password", Kind: ChangeModified, OldValue: "***redacted***", NewValue: "***redacted***"},
+		{Key: "timeout", Kind: ChangeAdded, NewValue: "5s"},
+	}
+	if len(diff.Changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), diff.Changes)
+	}
+	for i, change := range diff.Changes {
+		if change != want[i] {
+			t.Errorf("change[%d] = %+v, want %+v", i, change, want[i])
+		}
+	}
+
+	if d, _ := DiffConfigs(old, old); d.HasChanges() {
+		t.Errorf("identical configs should have no changes, got %+v", d.Changes)
+	}
+
+	if _, err := DiffConfigs[string](nil, nil); err == nil {
+		t.Error("expected error for non-struct type")
+	}
+}
+
+func TestLoad_StartupSnapshotDiffGateOnlyGuardsStartup(t *testing.T) {
+	type Config struct {
+		Database struct {
+			Host string
+		}
+		Approved bool
+	}
+
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	baseline := &ConfigSnapshot{Version: SnapshotVersion, Config: map[string]any{"database.host": "db-1"}}
+	if err := WriteSnapshot(baseline, baselinePath); err != nil {
+		t.Fatalf("write baseline: %v", err)
+	}
+
+	source := &mutableSource{data: map[string]any{"database.host": "db-2"}}
+	loader := NewLoader[Config]().
+		WithSource(source).
+		WithStartupSnapshotDiffGate(DiffGate{
+			BaselinePath: baselinePath,
+			CriticalKeys: []string{"database"},
+			ApprovalKey:  "approved",
+		})
+
+	// A blocked startup is checked again on the next attempt
+	if _, err := loader.Load(context.Background()); !errors.Is(err, ErrCriticalConfigChange) {
+		t.Fatalf("expected ErrCriticalConfigChange, got %v", err)
+	}
+	source.set(map[string]any{"database.host": "db-2", "approved": true})
+	if _, err := loader.Load(context.Background()); err != nil {
+		t.Fatalf("approved startup: unexpected error: %v", err)
+	}
+
+	// Once started, reloads are not gated and the baseline is not read again
+	if err := os.Remove(baselinePath); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(baselinePath, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	source.set(map[string]any{"database.host": "db-3"})
+	cfg, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("reload: unexpected error: %v", err)
+	}
+	if cfg.Database.Host != "db-3" {
+		t.Errorf("Host = %q, want db-3", cfg.Database.Host)
+	}
+}
+
+func TestWatch_WithReloadDiff(t *testing.T) {
+	type Config struct {
+		Host     string
+		Password string `conf:"secret"`
+	}
+
+	source := newWatchableSource("watchable", map[string]any{"host": "a", "password": "p1"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	snapshots, errs, err := NewLoader[Config]().
+		WithSource(source).
+		WithReloadDiff(true).
+		Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	initial := <-snapshots
+	if initial.Diff != nil {
+		t.Errorf("initial snapshot should have no diff, got %+v", initial.Diff)
+	}
+
+	source.updateData(map[string]any{"host": "b", "password": "p2"})
+	source.triggerChange("update")
+
+	select {
+	case snapshot := <-snapshots:
+		if snapshot.Diff == nil || len(snapshot.Diff.Changes) != 2 {
+			t.Fatalf("expected 2 changes, got %+v", snapshot.Diff)
+		}
+		if c := snapshot.Diff.Changes[0]; c.Key != "host" || c.OldValue != "a" || c.NewValue != "b" {
+			t.Errorf("unexpected host change: %+v", c)
+		}
+		if c := snapshot.Diff.Changes[1]; c.Key != "password" || c.NewValue != "***redacted***" {
+			t.Errorf("unexpected password change: %+v", c)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected reload error: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for reload snapshot")
+	}
+}
+
+func TestLoader_Reload(t *testing.T) {
+	type Config struct {
+		Host     string `conf:"required"`
+		Port     int
+		Password string `conf:"secret"`
+	}
+
+	source := &mockSource{data: map[string]any{"host": "a", "port": 1, "password": "p1"}}
+	loader := NewLoader[Config]().WithSource(source)
+
+	previous, err := loader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	source.data = map[string]any{"host": "b", "port": 1, "password": "p2"}
+	cfg, diff, err := loader.Reload(context.Background(), previous)
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if cfg.Host != "b" || cfg == previous {
+		t.Errorf("expected a new config with host b, got %+v", cfg)
+	}
+
+	want := []KeyChange{
+		{Key: "host", Kind: ChangeModified, OldValue: "a", NewValue: "b"},
+		{Key: "password", Kind: ChangeModified, OldValue: "***redacted***", NewValue: "***redacted***"},
+	}
+	if len(diff.Changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), diff.Changes)
+	}
+	for i, change := range diff.Changes {
+		if change != want[i] {
+			t.Errorf("change[%d] = %+v, want %+v", i, change, want[i])
+		}
+	}
+
+	t.Run("validation failure keeps previous", func(t *testing.T) {
+		source.data = map[string]any{"port": 3}
+		cfg, diff, err := loader.Reload(context.Background(), previous)
+
+		var valErr *ValidationError
+		if !errors.As(err, &valErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+		if cfg != nil || diff != nil {
+			t.Errorf("expected nil config and diff on error, got %+v, %+v", cfg, diff)
+		}
+		if previous.Host != "a" || previous.Password != "p1" {
+			t.Errorf("previous config was modified: %+v", previous)
+		}
+	})
+
+	t.Run("nil previous reports additions", func(t *testing.T) {
+		source.data = map[string]any{"host": "d", "port": 2}
+		_, diff, err := loader.Reload(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("Reload() error = %v", err)
+		}
+		for _, change := range diff.Changes {
+			if change.Kind != ChangeAdded {
+				t.Errorf("expected only additions, got %+v", change)
+			}
+		}
+		if !diff.HasChanges() {
+			t.Error("expected changes against a nil previous config")
+		}
+	})
+}
+
+func TestWatch_ChangedKeys(t *testing.T) {
+	type Config struct {
+		Host     string
+		Port     int
+		Password string `conf:"secret"`
+	}
+
+	source := newWatchableSource("watchable", map[string]any{"host": "a", "port": 1, "password": "p1"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	snapshots, errs, err := NewLoader[Config]().
+		WithSource(source).
+		Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	initial := <-snapshots
+	if initial.ChangedKeys != nil {
+		t.Errorf("initial snapshot should have no changed keys, got %v", initial.ChangedKeys)
+	}
+
+	source.updateData(map[string]any{"host": "a", "port": 2, "password": "p2"})
+	source.triggerChange("update")
+
+	select {
+	case snapshot := <-snapshots:
+		want := []string{"password", "port"}
+		if len(snapshot.ChangedKeys) != len(want) {
+			t.Fatalf("ChangedKeys = %v, want %v", snapshot.ChangedKeys, want)
+		}
+		for i, key := range want {
+			if snapshot.ChangedKeys[i] != key {
+				t.Errorf("ChangedKeys[%d] = %q, want %q", i, snapshot.ChangedKeys[i], key)
+			}
+		}
+		if snapshot.Diff != nil {
+			t.Errorf("Diff should stay nil without WithReloadDiff, got %+v", snapshot.Diff)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected reload error: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for reload snapshot")
+	}
+}
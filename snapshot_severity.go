@@ -0,0 +1,163 @@
+package rigging
+
+import "strings"
+
+// Severity ranks a KeyChange for alerting, from SeverityLow to SeverityCritical.
+type Severity string
+
+// Severities assigned by CompareSnapshots.
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// rank orders severities; unknown values rank below SeverityLow.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityLow:
+		return 1
+	case SeverityMedium:
+		return 2
+	case SeverityHigh:
+		return 3
+	case SeverityCritical:
+		return 4
+	}
+	return 0
+}
+
+// SeverityRule assigns a severity to the changes it matches. A rule matches a change when
+// all of its set conditions hold; a rule without conditions matches every change.
+type SeverityRule struct {
+	// KeyPrefixes limits the rule to these key paths and the keys below them
+	// (e.g. "database" matches "database.host"). Matching is case-insensitive.
+	KeyPrefixes []string
+
+	// Kinds limits the rule to these kinds of change.
+	Kinds []ChangeKind
+
+	// Secret limits the rule to keys marked secret in either snapshot's provenance.
+	Secret bool
+
+	// Severity is assigned to matching changes.
+	Severity Severity
+}
+
+// DefaultSeverityRules classify changes when no user rule matches: secret keys are critical,
+// removed keys high, modified keys medium, and added keys low.
+var DefaultSeverityRules = []SeverityRule{
+	{Secret: true, Severity: SeverityCritical},
+	{Kinds: []ChangeKind{ChangeRemoved}, Severity: SeverityHigh},
+	{Kinds: []ChangeKind{ChangeModified}, Severity: SeverityMedium},
+	{Kinds: []ChangeKind{ChangeAdded}, Severity: SeverityLow},
+}
+
+// CompareOption configures CompareSnapshots.
+type CompareOption func(*compareConfig)
+
+// compareConfig holds internal configuration for CompareSnapshots.
+type compareConfig struct {
+	rules []SeverityRule // Checked before DefaultSeverityRules
+}
+
+// WithSeverityRules adds rules that are checked, in order, before DefaultSeverityRules;
+// the first matching rule decides a change's severity. Multiple calls are appended.
+//
+//	rigging.CompareSnapshots(old, new, rigging.WithSeverityRules(
+//		rigging.SeverityRule{KeyPrefixes: []string{"database", "auth"}, Severity: rigging.SeverityCritical},
+//	))
+func WithSeverityRules(rules ...SeverityRule) CompareOption {
+	return func(cfg *compareConfig) {
+		cfg.rules = append(cfg.rules, rules...)
+	}
+}
+
+// CompareSnapshots is DiffSnapshots with a Severity for every change, assigned by the rules
+// given with WithSeverityRules and then DefaultSeverityRules. Values of keys marked secret in
+// either snapshot's provenance are redacted, whatever their severity, even if a snapshot was
+// built by hand with raw values.
+func CompareSnapshots(before, after *ConfigSnapshot, opts ...CompareOption) *SnapshotDiff {
+	cfg := &compareConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	rules := append(append([]SeverityRule(nil), cfg.rules...), DefaultSeverityRules...)
+
+	secretKeys := make(map[string]bool)
+	for _, snapshot := range []*ConfigSnapshot{before, after} {
+		if snapshot == nil {
+			continue
+		}
+		for _, field := range snapshot.Provenance {
+			if field.Secret {
+				secretKeys[strings.ToLower(field.KeyPath)] = true
+			}
+		}
+	}
+
+	diff := DiffSnapshots(before, after)
+	for i := range diff.Changes {
+		change := &diff.Changes[i]
+		secret := secretKeys[strings.ToLower(change.Key)]
+		if secret {
+			change.OldValue = redactChangeValue(change.OldValue)
+			change.NewValue = redactChangeValue(change.NewValue)
+		}
+		for _, rule := range rules {
+			if rule.matches(*change, secret) {
+				change.Severity = rule.Severity
+				break
+			}
+		}
+	}
+	return diff
+}
+
+// matches reports whether the rule applies to change.
+func (r SeverityRule) matches(change KeyChange, secret bool) bool {
+	if r.Secret && !secret {
+		return false
+	}
+
+	if len(r.Kinds) > 0 {
+		found := false
+		for _, kind := range r.Kinds {
+			if kind == change.Kind {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(r.KeyPrefixes) > 0 {
+		key := strings.ToLower(change.Key)
+		for _, prefix := range r.KeyPrefixes {
+			prefix = strings.ToLower(prefix)
+			if key == prefix || strings.HasPrefix(key, prefix+".") {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// AtLeast returns the changes whose severity is threshold or higher, e.g. to page only on
+// SeverityHigh and SeverityCritical changes. Changes without a severity are never returned.
+func (d *SnapshotDiff) AtLeast(threshold Severity) []KeyChange {
+	if d == nil {
+		return nil
+	}
+	var changes []KeyChange
+	for _, change := range d.Changes {
+		if change.Severity.rank() > 0 && change.Severity.rank() >= threshold.rank() {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,125 @@
+package rigging
+
+import "testing"
+
+func TestCompareSnapshots(t *testing.T) {
+	before := &ConfigSnapshot{
+		Config: map[string]any{
+			"database.host":     "db1.internal",
+			"database.password": "hunter2", // Raw value, e.g. a hand-built snapshot
+			"log.level":         "info",
+			"cache.ttl":         "1m",
+		},
+		Provenance: []FieldProvenance{{FieldPath: "Database.Password", KeyPath: "database.password", Secret: true}},
+	}
+	after := &ConfigSnapshot{
+		Config: map[string]any{
+			"database.host":     "db2.internal",
+			"database.password": "correct-horse",
+			"log.level":         "debug",
+			"feature.beta":      true,
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts []CompareOption
+		want []KeyChange
+	}{
+		{
+			name: "default rules",
+			want: []KeyChange{
+				{Key: "cache.ttl", Kind: ChangeRemoved, OldValue: "1m", Severity: SeverityHigh},
+				{Key: "database.host", Kind: ChangeModified, OldValue: "db1.internal", NewValue: "db2.internal", Severity: SeverityMedium},
+				{Key: "database.password", Kind: ChangeModified, OldValue: "***redacted***", NewValue: "***redacted***", Severity: SeverityCritical},
+				{Key: "feature.beta", Kind: ChangeAdded, NewValue: true, Severity: SeverityLow},
+				{Key: "log.level", Kind: ChangeModified, OldValue: "info", NewValue: "debug", Severity: SeverityMedium},
+			},
+		},
+		{
+			name: "user rules before defaults",
+			opts: []CompareOption{
+				WithSeverityRules(SeverityRule{KeyPrefixes: []string{"Database"}, Severity: SeverityCritical}),
+				WithSeverityRules(
+					SeverityRule{KeyPrefixes: []string{"log"}, Kinds: []ChangeKind{ChangeModified}, Severity: SeverityLow},
+					SeverityRule{Kinds: []ChangeKind{ChangeRemoved}, Severity: SeverityMedium},
+				),
+			},
+			want: []KeyChange{
+				{Key: "cache.ttl", Kind: ChangeRemoved, OldValue: "1m", Severity: SeverityMedium},
+				{Key: "database.host", Kind: ChangeModified, OldValue: "db1.internal", NewValue: "db2.internal", Severity: SeverityCritical},
+				{Key: "database.password", Kind: ChangeModified, OldValue: "***redacted***", NewValue: "***redacted***", Severity: SeverityCritical},
+				{Key: "feature.beta", Kind: ChangeAdded, NewValue: true, Severity: SeverityLow},
+				{Key: "log.level", Kind: ChangeModified, OldValue: "info", NewValue: "debug", Severity: SeverityLow},
+			},
+		},
+		{
+			name: "secret stays redacted when downgraded",
+			opts: []CompareOption{
+				WithSeverityRules(SeverityRule{Secret: true, Severity: SeverityLow}),
+			},
+			want: []KeyChange{
+				{Key: "cache.ttl", Kind: ChangeRemoved, OldValue: "1m", Severity: SeverityHigh},
+				{Key: "database.host", Kind: ChangeModified, OldValue: "db1.internal", NewValue: "db2.internal", Severity: SeverityMedium},
+				{Key: "database.password", Kind: ChangeModified, OldValue: "***redacted***", NewValue: "***redacted***", Severity: SeverityLow},
+				{Key: "feature.beta", Kind: ChangeAdded, NewValue: true, Severity: SeverityLow},
+				{Key: "log.level", Kind: ChangeModified, OldValue: "info", NewValue: "debug", Severity: SeverityMedium},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := CompareSnapshots(before, after, tt.opts...)
+			if len(diff.Changes) != len(tt.want) {
+				t.Fatalf("expected %d changes, got %d: %+v", len(tt.want), len(diff.Changes), diff.Changes)
+			}
+			for i, change := range diff.Changes {
+				if change != tt.want[i] {
+					t.Errorf("change[%d] = %+v, want %+v", i, change, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSnapshotDiff_AtLeast(t *testing.T) {
+	diff := &SnapshotDiff{Changes: []KeyChange{
+		{Key: "a", Severity: SeverityLow},
+		{Key: "b", Severity: SeverityCritical},
+		{Key: "c", Severity: SeverityMedium},
+		{Key: "d", Severity: SeverityHigh},
+		{Key: "e"}, // From DiffSnapshots, unclassified
+	}}
+
+	tests := []struct {
+		threshold Severity
+		want      []string
+	}{
+		{threshold: SeverityLow, want: []string{"a", "b", "c", "d"}},
+		{threshold: SeverityHigh, want: []string{"b", "d"}},
+		{threshold: SeverityCritical, want: []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.threshold), func(t *testing.T) {
+			var got []string
+			for _, change := range diff.AtLeast(tt.threshold) {
+				got = append(got, change.Key)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("AtLeast(%s) = %v, want %v", tt.threshold, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("AtLeast(%s) = %v, want %v", tt.threshold, got, tt.want)
+				}
+			}
+		})
+	}
+
+	var nilDiff *SnapshotDiff
+	if changes := nilDiff.AtLeast(SeverityLow); changes != nil {
+		t.Errorf("nil diff AtLeast() = %v, want nil", changes)
+	}
+}
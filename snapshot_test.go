@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -441,6 +442,72 @@ func TestApplyExclusions_PreservesOriginalConfig(t *testing.T) {
 	}
 }
 
+func TestApplyPrefixExclusions(t *testing.T) {
+	config := map[string]any{
+		"database.host":      "localhost",
+		"database.pool.size": 10,
+		"databases":          "other",
+		"cache":              "redis",
+	}
+
+	result := applyPrefixExclusions(config, []string{"DATABASE", "cache"})
+
+	if len(result) != 1 || result["databases"] != "other" {
+		t.Errorf("Expected only databases to remain, got: %v", result)
+	}
+	if len(config) != 4 {
+		t.Errorf("Original config should not be modified, got: %v", config)
+	}
+	if got := applyPrefixExclusions(config, nil); len(got) != 4 {
+		t.Errorf("Expected no exclusions for nil prefixes, got: %v", got)
+	}
+}
+
+func TestApplyPrefixExclusionsProperties_SubtreeRemoval(t *testing.T) {
+	// For any set of prefixes, a key SHALL be removed if and only if it equals
+	// a prefix or lies below it, and the input map SHALL NOT be modified.
+	keys := []string{
+		"app", "app.name", "app.log.level", "app.log.format", "apps.count",
+		"db.host", "db.replica.host", "db.replica.port", "dbx", "cache.ttl",
+	}
+	candidates := []string{"app", "app.log", "db", "db.replica", "cache.ttl", "missing", "App.Log"}
+
+	// Every subset of the candidate prefixes
+	for mask := 0; mask < 1<<len(candidates); mask++ {
+		var prefixes []string
+		for i, candidate := range candidates {
+			if mask&(1<<i) != 0 {
+				prefixes = append(prefixes, candidate)
+			}
+		}
+
+		config := make(map[string]any, len(keys))
+		for _, key := range keys {
+			config[key] = key
+		}
+
+		result := applyPrefixExclusions(config, prefixes)
+
+		for _, key := range keys {
+			excluded := false
+			for _, prefix := range prefixes {
+				p := strings.ToLower(prefix)
+				if key == p || strings.HasPrefix(key, p+".") {
+					excluded = true
+				}
+			}
+
+			if _, present := result[key]; present == excluded {
+				t.Errorf("prefixes %v: key %q present = %v, want %v", prefixes, key, present, !excluded)
+			}
+		}
+
+		if len(config) != len(keys) {
+			t.Fatalf("prefixes %v: input map was modified: %v", prefixes, config)
+		}
+	}
+}
+
 // CreateSnapshot unit tests
 
 func TestCreateSnapshot_NilConfig(t *testing.T) {
@@ -544,6 +611,37 @@ func TestCreateSnapshot_VersionAndTimestamp(t *testing.T) {
 	}
 }
 
+func TestCreateSnapshot_WithClock(t *testing.T) {
+	type Config struct {
+		Host string `conf:"name:host"`
+	}
+
+	fixed := time.Date(2024, 1, 15, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	snapshot, err := CreateSnapshot(&Config{Host: "localhost"},
+		WithClock(func() time.Time { return fixed }),
+		WithMetadata(map[string]string{"taken": "{{timestamp}}"}),
+	)
+	if err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+
+	want := time.Date(2024, 1, 15, 11, 30, 0, 0, time.UTC)
+	if !snapshot.Timestamp.Equal(want) || snapshot.Timestamp.Location() != time.UTC {
+		t.Errorf("Timestamp = %v, want %v", snapshot.Timestamp, want)
+	}
+	if got := snapshot.Metadata["taken"]; got != "20240115-113000" {
+		t.Errorf("Metadata[taken] = %q, want %q", got, "20240115-113000")
+	}
+
+	dir := t.TempDir()
+	if err := WriteSnapshot(snapshot, filepath.Join(dir, "config-{{timestamp}}.json")); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "config-20240115-113000.json")); err != nil {
+		t.Errorf("snapshot not written to the clock's timestamp: %v", err)
+	}
+}
+
 func TestCreateSnapshot_WithProvenance(t *testing.T) {
 	type Config struct {
 		Host     string `conf:"name:host"`
@@ -686,6 +784,80 @@ func TestCreateSnapshotProperties_SecretRedaction(t *testing.T) {
 	}
 }
 
+func TestCreateSnapshot_WithExcludePrefixes(t *testing.T) {
+	type Database struct {
+		Host     string
+		Password string `conf:"secret"`
+	}
+	type Config struct {
+		Database Database
+		Debug    bool
+		Name     string
+	}
+
+	cfg := &Config{Database: Database{Host: "localhost", Password: "secret"}, Debug: true, Name: "api"}
+
+	snapshot, err := CreateSnapshot(cfg, WithExcludePrefixes("database"), WithExcludeFields("debug"))
+	if err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+
+	if len(snapshot.Config) != 1 || snapshot.Config["name"] != "api" {
+		t.Errorf("Expected only name to remain, got: %v", snapshot.Config)
+	}
+}
+
+func TestCreateSnapshot_WithIncludeFields(t *testing.T) {
+	type Config struct {
+		Host     string
+		Port     int
+		Password string `conf:"secret"`
+		Debug    bool
+	}
+
+	cfg := &Config{Host: "localhost", Port: 8080, Password: "secret", Debug: true}
+
+	tests := []struct {
+		name string
+		opts []SnapshotOption
+		want []string
+	}{
+		{
+			name: "keeps only included fields",
+			opts: []SnapshotOption{WithIncludeFields("HOST", "port", "unknown")},
+			want: []string{"host", "port"},
+		},
+		{
+			name: "empty include keeps all fields",
+			opts: []SnapshotOption{WithIncludeFields()},
+			want: []string{"debug", "host", "password", "port"},
+		},
+		{
+			name: "exclusions apply after includes",
+			opts: []SnapshotOption{WithIncludeFields("host", "port"), WithExcludeFields("port")},
+			want: []string{"host"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, err := CreateSnapshot(cfg, tt.opts...)
+			if err != nil {
+				t.Fatalf("CreateSnapshot failed: %v", err)
+			}
+
+			if len(snapshot.Config) != len(tt.want) {
+				t.Errorf("Expected keys %v, got: %v", tt.want, snapshot.Config)
+			}
+			for _, key := range tt.want {
+				if _, exists := snapshot.Config[key]; !exists {
+					t.Errorf("Expected key %s in snapshot, got: %v", key, snapshot.Config)
+				}
+			}
+		})
+	}
+}
+
 func TestCreateSnapshotProperties_FieldExclusion(t *testing.T) {
 	// **Feature: snapshot-core, Property 3: Field Exclusion Correctness**
 	// **Validates: Requirements 4.1**
@@ -884,6 +1056,67 @@ func TestExpandPathWithTime_TimestampOnly(t *testing.T) {
 	}
 }
 
+func TestExpandPathWithTime_Hostname(t *testing.T) {
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+	testTime := time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC)
+
+	result := ExpandPathWithTime("snapshots/{{hostname}}/config-{{timestamp}}-{{hostname}}.json", testTime)
+
+	expected := "snapshots/" + host + "/config-20240115-103045-" + host + ".json"
+	if result != expected {
+		t.Errorf("Expected %s, got: %s", expected, result)
+	}
+}
+
+func TestExpandPathWithTime_EnvVariables(t *testing.T) {
+	t.Setenv("RIGGING_TEST_REGION", "eu-west-1")
+	t.Setenv("RIGGING_TEST_NESTED", "{{timestamp}}")
+	testTime := time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"single variable", "snapshots/{{env:RIGGING_TEST_REGION}}/config.json", "snapshots/eu-west-1/config.json"},
+		{"combined with timestamp", "{{env:RIGGING_TEST_REGION}}-{{timestamp}}.json", "eu-west-1-20240115-103045.json"},
+		{"repeated", "{{env:RIGGING_TEST_REGION}}/{{env:RIGGING_TEST_REGION}}", "eu-west-1/eu-west-1"},
+		{"unset variable is empty", "config-{{env:RIGGING_TEST_UNSET}}.json", "config-.json"},
+		{"values are not expanded again", "{{env:RIGGING_TEST_NESTED}}.json", "{{timestamp}}.json"},
+		{"unknown tokens are untouched", "{{region}}/{{env:RIGGING_TEST_REGION}}", "{{region}}/eu-west-1"},
+		{"unterminated token is untouched", "config-{{env:RIGGING_TEST_REGION.json", "config-{{env:RIGGING_TEST_REGION.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ExpandPathWithTime(tt.template, testTime); result != tt.expected {
+				t.Errorf("Expected %s, got: %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestWriteSnapshot_ExpandsEnvVariables(t *testing.T) {
+	t.Setenv("RIGGING_TEST_ENVIRONMENT", "staging")
+	dir := t.TempDir()
+
+	snapshot := &ConfigSnapshot{
+		Version:   SnapshotVersion,
+		Timestamp: time.Date(2024, 1, 15, 10, 30, 45, 0, time.UTC),
+		Config:    map[string]any{"host": "localhost"},
+	}
+	if err := WriteSnapshot(snapshot, filepath.Join(dir, "{{env:RIGGING_TEST_ENVIRONMENT}}-{{timestamp}}.json")); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "staging-20240115-103045.json")); err != nil {
+		t.Errorf("expected expanded snapshot path: %v", err)
+	}
+}
+
 func TestExpandPathWithTime_NonUTCTime(t *testing.T) {
 	// Test that non-UTC times are converted to UTC for formatting
 	loc, _ := time.LoadLocation("America/New_York")
@@ -1611,6 +1844,136 @@ func TestReadSnapshot_ReadsValidSnapshotFile(t *testing.T) {
 	}
 }
 
+func TestReadSnapshot_PreservesUnknownFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourcePath := filepath.Join(tmpDir, "newer.json")
+	rewritePath := filepath.Join(tmpDir, "rewritten.json")
+
+	// Snapshot written by a newer version with fields this version doesn't know
+	content := `{
+  "version": "1.0",
+  "timestamp": "2024-01-15T10:30:45Z",
+  "config": {"host": "localhost"},
+  "provenance": [],
+  "signature": "abc123",
+  "labels": {"team": "payments"}
+}`
+	if err := os.WriteFile(sourcePath, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+
+	snapshot, err := ReadSnapshot(sourcePath)
+	if err != nil {
+		t.Fatalf("ReadSnapshot should tolerate unknown fields: %v", err)
+	}
+	if snapshot.Config["host"] != "localhost" {
+		t.Errorf("Config host = %v, want localhost", snapshot.Config["host"])
+	}
+	if len(snapshot.Extra) != 2 {
+		t.Fatalf("expected 2 extra fields, got %d: %v", len(snapshot.Extra), snapshot.Extra)
+	}
+
+	// Modify and write back: unknown fields must survive
+	snapshot.Config["host"] = "example.com"
+	if err := WriteSnapshot(snapshot, rewritePath); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+
+	data, err := os.ReadFile(rewritePath)
+	if err != nil {
+		t.Fatalf("failed to read rewritten snapshot: %v", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("rewritten snapshot is not valid JSON: %v", err)
+	}
+	if raw["signature"] != "abc123" {
+		t.Errorf("signature = %v, want abc123", raw["signature"])
+	}
+	if labels, ok := raw["labels"].(map[string]any); !ok || labels["team"] != "payments" {
+		t.Errorf("labels not preserved: %v", raw["labels"])
+	}
+	if _, ok := raw["Extra"]; ok {
+		t.Error("Extra should not be serialized as its own field")
+	}
+
+	reread, err := ReadSnapshot(rewritePath)
+	if err != nil {
+		t.Fatalf("ReadSnapshot failed: %v", err)
+	}
+	if reread.Config["host"] != "example.com" || len(reread.Extra) != 2 {
+		t.Errorf("unexpected round-trip result: config=%v extra=%v", reread.Config, reread.Extra)
+	}
+}
+
+func TestSnapshot_MetadataRoundTrip(t *testing.T) {
+	type Config struct {
+		Host string
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skipf("hostname unavailable: %v", err)
+	}
+
+	snapshot, err := CreateSnapshot(&Config{Host: "localhost"},
+		WithMetadata(map[string]string{"release": "v1.4.2", "operator": "alice"}),
+		WithMetadata(map[string]string{"operator": "bob", "origin": "{{hostname}}"}))
+	if err != nil {
+		t.Fatalf("CreateSnapshot failed: %v", err)
+	}
+
+	want := map[string]string{"release": "v1.4.2", "operator": "bob", "origin": host}
+	if !reflect.DeepEqual(snapshot.Metadata, want) {
+		t.Errorf("Metadata = %v, want %v", snapshot.Metadata, want)
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := WriteSnapshot(snapshot, path); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+	read, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatalf("ReadSnapshot failed: %v", err)
+	}
+	if !reflect.DeepEqual(read.Metadata, want) {
+		t.Errorf("Metadata after round trip = %v, want %v", read.Metadata, want)
+	}
+	if read.Extra != nil {
+		t.Errorf("metadata should not be treated as an unknown field, got Extra %v", read.Extra)
+	}
+
+	t.Run("omitted when empty", func(t *testing.T) {
+		snapshot, _ := CreateSnapshot(&Config{})
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if strings.Contains(string(data), "metadata") {
+			t.Errorf("expected no metadata field, got %s", data)
+		}
+	})
+}
+
+func TestReadSnapshot_NoExtraForKnownFields(t *testing.T) {
+	tmpDir := t.TempDir()
+	targetPath := filepath.Join(tmpDir, "snapshot.json")
+
+	snapshot := &ConfigSnapshot{Version: SnapshotVersion, Config: map[string]any{"a": "b"}}
+	if err := WriteSnapshot(snapshot, targetPath); err != nil {
+		t.Fatalf("WriteSnapshot failed: %v", err)
+	}
+
+	read, err := ReadSnapshot(targetPath)
+	if err != nil {
+		t.Fatalf("ReadSnapshot failed: %v", err)
+	}
+	if read.Extra != nil {
+		t.Errorf("expected nil Extra, got %v", read.Extra)
+	}
+}
+
 func TestReadSnapshot_ReturnsErrorForMissingFile(t *testing.T) {
 	nonExistentPath := "/path/that/does/not/exist/snapshot.json"
 
@@ -2663,3 +3026,51 @@ func TestTimestampConsistency_MultipleTemplateOccurrences(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigSnapshot_Get(t *testing.T) {
+	snapshot := &ConfigSnapshot{Config: map[string]any{
+		"database.host": "localhost",
+		"database.port": 5432,
+		"Legacy.Key":    "mixed",
+	}}
+
+	tests := []struct {
+		name    string
+		keyPath string
+		want    any
+		wantOK  bool
+	}{
+		{name: "present", keyPath: "database.host", want: "localhost", wantOK: true},
+		{name: "absent", keyPath: "database.user", wantOK: false},
+		{name: "differently cased query", keyPath: "Database.Port", want: 5432, wantOK: true},
+		{name: "differently cased stored key", keyPath: "legacy.key", want: "mixed", wantOK: true},
+		{name: "prefix is not a key", keyPath: "database", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := snapshot.Get(tt.keyPath)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Get(%q) = %v, %v; want %v, %v", tt.keyPath, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestConfigSnapshot_Keys(t *testing.T) {
+	snapshot := &ConfigSnapshot{Config: map[string]any{
+		"server.port":   8080,
+		"database.host": "localhost",
+		"api.key":       "***redacted***",
+	}}
+
+	want := []string{"api.key", "database.host", "server.port"}
+	if got := snapshot.Keys(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Keys() = %v, want %v", got, want)
+	}
+
+	empty := &ConfigSnapshot{}
+	if got := empty.Keys(); len(got) != 0 {
+		t.Errorf("Keys() on empty snapshot = %v, want none", got)
+	}
+}
@@ -3,12 +3,33 @@ package sourceenv
 import (
 	"context"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/Azhovan/rigging"
 	"github.com/Azhovan/rigging/internal/normalize"
 )
 
+// KeyStyle selects how variable names are turned into key paths.
+// In every style, double underscores (__) separate nesting levels.
+type KeyStyle string
+
+// Key styles for Options.KeyStyle.
+const (
+	// KeyStyleFlat strips single underscores: MAX_CONNECTIONS → maxconnections (default).
+	// Matches keys derived from field names (MaxConnections) and camelCase file keys.
+	KeyStyleFlat KeyStyle = "flat"
+
+	// KeyStyleSnake keeps single underscores: MAX_CONNECTIONS → max_connections.
+	// Matches snake_case keys, e.g. `name:max_connections` tags or snake_case file keys.
+	KeyStyleSnake KeyStyle = "snake"
+
+	// KeyStyleCamel joins words in camelCase: MAX_CONNECTIONS → maxConnections.
+	// Keys are matched case-insensitively when binding, so this binds like KeyStyleFlat
+	// but keeps readable keys in Load output and provenance.
+	KeyStyleCamel KeyStyle = "camel"
+)
+
 // Options configures environment variable source behavior.
 type Options struct {
 	// Prefix filters vars starting with prefix (stripped before normalization).
@@ -21,15 +42,41 @@ type Options struct {
 	// When true, prefix must match exactly.
 	// Keys are always normalized to lowercase after prefix stripping.
 	CaseSensitive bool
+
+	// Allowlist restricts loading to these variable names (after prefix stripping).
+	// Other variables are ignored, even under the prefix. Empty = no restriction.
+	// Names are matched case-insensitively unless CaseSensitive is set.
+	Allowlist []string
+
+	// Environ, when non-nil, is read instead of the process environment (os.Environ).
+	// Useful for hermetic tests and sandboxed contexts. Default: nil (real environment).
+	Environ map[string]string
+
+	// KeyStyle controls how names are normalized after prefix stripping.
+	// Empty or unknown values use KeyStyleFlat.
+	KeyStyle KeyStyle
+
+	// TreatEmptyAsUnset skips variables set to the empty string (e.g. after `export FOO=`),
+	// so that lower-precedence sources or defaults apply instead of an empty value.
+	// Default: false (empty variables are loaded and override earlier sources).
+	TreatEmptyAsUnset bool
 }
 
 type envSource struct {
-	opts Options
+	opts    Options
+	allowed map[string]struct{} // nil when no allowlist is configured
 }
 
 // New creates an environment variable source.
 func New(opts Options) rigging.Source {
-	return &envSource{opts: opts}
+	src := &envSource{opts: opts}
+	if len(opts.Allowlist) > 0 {
+		src.allowed = make(map[string]struct{}, len(opts.Allowlist))
+		for _, name := range opts.Allowlist {
+			src.allowed[src.allowlistKey(name)] = struct{}{}
+		}
+	}
+	return src
 }
 
 // Load scans environment variables, filters by prefix, and normalizes keys.
@@ -43,7 +90,7 @@ func (e *envSource) LoadWithKeys(ctx context.Context) (map[string]any, map[strin
 	result := make(map[string]any)
 	originalKeys := make(map[string]string)
 
-	for _, env := range os.Environ() {
+	for _, env := range e.environ() {
 		parts := strings.SplitN(env, "=", 2)
 		if len(parts) != 2 {
 			continue
@@ -51,6 +98,9 @@ func (e *envSource) LoadWithKeys(ctx context.Context) (map[string]any, map[strin
 
 		originalKey := parts[0]
 		value := parts[1]
+		if value == "" && e.opts.TreatEmptyAsUnset {
+			continue
+		}
 		key := originalKey
 
 		if e.opts.Prefix != "" {
@@ -71,8 +121,14 @@ func (e *envSource) LoadWithKeys(ctx context.Context) (map[string]any, map[strin
 			continue
 		}
 
+		if e.allowed != nil {
+			if _, ok := e.allowed[e.allowlistKey(key)]; !ok {
+				continue
+			}
+		}
+
 		// Normalize: FOO__BAR → foo.bar
-		normalizedKey := normalize.ToLowerDotPath(key)
+		normalizedKey := e.normalizeKey(key)
 		result[normalizedKey] = value
 		originalKeys[normalizedKey] = originalKey
 	}
@@ -80,6 +136,41 @@ func (e *envSource) LoadWithKeys(ctx context.Context) (map[string]any, map[strin
 	return result, originalKeys, nil
 }
 
+// environ returns the variables to scan as "KEY=value" pairs.
+// Options.Environ is returned in sorted order so loading is deterministic.
+func (e *envSource) environ() []string {
+	if e.opts.Environ == nil {
+		return os.Environ()
+	}
+
+	env := make([]string, 0, len(e.opts.Environ))
+	for key, value := range e.opts.Environ {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
+
+// normalizeKey converts a variable name (after prefix stripping) to a key path in the configured style.
+func (e *envSource) normalizeKey(key string) string {
+	switch e.opts.KeyStyle {
+	case KeyStyleSnake:
+		return normalize.ToSnakeDotPath(key)
+	case KeyStyleCamel:
+		return normalize.ToCamelDotPath(key)
+	default:
+		return normalize.ToLowerDotPath(key)
+	}
+}
+
+// allowlistKey returns the form of name used for allowlist matching.
+func (e *envSource) allowlistKey(name string) string {
+	if e.opts.CaseSensitive {
+		return name
+	}
+	return strings.ToUpper(name)
+}
+
 // Watch returns ErrWatchNotSupported (env vars don't change at runtime).
 func (e *envSource) Watch(ctx context.Context) (<-chan rigging.ChangeEvent, error) {
 	return nil, rigging.ErrWatchNotSupported
@@ -3,9 +3,11 @@ package sourceenv
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Azhovan/rigging"
+	"github.com/Azhovan/rigging/sourcefile"
 )
 
 func TestEnvSource_Load(t *testing.T) {
@@ -247,6 +249,71 @@ func TestEnvSource_EmptyValues(t *testing.T) {
 	}
 }
 
+func TestEnvSource_TreatEmptyAsUnset(t *testing.T) {
+	type Config struct {
+		Host string
+		Port int `conf:"default:8080"`
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("host: file.internal\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	environ := map[string]string{"APP_HOST": "", "APP_PORT": "", "APP_OTHER": "set"}
+
+	tests := []struct {
+		name       string
+		treatEmpty bool
+		wantHost   string
+		wantPort   int
+		wantErr    bool
+	}{
+		{
+			name:       "empty values clobber file and defaults",
+			treatEmpty: false,
+			wantErr:    true, // "" is not a valid int
+		},
+		{
+			name:       "empty values are unset",
+			treatEmpty: true,
+			wantHost:   "file.internal",
+			wantPort:   8080,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := New(Options{Prefix: "APP_", Environ: environ, Allowlist: []string{"HOST", "PORT"}, TreatEmptyAsUnset: tt.treatEmpty})
+
+			result, err := env.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if _, ok := result["host"]; ok == tt.treatEmpty {
+				t.Errorf("host present = %v, want %v", ok, !tt.treatEmpty)
+			}
+
+			cfg, err := rigging.NewLoader[Config]().
+				WithSource(sourcefile.New(path, sourcefile.Options{})).
+				WithSource(env).
+				Load(context.Background())
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Load() = %+v, want error", cfg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Host != tt.wantHost || cfg.Port != tt.wantPort {
+				t.Errorf("cfg = %+v, want host %q, port %d", cfg, tt.wantHost, tt.wantPort)
+			}
+		})
+	}
+}
+
 func TestEnvSource_ComplexNesting(t *testing.T) {
 	envVars := map[string]string{
 		"APP__DATABASE__CONNECTION__HOST":     "db.example.com",
@@ -286,3 +353,228 @@ func TestEnvSource_ComplexNesting(t *testing.T) {
 		}
 	}
 }
+
+func TestEnvSource_Allowlist(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        Options
+		envVars     map[string]string
+		expected    map[string]any
+		notExpected []string
+	}{
+		{
+			name: "unlisted prefixed variables are ignored",
+			opts: Options{Prefix: "APP_", Allowlist: []string{"HOST", "PORT"}},
+			envVars: map[string]string{
+				"APP_HOST":  "localhost",
+				"APP_PORT":  "8080",
+				"APP_DEBUG": "true",
+			},
+			expected: map[string]any{
+				"host": "localhost",
+				"port": "8080",
+			},
+			notExpected: []string{"debug"},
+		},
+		{
+			name: "nested names",
+			opts: Options{Prefix: "APP_", Allowlist: []string{"DATABASE__HOST"}},
+			envVars: map[string]string{
+				"APP_DATABASE__HOST": "db.example.com",
+				"APP_DATABASE__PORT": "5432",
+			},
+			expected: map[string]any{
+				"database.host": "db.example.com",
+			},
+			notExpected: []string{"database.port"},
+		},
+		{
+			name: "case insensitive by default",
+			opts: Options{Prefix: "APP_", Allowlist: []string{"host"}},
+			envVars: map[string]string{
+				"APP_HOST": "localhost",
+			},
+			expected: map[string]any{
+				"host": "localhost",
+			},
+		},
+		{
+			name: "case sensitive matching",
+			opts: Options{Prefix: "APP_", CaseSensitive: true, Allowlist: []string{"HOST"}},
+			envVars: map[string]string{
+				"APP_HOST": "localhost",
+				"APP_Port": "8080",
+			},
+			expected: map[string]any{
+				"host": "localhost",
+			},
+			notExpected: []string{"port"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.envVars {
+				os.Setenv(k, v)
+				defer os.Unsetenv(k)
+			}
+
+			result, err := New(tt.opts).Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if len(result) != len(tt.expected) {
+				t.Errorf("got %d keys, want %d: %v", len(result), len(tt.expected), result)
+			}
+			for key, expectedValue := range tt.expected {
+				if actualValue, ok := result[key]; !ok || actualValue != expectedValue {
+					t.Errorf("key %q: got %v, want %v", key, actualValue, expectedValue)
+				}
+			}
+			for _, key := range tt.notExpected {
+				if _, ok := result[key]; ok {
+					t.Errorf("unexpected key %q found in result", key)
+				}
+			}
+		})
+	}
+}
+
+func TestEnvSource_Environ(t *testing.T) {
+	os.Setenv("ENVIRONTEST_FROM_PROCESS", "process")
+	defer os.Unsetenv("ENVIRONTEST_FROM_PROCESS")
+
+	src := New(Options{
+		Prefix: "ENVIRONTEST_",
+		Environ: map[string]string{
+			"ENVIRONTEST_HOST":           "localhost",
+			"environtest_DATABASE__PORT": "5432",
+			"OTHER_VAR":                  "ignored",
+		},
+	})
+
+	result, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	expected := map[string]any{
+		"host":          "localhost",
+		"database.port": "5432",
+	}
+	if len(result) != len(expected) {
+		t.Errorf("got %d keys, want %d: %v", len(result), len(expected), result)
+	}
+	for key, expectedValue := range expected {
+		if actualValue, ok := result[key]; !ok || actualValue != expectedValue {
+			t.Errorf("key %q: got %v, want %v", key, actualValue, expectedValue)
+		}
+	}
+
+	t.Run("empty map ignores the process environment", func(t *testing.T) {
+		result, err := New(Options{Environ: map[string]string{}}).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(result) != 0 {
+			t.Errorf("expected no keys, got %v", result)
+		}
+	})
+}
+
+func TestEnvSource_KeyStyle(t *testing.T) {
+	environ := map[string]string{
+		"APP_MAX_CONNECTIONS":        "100",
+		"APP_DATABASE__CONN_TIMEOUT": "5s",
+	}
+
+	tests := []struct {
+		style    KeyStyle
+		expected map[string]any
+	}{
+		{style: "", expected: map[string]any{"maxconnections": "100", "database.conntimeout": "5s"}},
+		{style: KeyStyleFlat, expected: map[string]any{"maxconnections": "100", "database.conntimeout": "5s"}},
+		{style: KeyStyleSnake, expected: map[string]any{"max_connections": "100", "database.conn_timeout": "5s"}},
+		{style: KeyStyleCamel, expected: map[string]any{"maxConnections": "100", "database.connTimeout": "5s"}},
+		{style: "unknown", expected: map[string]any{"maxconnections": "100", "database.conntimeout": "5s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.style), func(t *testing.T) {
+			result, err := New(Options{Prefix: "APP_", Environ: environ, KeyStyle: tt.style}).Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if len(result) != len(tt.expected) {
+				t.Errorf("got %d keys, want %d: %v", len(result), len(tt.expected), result)
+			}
+			for key, expectedValue := range tt.expected {
+				if actualValue, ok := result[key]; !ok || actualValue != expectedValue {
+					t.Errorf("key %q: got %v, want %v", key, actualValue, expectedValue)
+				}
+			}
+		})
+	}
+}
+
+func TestEnvSource_KeyStyleBinding(t *testing.T) {
+	type Config struct {
+		MaxConnections int
+		IdleTimeout    string `conf:"name:idle_timeout"`
+	}
+
+	t.Run("camel env keys bind to derived field keys", func(t *testing.T) {
+		src := New(Options{Prefix: "APP_", KeyStyle: KeyStyleCamel, Environ: map[string]string{"APP_MAX_CONNECTIONS": "100"}})
+		cfg, err := rigging.NewLoader[Config]().WithSource(src).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.MaxConnections != 100 {
+			t.Errorf("MaxConnections = %d, want 100", cfg.MaxConnections)
+		}
+
+		prov, _ := rigging.GetProvenance(cfg)
+		if len(prov.Fields) != 1 || prov.Fields[0].SourceName != "env:APP_MAX_CONNECTIONS" {
+			t.Errorf("unexpected provenance: %+v", prov.Fields)
+		}
+	})
+
+	t.Run("snake env keys bind to snake_case name tags", func(t *testing.T) {
+		src := New(Options{Prefix: "APP_", KeyStyle: KeyStyleSnake, Environ: map[string]string{"APP_IDLE_TIMEOUT": "30s"}})
+		cfg, err := rigging.NewLoader[Config]().WithSource(src).Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if cfg.IdleTimeout != "30s" {
+			t.Errorf("IdleTimeout = %q, want 30s", cfg.IdleTimeout)
+		}
+	})
+}
+
+func TestEnvSource_SecretFile(t *testing.T) {
+	type Config struct {
+		Database struct {
+			Password string `conf:"secret,required"`
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, style := range []KeyStyle{KeyStyleFlat, KeyStyleSnake, KeyStyleCamel} {
+		t.Run(string(style), func(t *testing.T) {
+			src := New(Options{Prefix: "APP_", KeyStyle: style, Environ: map[string]string{"APP_DATABASE__PASSWORD_FILE": path}})
+			cfg, err := rigging.NewLoader[Config]().WithSource(src).Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.Database.Password != "hunter2" {
+				t.Errorf("Password = %q, want hunter2", cfg.Database.Password)
+			}
+		})
+	}
+}
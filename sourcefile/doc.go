@@ -6,4 +6,19 @@
 //
 //	source := sourcefile.New("config.yaml", sourcefile.Options{Required: true})
 //	loader := rigging.NewLoader[Config]().WithSource(source)
+//
+// NewFS reads the file from an fs.FS instead, e.g. one embedded with go:embed:
+//
+//	//go:embed config
+//	var configFS embed.FS
+//
+//	source := sourcefile.NewFS(configFS, "config/app.yaml", sourcefile.Options{Required: true})
+//
+// NewGlob merges all files matching a pattern, later files overriding earlier ones:
+//
+//	source := sourcefile.NewGlob("conf.d/*.yaml", sourcefile.Options{})
+//
+// Update edits a single key of a YAML file in place, keeping its comments:
+//
+//	err := sourcefile.Update("config.yaml", "database.port", 5433)
 package sourcefile
@@ -2,15 +2,15 @@ package sourcefile
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/Azhovan/rigging"
-	"github.com/pelletier/go-toml/v2"
-	"gopkg.in/yaml.v3"
+	"github.com/Azhovan/rigging/internal/document"
 )
 
 // Options configures file source behavior.
@@ -20,11 +20,29 @@ type Options struct {
 
 	// Required: if true, missing files cause an error. Default: false (returns empty map).
 	Required bool
+
+	// Positions: if true, the line of each key is tracked and reported in provenance
+	// (FieldProvenance.Line). Default: false.
+	Positions bool
+
+	// ExpandEnv: if true, ${VAR} and $VAR references in string values are expanded
+	// from the environment. VAR must start with a letter or underscore; any other "$"
+	// (e.g. "cost $5") is kept as is. Default: false.
+	ExpandEnv bool
+
+	// KeepUnmatched: with ExpandEnv, references to unset variables are kept as written
+	// instead of being replaced by an empty string. Default: false.
+	KeepUnmatched bool
+
+	// Env: if non-nil, variables are looked up in this map instead of the process
+	// environment. Intended for tests.
+	Env map[string]string
 }
 
 type fileSource struct {
 	path string
 	opts Options
+	fsys fs.FS // Filesystem to read from, nil for the OS filesystem
 }
 
 // New creates a file-based configuration source.
@@ -35,6 +53,18 @@ func New(path string, opts Options) rigging.Source {
 	}
 }
 
+// NewFS creates a configuration source for the file at path in fsys, such as an embed.FS
+// or fstest.MapFS. Paths use the io/fs conventions: slash-separated and unrooted
+// ("config/app.yaml"). Format detection and Options behave as with New, including
+// Required for missing files.
+func NewFS(fsys fs.FS, path string, opts Options) rigging.Source {
+	return &fileSource{
+		path: path,
+		opts: opts,
+		fsys: fsys,
+	}
+}
+
 // Load reads and parses the file, returning flattened configuration.
 func (f *fileSource) Load(ctx context.Context) (map[string]any, error) {
 	result, _, err := f.LoadWithKeys(ctx)
@@ -43,15 +73,22 @@ func (f *fileSource) Load(ctx context.Context) (map[string]any, error) {
 
 // LoadWithKeys reads and parses the file, returning flattened configuration with original keys.
 func (f *fileSource) LoadWithKeys(ctx context.Context) (map[string]any, map[string]string, error) {
-	data, err := os.ReadFile(f.path)
+	result, originalKeys, _, err := f.LoadWithPositions(ctx)
+	return result, originalKeys, err
+}
+
+// LoadWithPositions reads and parses the file, returning flattened configuration with original keys
+// and, when Options.Positions is set, the line of each key.
+func (f *fileSource) LoadWithPositions(ctx context.Context) (map[string]any, map[string]string, map[string]int, error) {
+	data, err := f.readFile()
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			if f.opts.Required {
-				return nil, nil, fmt.Errorf("required config file not found: %s: %w", f.path, err)
+				return nil, nil, nil, fmt.Errorf("required config file not found: %s: %w", f.path, err)
 			}
-			return make(map[string]any), make(map[string]string), nil
+			return make(map[string]any), make(map[string]string), nil, nil
 		}
-		return nil, nil, fmt.Errorf("read config file %s: %w", f.path, err)
+		return nil, nil, nil, fmt.Errorf("read config file %s: %w", f.path, err)
 	}
 
 	format := f.opts.Format
@@ -59,61 +96,137 @@ func (f *fileSource) LoadWithKeys(ctx context.Context) (map[string]any, map[stri
 		format = inferFormat(f.path)
 	}
 
-	var raw map[string]any
-	switch format {
-	case "yaml", "yml":
-		if err := yaml.Unmarshal(data, &raw); err != nil {
-			return nil, nil, fmt.Errorf("parse YAML file %s: %w", f.path, err)
-		}
-	case "json":
-		if err := json.Unmarshal(data, &raw); err != nil {
-			return nil, nil, fmt.Errorf("parse JSON file %s: %w", f.path, err)
-		}
-	case "toml":
-		if err := toml.Unmarshal(data, &raw); err != nil {
-			return nil, nil, fmt.Errorf("parse TOML file %s: %w", f.path, err)
-		}
-	default:
-		return nil, nil, fmt.Errorf("unsupported file format: %s (supported: yaml, json, toml)", format)
+	raw, err := document.Parse(format, data)
+	if errors.Is(err, document.ErrUnsupportedFormat) {
+		return nil, nil, nil, fmt.Errorf("unsupported file format: %s (supported: yaml, json, toml)", format)
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("parse %s file %s: %w", document.Name(format), f.path, err)
 	}
 
 	// Flatten nested structures to dot-separated keys
-	flattened := make(map[string]any)
-	originalKeys := make(map[string]string)
-	flattenMapWithKeys("", raw, flattened, originalKeys)
+	flattened, originalKeys := document.Flatten(raw)
+
+	if f.opts.ExpandEnv {
+		for key, value := range flattened {
+			flattened[key] = f.expand(value)
+		}
+	}
+
+	if !f.opts.Positions {
+		return flattened, originalKeys, nil, nil
+	}
+
+	lines, err := keyLines(format, data)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("read key positions in %s: %w", f.path, err)
+	}
+
+	return flattened, originalKeys, lines, nil
+}
 
-	return flattened, originalKeys, nil
+// readFile reads the file from fsys, or from the OS filesystem if fsys is nil.
+func (f *fileSource) readFile() ([]byte, error) {
+	if f.fsys != nil {
+		return fs.ReadFile(f.fsys, f.path)
+	}
+	return os.ReadFile(f.path)
 }
 
-// flattenMapWithKeys recursively flattens nested maps to dot-separated keys and tracks original keys.
-func flattenMapWithKeys(prefix string, value any, result map[string]any, originalKeys map[string]string) {
+// expand replaces environment references in string values, including strings
+// inside lists and objects within lists. Other values are returned unchanged.
+func (f *fileSource) expand(value any) any {
 	switch v := value.(type) {
-	case map[string]any:
-		for key, val := range v {
-			newPrefix := key
-			if prefix != "" {
-				newPrefix = prefix + "." + key
-			}
-			flattenMapWithKeys(newPrefix, val, result, originalKeys)
+	case string:
+		return f.expandString(v)
+	case []any:
+		expanded := make([]any, len(v))
+		for i, elem := range v {
+			expanded[i] = f.expand(elem)
 		}
-	case map[any]any:
-		for key, val := range v {
-			keyStr, ok := key.(string)
-			if !ok {
-				continue
-			}
-			newPrefix := keyStr
-			if prefix != "" {
-				newPrefix = prefix + "." + keyStr
-			}
-			flattenMapWithKeys(newPrefix, val, result, originalKeys)
+		return expanded
+	case map[string]any:
+		expanded := make(map[string]any, len(v))
+		for key, elem := range v {
+			expanded[key] = f.expand(elem)
 		}
+		return expanded
 	default:
-		if prefix != "" {
-			result[prefix] = value
-			originalKeys[prefix] = prefix // For files, the key is already in the right format
+		return value
+	}
+}
+
+// expandString replaces ${NAME} and $NAME references in s, where NAME starts with a letter or
+// underscore followed by letters, digits, or underscores. Any other "$" (e.g. "$5", "$$", or
+// "${1}") is kept as is. Unlike os.Expand, unset variables with KeepUnmatched keep their
+// original text, so "$MISSING/x" stays "$MISSING/x".
+func (f *fileSource) expandString(s string) string {
+	if !strings.Contains(s, "$") {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '$' {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		name, end := envReference(s, i)
+		if name == "" {
+			b.WriteByte('$')
+			i++
+			continue
+		}
+		if value, ok := f.lookupEnv(name); ok {
+			b.WriteString(value)
+		} else if f.opts.KeepUnmatched {
+			b.WriteString(s[i:end])
 		}
+		i = end
+	}
+	return b.String()
+}
+
+// envReference parses a reference starting at the "$" at s[start]. It returns the variable
+// name and the end of the reference, or an empty name if s[start:] is not a reference.
+func envReference(s string, start int) (string, int) {
+	i := start + 1
+	braced := i < len(s) && s[i] == '{'
+	if braced {
+		i++
+	}
+
+	nameStart := i
+	for i < len(s) && (s[i] == '_' || isLetter(s[i]) || (i > nameStart && s[i] >= '0' && s[i] <= '9')) {
+		i++
+	}
+	if i == nameStart {
+		return "", start
+	}
+	name := s[nameStart:i]
+
+	if braced {
+		if i >= len(s) || s[i] != '}' {
+			return "", start
+		}
+		i++
+	}
+	return name, i
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// lookupEnv resolves a single variable from Env or the process environment.
+func (f *fileSource) lookupEnv(name string) (string, bool) {
+	if f.opts.Env != nil {
+		value, ok := f.opts.Env[name]
+		return value, ok
 	}
+	return os.LookupEnv(name)
 }
 
 // Watch returns ErrWatchNotSupported (file watching not yet implemented).
@@ -1,7 +1,9 @@
 package sourcefile
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -328,167 +330,319 @@ func TestFileSource_ArraysPreserved(t *testing.T) {
 	assert.Len(t, ports, 3)
 }
 
-func TestFlattenMapWithKeys_SimpleMap(t *testing.T) {
-	input := map[string]any{
-		"key1": "value1",
-		"key2": "value2",
-	}
-	result := make(map[string]any)
-	originalKeys := make(map[string]string)
+func TestFileSource_LoadWithPositions(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    map[string]int
+	}{
+		{
+			name: "yaml",
+			file: "config.yaml",
+			content: `database:
+  host: localhost
 
-	flattenMapWithKeys("", input, result, originalKeys)
+  port: 5432
+features:
+  - a
+  - b
+`,
+			want: map[string]int{"database.host": 2, "database.port": 4, "features": 5},
+		},
+		{
+			name: "json",
+			file: "config.json",
+			content: `{
+  "database": {
+    "host": "localhost",
+    "port": 5432
+  },
+  "features": [{"name": "a"}],
+  "debug": true
+}`,
+			want: map[string]int{"database.host": 3, "database.port": 4, "features": 6, "debug": 7},
+		},
+		{
+			name: "toml",
+			file: "config.toml",
+			content: `debug = true
 
-	assert.Equal(t, "value1", result["key1"])
-	assert.Equal(t, "value2", result["key2"])
-	assert.Equal(t, "key1", originalKeys["key1"])
-	assert.Equal(t, "key2", originalKeys["key2"])
-}
+[database]
+host = "localhost"
+pool = { size = 10 }
+server.port = 8080
 
-func TestFlattenMapWithKeys_NestedMap(t *testing.T) {
-	input := map[string]any{
-		"database": map[string]any{
-			"host": "localhost",
-			"port": 5432,
+[[servers]]
+name = "a"
+`,
+			want: map[string]int{"debug": 1, "database.host": 4, "database.pool.size": 5, "database.server.port": 6},
 		},
 	}
-	result := make(map[string]any)
-	originalKeys := make(map[string]string)
 
-	flattenMapWithKeys("", input, result, originalKeys)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
 
-	assert.Equal(t, "localhost", result["database.host"])
-	assert.Equal(t, 5432, result["database.port"])
-	assert.Equal(t, "database.host", originalKeys["database.host"])
-	assert.Equal(t, "database.port", originalKeys["database.port"])
+			src := New(path, Options{Positions: true}).(rigging.SourceWithPositions)
+			_, _, lines, err := src.LoadWithPositions(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, lines)
+		})
+	}
 }
 
-func TestFlattenMapWithKeys_DeepNesting(t *testing.T) {
-	input := map[string]any{
-		"level1": map[string]any{
-			"level2": map[string]any{
-				"level3": map[string]any{
-					"key": "deep-value",
-				},
-			},
-		},
+func TestFileSource_LoadWithPositions_Disabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("host: localhost\n"), 0644))
+
+	src := New(path, Options{}).(rigging.SourceWithPositions)
+	data, _, lines, err := src.LoadWithPositions(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", data["host"])
+	assert.Nil(t, lines)
+}
+
+func TestFileSource_Positions_Provenance(t *testing.T) {
+	type Config struct {
+		Database struct {
+			Host string
+			Port int
+		}
 	}
-	result := make(map[string]any)
-	originalKeys := make(map[string]string)
 
-	flattenMapWithKeys("", input, result, originalKeys)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("database:\n  host: localhost\n  port: 5432\n"), 0644))
 
-	assert.Equal(t, "deep-value", result["level1.level2.level3.key"])
-	assert.Equal(t, "level1.level2.level3.key", originalKeys["level1.level2.level3.key"])
-}
+	cfg, err := rigging.NewLoader[Config]().
+		WithSource(New(path, Options{Positions: true})).
+		Load(context.Background())
+	require.NoError(t, err)
 
-func TestFlattenMapWithKeys_WithPrefix(t *testing.T) {
-	input := map[string]any{
-		"host": "localhost",
-		"port": 5432,
+	prov, ok := rigging.GetProvenance(cfg)
+	require.True(t, ok)
+	lines := make(map[string]int)
+	for _, field := range prov.Fields {
+		lines[field.KeyPath] = field.Line
 	}
-	result := make(map[string]any)
-	originalKeys := make(map[string]string)
+	assert.Equal(t, map[string]int{"database.host": 2, "database.port": 3}, lines)
+}
 
-	flattenMapWithKeys("database", input, result, originalKeys)
+func TestFileSource_YAMLAnchorsAndMergeKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `defaults: &defaults
+  timeout: 30s
+  retries: 3
+  tls:
+    enabled: true
+limits: &limits
+  retries: 1
+  rate: 100
+primary:
+  <<: *defaults
+  host: primary.internal
+  retries: 5
+replica:
+  <<: [*defaults, *limits]
+  host: replica.internal
+  tls:
+    enabled: false
+cache: *limits
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	src := New(path, Options{Positions: true}).(rigging.SourceWithPositions)
+	data, _, lines, err := src.LoadWithPositions(context.Background())
+	require.NoError(t, err)
 
-	assert.Equal(t, "localhost", result["database.host"])
-	assert.Equal(t, 5432, result["database.port"])
-	assert.Equal(t, "database.host", originalKeys["database.host"])
-	assert.Equal(t, "database.port", originalKeys["database.port"])
+	assert.Equal(t, map[string]any{
+		"defaults.timeout":     "30s",
+		"defaults.retries":     3,
+		"defaults.tls.enabled": true,
+		"limits.retries":       1,
+		"limits.rate":          100,
+		"primary.timeout":      "30s",
+		"primary.retries":      5,
+		"primary.tls.enabled":  true,
+		"primary.host":         "primary.internal",
+		"replica.timeout":      "30s",
+		"replica.retries":      3, // The first merged map wins
+		"replica.rate":         100,
+		"replica.tls.enabled":  false,
+		"replica.host":         "replica.internal",
+		"cache.retries":        1,
+		"cache.rate":           100,
+	}, data)
+
+	// Merged keys report the line of the anchored definition
+	assert.Equal(t, 2, lines["primary.timeout"])
+	assert.Equal(t, 12, lines["primary.retries"])
+	assert.Equal(t, 3, lines["replica.retries"])
+	assert.Equal(t, 8, lines["replica.rate"])
+	assert.Equal(t, 17, lines["replica.tls.enabled"])
+	assert.Equal(t, 7, lines["cache.retries"])
+	assert.NotContains(t, lines, "primary.<<")
 }
 
-func TestFlattenMapWithKeys_MapAnyAny(t *testing.T) {
-	input := map[any]any{
-		"key1": "value1",
-		"key2": 123,
+func TestFileSource_OptionalSliceWithNulls(t *testing.T) {
+	type Config struct {
+		Weights []rigging.Optional[int]
 	}
-	result := make(map[string]any)
-	originalKeys := make(map[string]string)
 
-	flattenMapWithKeys("", input, result, originalKeys)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("weights: [10, null, 0, ~]\n"), 0644))
+
+	cfg, err := rigging.NewLoader[Config]().
+		WithSource(New(path, Options{})).
+		Load(context.Background())
+	require.NoError(t, err)
 
-	assert.Equal(t, "value1", result["key1"])
-	assert.Equal(t, 123, result["key2"])
-	assert.Equal(t, "key1", originalKeys["key1"])
-	assert.Equal(t, "key2", originalKeys["key2"])
+	assert.Equal(t, []rigging.Optional[int]{
+		{Value: 10, Set: true},
+		{},
+		{Value: 0, Set: true},
+		{},
+	}, cfg.Weights)
 }
 
-func TestFlattenMapWithKeys_MapAnyAnyNested(t *testing.T) {
-	input := map[any]any{
-		"database": map[any]any{
-			"host": "localhost",
-			"port": 5432,
+func TestFileSource_ExpandEnv(t *testing.T) {
+	env := map[string]string{
+		"DB_PASSWORD": "s3cret",
+		"DB_HOST":     "db.internal",
+		"DB_PORT":     "5432",
+	}
+	yamlContent := `
+database:
+  password: ${DB_PASSWORD}
+  url: postgres://$DB_HOST:${DB_PORT}/app
+  replica:
+    host: ${DB_REPLICA}
+hosts:
+  - ${DB_HOST}
+  - name: $DB_HOST
+port: 8080
+literal: no references
+`
+
+	tests := []struct {
+		name string
+		opts Options
+		want map[string]any
+	}{
+		{
+			name: "disabled",
+			opts: Options{Env: env},
+			want: map[string]any{
+				"database.password":     "${DB_PASSWORD}",
+				"database.url":          "postgres://$DB_HOST:${DB_PORT}/app",
+				"database.replica.host": "${DB_REPLICA}",
+				"hosts":                 []any{"${DB_HOST}", map[string]any{"name": "$DB_HOST"}},
+				"port":                  8080,
+				"literal":               "no references",
+			},
+		},
+		{
+			name: "missing variables become empty",
+			opts: Options{ExpandEnv: true, Env: env},
+			want: map[string]any{
+				"database.password":     "s3cret",
+				"database.url":          "postgres://db.internal:5432/app",
+				"database.replica.host": "",
+				"hosts":                 []any{"db.internal", map[string]any{"name": "db.internal"}},
+				"port":                  8080,
+				"literal":               "no references",
+			},
+		},
+		{
+			name: "missing variables kept",
+			opts: Options{ExpandEnv: true, KeepUnmatched: true, Env: env},
+			want: map[string]any{
+				"database.password":     "s3cret",
+				"database.url":          "postgres://db.internal:5432/app",
+				"database.replica.host": "${DB_REPLICA}",
+				"hosts":                 []any{"db.internal", map[string]any{"name": "db.internal"}},
+				"port":                  8080,
+				"literal":               "no references",
+			},
 		},
 	}
-	result := make(map[string]any)
-	originalKeys := make(map[string]string)
 
-	flattenMapWithKeys("", input, result, originalKeys)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlFile := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(yamlFile, []byte(yamlContent), 0644))
 
-	assert.Equal(t, "localhost", result["database.host"])
-	assert.Equal(t, 5432, result["database.port"])
+			result, err := New(yamlFile, tt.opts).Load(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
 }
 
-func TestFlattenMapWithKeys_MapAnyAnyNonStringKey(t *testing.T) {
-	input := map[any]any{
-		"valid":   "value1",
-		123:       "ignored", // non-string key should be skipped
-		"another": "value2",
+func TestFileSource_ExpandEnv_Literals(t *testing.T) {
+	env := map[string]string{"DB_HOST": "db.internal"}
+
+	tests := []struct {
+		value     string
+		want      string
+		wantEmpty string // Without KeepUnmatched
+	}{
+		{value: "$MISSING/x", want: "$MISSING/x", wantEmpty: "/x"},
+		{value: "${MISSING}/x", want: "${MISSING}/x", wantEmpty: "/x"},
+		{value: "cost $5", want: "cost $5", wantEmpty: "cost $5"},
+		{value: "$2a$10$N9qo8uLOickgx2ZMRZoMye", want: "$2a$10$N9qo8uLOickgx2ZMRZoMye", wantEmpty: "$2a$10"},
+		{value: "100% $$ ${1} ${DB_HOST", want: "100% $$ ${1} ${DB_HOST", wantEmpty: "100% $$ ${1} ${DB_HOST"},
+		{value: "trailing $", want: "trailing $", wantEmpty: "trailing $"},
+		{value: "$DB_HOST:${DB_HOST}-x", want: "db.internal:db.internal-x", wantEmpty: "db.internal:db.internal-x"},
 	}
-	result := make(map[string]any)
-	originalKeys := make(map[string]string)
 
-	flattenMapWithKeys("", input, result, originalKeys)
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			data, err := json.Marshal(map[string]string{"value": tt.value})
+			require.NoError(t, err)
+			jsonFile := filepath.Join(t.TempDir(), "config.json")
+			require.NoError(t, os.WriteFile(jsonFile, data, 0644))
 
-	assert.Equal(t, "value1", result["valid"])
-	assert.Equal(t, "value2", result["another"])
-	assert.NotContains(t, result, "123")
-}
+			result, err := New(jsonFile, Options{ExpandEnv: true, KeepUnmatched: true, Env: env}).Load(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result["value"])
 
-func TestFlattenMapWithKeys_MixedTypes(t *testing.T) {
-	input := map[string]any{
-		"string": "text",
-		"number": 42,
-		"bool":   true,
-		"float":  3.14,
-		"array":  []any{1, 2, 3},
-		"nested": map[string]any{
-			"key": "value",
-		},
+			result, err = New(jsonFile, Options{ExpandEnv: true, Env: env}).Load(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEmpty, result["value"])
+		})
 	}
-	prefix := "pref"
-	result := make(map[string]any)
-	originalKeys := make(map[string]string)
-
-	flattenMapWithKeys(prefix, input, result, originalKeys)
-
-	assert.Equal(t, "text", result["pref.string"])
-	assert.Equal(t, 42, result["pref.number"])
-	assert.Equal(t, true, result["pref.bool"])
-	assert.Equal(t, 3.14, result["pref.float"])
-	assert.Equal(t, []any{1, 2, 3}, result["pref.array"])
-	assert.Equal(t, "value", result["pref.nested.key"])
 }
 
-func TestFlattenMapWithKeys_EmptyMap(t *testing.T) {
-	input := map[string]any{}
-	result := make(map[string]any)
-	originalKeys := make(map[string]string)
+func TestFileSource_ExpandEnv_ProcessEnvironment(t *testing.T) {
+	t.Setenv("RIGGING_TEST_TOKEN", "from-env")
 
-	flattenMapWithKeys("", input, result, originalKeys)
+	jsonFile := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"api": {"token": "${RIGGING_TEST_TOKEN}"}}`), 0644))
 
-	assert.Empty(t, result)
-	assert.Empty(t, originalKeys)
+	result, err := New(jsonFile, Options{ExpandEnv: true}).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", result["api.token"])
 }
 
-func TestFlattenMapWithKeys_EmptyPrefix(t *testing.T) {
-	input := "value"
-	result := make(map[string]any)
-	originalKeys := make(map[string]string)
+func TestFileSource_ExpandEnv_SecretRedacted(t *testing.T) {
+	type Config struct {
+		Database struct {
+			Password string `conf:"secret"`
+		}
+	}
+
+	yamlFile := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte("database:\n  password: ${DB_PASSWORD}\n"), 0644))
 
-	// When prefix is empty, the value should not be added
-	flattenMapWithKeys("", input, result, originalKeys)
+	source := New(yamlFile, Options{ExpandEnv: true, Env: map[string]string{"DB_PASSWORD": "s3cret"}})
+	cfg, err := rigging.NewLoader[Config]().WithSource(source).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", cfg.Database.Password)
 
-	assert.Empty(t, result)
-	assert.Empty(t, originalKeys)
+	var buf bytes.Buffer
+	require.NoError(t, rigging.DumpEffective(&buf, cfg))
+	assert.NotContains(t, buf.String(), "s3cret")
+	assert.Contains(t, buf.String(), "***redacted***")
 }
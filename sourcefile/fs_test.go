@@ -0,0 +1,112 @@
+package sourcefile
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Azhovan/rigging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFSSource_FormatInference(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config/app.yaml": {Data: []byte("database:\n  host: localhost\n  port: 5432\n")},
+		"config/app.yml":  {Data: []byte("key: value")},
+		"config/app.json": {Data: []byte(`{"database": {"host": "localhost"}}`)},
+		"config/app.toml": {Data: []byte("[database]\nhost = \"localhost\"\n")},
+		"config/app.txt":  {Data: []byte("key: value")},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		opts     Options
+		expected map[string]any
+	}{
+		{
+			name:     "yaml extension",
+			path:     "config/app.yaml",
+			expected: map[string]any{"database.host": "localhost", "database.port": 5432},
+		},
+		{
+			name:     "yml extension",
+			path:     "config/app.yml",
+			expected: map[string]any{"key": "value"},
+		},
+		{
+			name:     "json extension",
+			path:     "config/app.json",
+			expected: map[string]any{"database.host": "localhost"},
+		},
+		{
+			name:     "toml extension",
+			path:     "config/app.toml",
+			expected: map[string]any{"database.host": "localhost"},
+		},
+		{
+			name:     "explicit format",
+			path:     "config/app.txt",
+			opts:     Options{Format: "yaml"},
+			expected: map[string]any{"key": "value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := NewFS(fsys, tt.path, tt.opts).Load(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, data)
+		})
+	}
+}
+
+func TestFSSource_MissingFile(t *testing.T) {
+	fsys := fstest.MapFS{}
+
+	data, err := NewFS(fsys, "config.yaml", Options{}).Load(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, data, "should return empty map for missing non-required file")
+
+	data, err = NewFS(fsys, "config.yaml", Options{Required: true}).Load(context.Background())
+	require.Error(t, err)
+	assert.Nil(t, data)
+	assert.Contains(t, err.Error(), "required config file not found")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestFSSource_UnsupportedFormat(t *testing.T) {
+	fsys := fstest.MapFS{"config.ini": {Data: []byte("key=value")}}
+
+	_, err := NewFS(fsys, "config.ini", Options{}).Load(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported file format")
+}
+
+func TestFSSource_Loader(t *testing.T) {
+	type Config struct {
+		Host string
+		Port int
+	}
+
+	fsys := fstest.MapFS{"config/app.yaml": {Data: []byte("host: localhost\n\nport: 8080\n")}}
+
+	cfg, err := rigging.NewLoader[Config]().
+		WithSource(NewFS(fsys, "config/app.yaml", Options{Required: true, Positions: true})).
+		Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", cfg.Host)
+	assert.Equal(t, 8080, cfg.Port)
+
+	prov, ok := rigging.GetProvenance(cfg)
+	require.True(t, ok)
+	for _, field := range prov.Fields {
+		assert.Equal(t, "file:app.yaml", field.SourceName)
+		if field.FieldPath == "Port" {
+			assert.Equal(t, 3, field.Line)
+		}
+	}
+}
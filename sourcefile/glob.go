@@ -0,0 +1,92 @@
+package sourcefile
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/Azhovan/rigging"
+)
+
+type globSource struct {
+	pattern string
+	opts    Options
+}
+
+// NewGlob creates a source that merges all files matching pattern (e.g. "conf.d/*.yaml", see
+// filepath.Match) in lexicographic order of their paths, later files overriding earlier ones.
+// Provenance names the file each key came from (e.g. "file:conf.d/10-db.yaml").
+// Options apply to every file; the format is inferred per file unless set.
+// With Required, a pattern matching no files is an error.
+func NewGlob(pattern string, opts Options) rigging.Source {
+	return &globSource{
+		pattern: pattern,
+		opts:    opts,
+	}
+}
+
+// Load reads and merges the matching files, returning flattened configuration.
+func (g *globSource) Load(ctx context.Context) (map[string]any, error) {
+	result, _, err := g.LoadWithKeys(ctx)
+	return result, err
+}
+
+// LoadWithKeys reads and merges the matching files, returning flattened configuration
+// with the file each key came from.
+func (g *globSource) LoadWithKeys(ctx context.Context) (map[string]any, map[string]string, error) {
+	result, originalKeys, _, err := g.LoadWithPositions(ctx)
+	return result, originalKeys, err
+}
+
+// LoadWithPositions reads and merges the matching files, returning flattened configuration
+// with the file each key came from and, when Options.Positions is set, its line in that file.
+func (g *globSource) LoadWithPositions(ctx context.Context) (map[string]any, map[string]string, map[string]int, error) {
+	matches, err := filepath.Glob(g.pattern)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("match config files %s: %w", g.pattern, err)
+	}
+	if len(matches) == 0 && g.opts.Required {
+		return nil, nil, nil, fmt.Errorf("no config files match %s", g.pattern)
+	}
+	sort.Strings(matches)
+
+	result := make(map[string]any)
+	originalKeys := make(map[string]string)
+	var lines map[string]int
+	if g.opts.Positions {
+		lines = make(map[string]int)
+	}
+
+	for _, path := range matches {
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+
+		file := &fileSource{path: path, opts: g.opts}
+		data, _, fileLines, err := file.LoadWithPositions(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for key, value := range data {
+			result[key] = value
+			originalKeys[key] = "file:" + path
+			if lines != nil {
+				lines[key] = fileLines[key]
+			}
+		}
+	}
+
+	return result, originalKeys, lines, nil
+}
+
+// Watch returns ErrWatchNotSupported (file watching not yet implemented).
+func (g *globSource) Watch(ctx context.Context) (<-chan rigging.ChangeEvent, error) {
+	return nil, rigging.ErrWatchNotSupported
+}
+
+// Name returns a human-readable identifier for this source.
+func (g *globSource) Name() string {
+	return "file:" + g.pattern
+}
@@ -0,0 +1,138 @@
+package sourcefile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Azhovan/rigging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles creates files (name -> content) in a new temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestGlobSource_OverrideOrder(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"10-base.yaml": "database:\n  host: base.internal\n  port: 5432\nlog:\n  level: info\n",
+		"20-db.yaml":   "database:\n  host: db.internal\n",
+		"30-log.json":  `{"log": {"level": "debug"}}`,
+		"notes.txt":    "not matched",
+	})
+
+	tests := []struct {
+		name     string
+		pattern  string
+		wantData map[string]any
+		wantKeys map[string]string
+	}{
+		{
+			name:    "later files override earlier ones",
+			pattern: filepath.Join(dir, "*0-*"),
+			wantData: map[string]any{
+				"database.host": "db.internal",
+				"database.port": 5432,
+				"log.level":     "debug",
+			},
+			wantKeys: map[string]string{
+				"database.host": "file:" + filepath.Join(dir, "20-db.yaml"),
+				"database.port": "file:" + filepath.Join(dir, "10-base.yaml"),
+				"log.level":     "file:" + filepath.Join(dir, "30-log.json"),
+			},
+		},
+		{
+			name:    "pattern selects files",
+			pattern: filepath.Join(dir, "*.yaml"),
+			wantData: map[string]any{
+				"database.host": "db.internal",
+				"database.port": 5432,
+				"log.level":     "info",
+			},
+			wantKeys: map[string]string{
+				"database.host": "file:" + filepath.Join(dir, "20-db.yaml"),
+				"database.port": "file:" + filepath.Join(dir, "10-base.yaml"),
+				"log.level":     "file:" + filepath.Join(dir, "10-base.yaml"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := NewGlob(tt.pattern, Options{})
+			data, keys, err := source.(rigging.SourceWithKeys).LoadWithKeys(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantData, data)
+			assert.Equal(t, tt.wantKeys, keys)
+		})
+	}
+}
+
+func TestGlobSource_Provenance(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"10-base.yaml": "host: base.internal\nport: 5432\n",
+		"20-db.yaml":   "\nhost: db.internal\n",
+	})
+
+	type Config struct {
+		Host string
+		Port int
+	}
+
+	source := NewGlob(filepath.Join(dir, "*.yaml"), Options{Positions: true})
+	cfg, err := rigging.NewLoader[Config]().WithSource(source).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "db.internal", cfg.Host)
+	assert.Equal(t, 5432, cfg.Port)
+
+	prov, ok := rigging.GetProvenance(cfg)
+	require.True(t, ok)
+	byField := make(map[string]rigging.FieldProvenance)
+	for _, field := range prov.Fields {
+		byField[field.FieldPath] = field
+	}
+	assert.Equal(t, "file:"+filepath.Join(dir, "20-db.yaml"), byField["Host"].SourceName)
+	assert.Equal(t, 2, byField["Host"].Line)
+	assert.Equal(t, "file:"+filepath.Join(dir, "10-base.yaml"), byField["Port"].SourceName)
+	assert.Equal(t, 2, byField["Port"].Line)
+}
+
+func TestGlobSource_NoMatches(t *testing.T) {
+	pattern := filepath.Join(t.TempDir(), "*.yaml")
+
+	data, err := NewGlob(pattern, Options{}).Load(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, data)
+
+	_, err = NewGlob(pattern, Options{Required: true}).Load(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no config files match")
+}
+
+func TestGlobSource_MalformedFile(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"10-good.yaml": "host: localhost\n",
+		"20-bad.yaml":  "host: [unclosed\n",
+	})
+
+	_, err := NewGlob(filepath.Join(dir, "*.yaml"), Options{}).Load(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), filepath.Join(dir, "20-bad.yaml"))
+}
+
+func TestGlobSource_BadPattern(t *testing.T) {
+	_, err := NewGlob("[", Options{}).Load(context.Background())
+	require.Error(t, err)
+}
+
+func TestGlobSource_Name(t *testing.T) {
+	assert.Equal(t, "file:conf.d/*.yaml", NewGlob("conf.d/*.yaml", Options{}).Name())
+}
@@ -0,0 +1,205 @@
+package sourcefile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+	"gopkg.in/yaml.v3"
+)
+
+// keyLines returns the 1-based line on which each flattened leaf key is defined.
+func keyLines(format string, data []byte) (map[string]int, error) {
+	lines := make(map[string]int)
+
+	switch format {
+	case "yaml", "yml":
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		if len(doc.Content) > 0 {
+			yamlKeyLines("", doc.Content[0], lines)
+		}
+	case "json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if err := jsonKeyLines("", dec, data, lines); err != nil {
+			return nil, err
+		}
+	case "toml":
+		if err := tomlKeyLines(data, lines); err != nil {
+			return nil, err
+		}
+	}
+
+	return lines, nil
+}
+
+// yamlKeyLines records the line of every leaf key below a YAML mapping node.
+// Aliases resolve to their anchors, so keys merged in with `<<:` report the line
+// where the anchored value is defined. Explicit keys override merged ones.
+func yamlKeyLines(prefix string, node *yaml.Node, lines map[string]int) {
+	node = resolveAlias(node)
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	// Merged maps first, so explicit keys below override them
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if keyNode := node.Content[i]; keyNode.Tag == "!!merge" || keyNode.Value == "<<" {
+			valueNode := resolveAlias(node.Content[i+1])
+			if valueNode.Kind == yaml.SequenceNode {
+				// Earlier maps in the sequence take precedence
+				for j := len(valueNode.Content) - 1; j >= 0; j-- {
+					yamlKeyLines(prefix, valueNode.Content[j], lines)
+				}
+				continue
+			}
+			yamlKeyLines(prefix, valueNode, lines)
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], resolveAlias(node.Content[i+1])
+		if keyNode.Tag == "!!merge" || keyNode.Value == "<<" {
+			continue
+		}
+		key := joinKey(prefix, keyNode.Value)
+		if valueNode.Kind == yaml.MappingNode {
+			yamlKeyLines(key, valueNode, lines)
+			continue
+		}
+		lines[key] = keyNode.Line
+	}
+}
+
+// resolveAlias returns the node an alias refers to, or node itself.
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+// jsonKeyLines reads one JSON value from dec and records the line of every leaf key in it.
+func jsonKeyLines(prefix string, dec *json.Decoder, data []byte, lines map[string]int) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil // Scalar value
+	}
+
+	switch delim {
+	case '{':
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			name, ok := token.(string)
+			if !ok {
+				return fmt.Errorf("unexpected JSON token %v", token)
+			}
+			key := joinKey(prefix, name)
+			// The offset is just past the key, which is on the line where it is defined
+			lines[key] = lineAt(data, dec.InputOffset())
+			if err := jsonKeyLines(key, dec, data, lines); err != nil {
+				return err
+			}
+		}
+	case '[':
+		// Arrays are leaf values; skip their contents
+		for dec.More() {
+			if err := jsonKeyLines("", dec, data, map[string]int{}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Consume the closing delimiter
+	if _, err := dec.Token(); err != nil && err != io.EOF {
+		return err
+	}
+
+	// Objects are not leaves
+	if delim == '{' && prefix != "" {
+		delete(lines, prefix)
+	}
+	return nil
+}
+
+// tomlKeyLines records the line of every leaf key in a TOML document.
+// Keys inside arrays of tables are skipped since arrays are leaf values.
+func tomlKeyLines(data []byte, lines map[string]int) error {
+	var p unstable.Parser
+	p.Reset(data)
+
+	table := ""
+	inArray := false
+	for p.NextExpression() {
+		expr := p.Expression()
+		switch expr.Kind {
+		case unstable.Table:
+			table = tomlKey("", expr.Key())
+			inArray = false
+		case unstable.ArrayTable:
+			inArray = true
+		case unstable.KeyValue:
+			if !inArray {
+				tomlKeyValueLines(&p, table, expr, lines)
+			}
+		}
+	}
+	return p.Error()
+}
+
+// tomlKeyValueLines records the line of a key-value, recursing into inline tables.
+func tomlKeyValueLines(p *unstable.Parser, prefix string, expr *unstable.Node, lines map[string]int) {
+	keys := expr.Key()
+	key := tomlKey(prefix, keys)
+
+	value := expr.Value()
+	if value.Kind == unstable.InlineTable {
+		children := value.Children()
+		for children.Next() {
+			tomlKeyValueLines(p, key, children.Node(), lines)
+		}
+		return
+	}
+
+	first := expr.Key()
+	if first.Next() {
+		lines[key] = p.Shape(first.Node().Raw).Start.Line
+	}
+}
+
+// tomlKey joins the parts of a (possibly dotted) TOML key onto prefix.
+func tomlKey(prefix string, keys unstable.Iterator) string {
+	key := prefix
+	for keys.Next() {
+		key = joinKey(key, string(keys.Node().Data))
+	}
+	return key
+}
+
+// joinKey appends name to a dot-separated prefix.
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// lineAt returns the 1-based line containing byte offset.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte{'\n'}) + 1
+}
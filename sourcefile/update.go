@@ -0,0 +1,191 @@
+package sourcefile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUpdateUnsupported is returned by Update for formats whose libraries cannot rewrite a file
+// without losing comments or key order (JSON and TOML).
+var ErrUpdateUnsupported = errors.New("sourcefile: in-place update not supported for this format")
+
+// Update sets keyPath (e.g. "database.port") in the YAML file at path to value, keeping the
+// comments, key order, and indentation of the rest of the document (blank lines are not
+// kept, a limitation of the YAML library). Keys match
+// case-insensitively, like keys seen by the loader. Missing keys and intermediate mappings are
+// appended; a key provided only through a merge key (<<) is added explicitly, overriding it.
+// The file is replaced atomically. JSON and TOML files return ErrUpdateUnsupported.
+func Update(path, keyPath string, value any) error {
+	if format := inferFormat(path); format != "yaml" {
+		return fmt.Errorf("update %s: %w", path, ErrUpdateUnsupported)
+	}
+	segments := strings.Split(keyPath, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return fmt.Errorf("update %s: invalid key path %q", path, keyPath)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file %s: %w", path, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat config file %s: %w", path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parse YAML file %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	if err := setYAMLValue(doc.Content[0], segments, value); err != nil {
+		return fmt.Errorf("update %s in %s: %w", keyPath, path, err)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(yamlIndent(doc.Content[0]))
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("encode YAML file %s: %w", path, err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("encode YAML file %s: %w", path, err)
+	}
+
+	return writeFileAtomic(path, buf.Bytes(), info.Mode().Perm())
+}
+
+// setYAMLValue sets the value at segments below a mapping node, creating missing mappings
+// and replacing null ones.
+func setYAMLValue(node *yaml.Node, segments []string, value any) error {
+	if node.Kind == yaml.AliasNode {
+		return errors.New("value is defined through an alias")
+	}
+	// A null section (e.g. "database:" with nothing below it) becomes a mapping, keeping its comments
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		*node = yaml.Node{
+			Kind:        yaml.MappingNode,
+			Tag:         "!!map",
+			HeadComment: node.HeadComment,
+			LineComment: node.LineComment,
+			FootComment: node.FootComment,
+		}
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s is not a mapping", yamlKindName(node))
+	}
+
+	valueIndex := findYAMLKey(node, segments[0])
+	if valueIndex < 0 {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: segments[0]},
+			&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"},
+		)
+		valueIndex = len(node.Content) - 1
+	}
+
+	if len(segments) > 1 {
+		if err := setYAMLValue(node.Content[valueIndex], segments[1:], value); err != nil {
+			return fmt.Errorf("%s: %w", segments[0], err)
+		}
+		return nil
+	}
+
+	var newNode yaml.Node
+	if err := newNode.Encode(value); err != nil {
+		return err
+	}
+	oldNode := node.Content[valueIndex]
+	if oldNode.Kind == yaml.ScalarNode && newNode.Kind == yaml.ScalarNode && oldNode.Tag == newNode.Tag {
+		newNode.Style = oldNode.Style // Keep quoting of strings
+	}
+	newNode.HeadComment = oldNode.HeadComment
+	newNode.LineComment = oldNode.LineComment
+	newNode.FootComment = oldNode.FootComment
+	node.Content[valueIndex] = &newNode
+	return nil
+}
+
+// findYAMLKey returns the index of the value for key in a mapping node, or -1.
+// An exact match wins over a case-insensitive one; merge keys are skipped.
+func findYAMLKey(node *yaml.Node, key string) int {
+	folded := -1
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode := node.Content[i]
+		if keyNode.Tag == "!!merge" || keyNode.Value == "<<" {
+			continue
+		}
+		if keyNode.Value == key {
+			return i + 1
+		}
+		if folded < 0 && strings.EqualFold(keyNode.Value, key) {
+			folded = i + 1
+		}
+	}
+	return folded
+}
+
+// yamlKindName describes a node for error messages.
+func yamlKindName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		return "sequence"
+	case yaml.ScalarNode:
+		return fmt.Sprintf("scalar %q", node.Value)
+	default:
+		return "value"
+	}
+}
+
+// yamlIndent returns the indentation of the first nested mapping, or 2 if there is none.
+func yamlIndent(node *yaml.Node) int {
+	if node.Kind != yaml.MappingNode {
+		return 2
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if valueNode.Kind == yaml.MappingNode && valueNode.Style&yaml.FlowStyle == 0 && len(valueNode.Content) > 0 {
+			if indent := valueNode.Content[0].Column - keyNode.Column; indent > 0 {
+				return indent
+			}
+		}
+	}
+	return 2
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it over path,
+// so readers never see a partially written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file for %s: %w", path, err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // No-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %s: %w", tmpName, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write %s: %w", tmpName, err)
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		return fmt.Errorf("chmod %s: %w", tmpName, err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("replace %s: %w", path, err)
+	}
+	return nil
+}
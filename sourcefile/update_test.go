@@ -0,0 +1,199 @@
+package sourcefile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const updateYAML = `# Service configuration
+server:
+  host: localhost # bind address
+  port: 8080
+
+# Database settings
+Database:
+  name: "app"
+  pool:
+    size: 5
+`
+
+// writeUpdateFile writes content to a new file with the given name and returns its path.
+func writeUpdateFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o640))
+	return path
+}
+
+func TestUpdate_YAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyPath string
+		value   any
+		want    string
+	}{
+		{
+			name:    "existing key keeps comments and order, not blank lines",
+			keyPath: "server.port",
+			value:   9090,
+			want: `# Service configuration
+server:
+  host: localhost # bind address
+  port: 9090
+# Database settings
+Database:
+  name: "app"
+  pool:
+    size: 5
+`,
+		},
+		{
+			name:    "line comment and quoting kept",
+			keyPath: "database.name",
+			value:   "orders",
+			want: `# Service configuration
+server:
+  host: localhost # bind address
+  port: 8080
+# Database settings
+Database:
+  name: "orders"
+  pool:
+    size: 5
+`,
+		},
+		{
+			name:    "new nested key is appended",
+			keyPath: "cache.ttl",
+			value:   "5m",
+			want: `# Service configuration
+server:
+  host: localhost # bind address
+  port: 8080
+# Database settings
+Database:
+  name: "app"
+  pool:
+    size: 5
+cache:
+  ttl: 5m
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeUpdateFile(t, "config.yaml", updateYAML)
+
+			require.NoError(t, Update(path, tt.keyPath, tt.value))
+
+			got, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestUpdate_RoundTrip(t *testing.T) {
+	path := writeUpdateFile(t, "config.yml", updateYAML)
+
+	require.NoError(t, Update(path, "server.host", "0.0.0.0"))
+	require.NoError(t, Update(path, "database.pool.size", 20))
+	require.NoError(t, Update(path, "features", []string{"search", "beta"}))
+
+	data, err := New(path, Options{}).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "0.0.0.0", data["server.host"])
+	assert.Equal(t, 20, data["Database.pool.size"])
+	assert.Equal(t, []any{"search", "beta"}, data["features"])
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o640), info.Mode().Perm(), "file mode is preserved")
+}
+
+func TestUpdate_MergeKey(t *testing.T) {
+	path := writeUpdateFile(t, "config.yaml", `base: &base
+  host: localhost
+  port: 8080
+server:
+  <<: *base
+  port: 9000
+`)
+
+	require.NoError(t, Update(path, "server.host", "example.com"))
+
+	data, err := New(path, Options{}).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", data["server.host"])
+	assert.Equal(t, "localhost", data["base.host"], "the anchored mapping is unchanged")
+	assert.Equal(t, 9000, data["server.port"])
+}
+
+func TestUpdate_NullSection(t *testing.T) {
+	path := writeUpdateFile(t, "config.yaml", `server:
+  port: 8080
+# Database settings
+database: # filled in per environment
+cache: ~
+`)
+
+	require.NoError(t, Update(path, "database.host", "db.internal"))
+	require.NoError(t, Update(path, "cache.ttl", "5m"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `server:
+  port: 8080
+# Database settings
+database: # filled in per environment
+  host: db.internal
+cache:
+  ttl: 5m
+`, string(data))
+}
+
+func TestUpdate_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		keyPath string
+		wantErr string
+		wantIs  error
+	}{
+		{name: "JSON", file: "config.json", content: `{"port": 8080}`, keyPath: "port", wantIs: ErrUpdateUnsupported},
+		{name: "TOML", file: "config.toml", content: "port = 8080\n", keyPath: "port", wantIs: ErrUpdateUnsupported},
+		{name: "scalar parent", file: "config.yaml", content: "server: localhost\n", keyPath: "server.port", wantErr: `scalar "localhost" is not a mapping`},
+		{name: "sequence parent", file: "config.yaml", content: "hosts:\n  - a\n", keyPath: "hosts.first", wantErr: "sequence is not a mapping"},
+		{name: "alias parent", file: "config.yaml", content: "base: &b\n  port: 1\nserver: *b\n", keyPath: "server.port", wantErr: "alias"},
+		{name: "empty segment", file: "config.yaml", content: "port: 1\n", keyPath: "server..port", wantErr: "invalid key path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeUpdateFile(t, tt.file, tt.content)
+
+			err := Update(path, tt.keyPath, 1)
+			require.Error(t, err)
+			if tt.wantIs != nil {
+				assert.ErrorIs(t, err, tt.wantIs)
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+
+			got, readErr := os.ReadFile(path)
+			require.NoError(t, readErr)
+			assert.Equal(t, tt.content, string(got), "file is unchanged on error")
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		err := Update(filepath.Join(t.TempDir(), "missing.yaml"), "port", 1)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
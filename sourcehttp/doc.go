@@ -0,0 +1,13 @@
+// Package sourcehttp loads configuration from a JSON, YAML, or TOML document served over HTTP.
+//
+// Format is taken from Options.Format, the response Content-Type, or the URL extension.
+//
+// Example:
+//
+//	source := sourcehttp.New("https://config.internal/app.json", sourcehttp.Options{
+//	    Header:   http.Header{"Authorization": {"Bearer " + token}},
+//	    Timeout:  5 * time.Second,
+//	    Required: true,
+//	})
+//	loader := rigging.NewLoader[Config]().WithSource(source)
+package sourcehttp
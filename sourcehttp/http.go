@@ -0,0 +1,157 @@
+package sourcehttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Azhovan/rigging"
+	"github.com/Azhovan/rigging/internal/document"
+)
+
+// Options configures HTTP source behavior.
+type Options struct {
+	// Header is added to the GET request (e.g., Authorization).
+	Header http.Header
+
+	// Timeout bounds each request in addition to the context deadline. Zero means no extra timeout.
+	Timeout time.Duration
+
+	// Format: "yaml", "json", or "toml". Detected from the Content-Type or URL extension if empty.
+	Format string
+
+	// Required: if true, a 404 response causes an error. Default: false (returns empty map on 404).
+	// Other non-2xx responses always cause an error.
+	Required bool
+
+	// Client performs the request. Default: http.DefaultClient.
+	Client *http.Client
+}
+
+type httpSource struct {
+	url  string
+	opts Options
+}
+
+// New creates an HTTP configuration source that fetches url with a GET request.
+func New(url string, opts Options) rigging.Source {
+	return &httpSource{
+		url:  url,
+		opts: opts,
+	}
+}
+
+// Load fetches and parses the document, returning flattened configuration.
+func (h *httpSource) Load(ctx context.Context) (map[string]any, error) {
+	result, _, err := h.LoadWithKeys(ctx)
+	return result, err
+}
+
+// LoadWithKeys fetches and parses the document, returning flattened configuration with original keys.
+func (h *httpSource) LoadWithKeys(ctx context.Context) (map[string]any, map[string]string, error) {
+	if h.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.opts.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create request for %s: %w", h.Name(), err)
+	}
+	for key, values := range h.opts.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	client := h.opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch %s: %w", h.Name(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound && !h.opts.Required {
+		return make(map[string]any), make(map[string]string), nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, nil, fmt.Errorf("fetch %s: unexpected status %s", h.Name(), resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read response from %s: %w", h.Name(), err)
+	}
+
+	format := h.opts.Format
+	if format == "" {
+		format = inferFormat(resp.Header.Get("Content-Type"), h.url)
+	}
+
+	raw, err := document.Parse(format, body)
+	if errors.Is(err, document.ErrUnsupportedFormat) {
+		return nil, nil, fmt.Errorf("unsupported format %q from %s (supported: yaml, json, toml)", format, h.Name())
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse %s from %s: %w", document.Name(format), h.Name(), err)
+	}
+
+	// Flatten nested structures to dot-separated keys
+	flattened, originalKeys := document.Flatten(raw)
+
+	return flattened, originalKeys, nil
+}
+
+// Watch returns ErrWatchNotSupported (remote watching not yet implemented).
+func (h *httpSource) Watch(ctx context.Context) (<-chan rigging.ChangeEvent, error) {
+	return nil, rigging.ErrWatchNotSupported
+}
+
+// Name returns a human-readable identifier for this source.
+// Credentials and query parameters are omitted since they may contain secrets.
+func (h *httpSource) Name() string {
+	u, err := url.Parse(h.url)
+	if err != nil {
+		return "http"
+	}
+	return "http:" + u.Host + u.Path
+}
+
+// inferFormat detects the document format from the Content-Type, falling back to the URL extension.
+func inferFormat(contentType string, rawURL string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch {
+		case strings.HasSuffix(mediaType, "json"):
+			return "json"
+		case strings.HasSuffix(mediaType, "yaml"):
+			return "yaml"
+		case strings.HasSuffix(mediaType, "toml"):
+			return "toml"
+		}
+	}
+
+	if u, err := url.Parse(rawURL); err == nil {
+		switch strings.ToLower(path.Ext(u.Path)) {
+		case ".yaml", ".yml":
+			return "yaml"
+		case ".json":
+			return "json"
+		case ".toml":
+			return "toml"
+		}
+	}
+
+	return ""
+}
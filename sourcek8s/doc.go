@@ -0,0 +1,20 @@
+// Package sourcek8s loads configuration from Kubernetes ConfigMap and Secret volume mounts,
+// where every file in a directory is one key and its contents are the value.
+//
+// File names are normalized like environment variables: double underscores (__) separate
+// nesting levels, so a file named "database__host" provides "database.host". Names that
+// already contain dots (e.g. "database.host") are kept as is. A trailing newline is trimmed
+// from each value.
+//
+// Files under Options.SecretDir override keys from the main directory and are marked secret,
+// so their values are redacted in provenance-aware output such as DumpEffective even if the
+// field has no secret tag.
+//
+// Hidden entries (including the "..data" links Kubernetes uses for atomic updates),
+// directories, binary files, and files larger than Options.MaxFileSize are skipped.
+//
+// Example:
+//
+//	source := sourcek8s.New("/etc/config", sourcek8s.Options{SecretDir: "/etc/secrets"})
+//	loader := rigging.NewLoader[Config]().WithSource(source)
+package sourcek8s
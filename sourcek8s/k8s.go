@@ -0,0 +1,161 @@
+package sourcek8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Azhovan/rigging"
+	"github.com/Azhovan/rigging/internal/normalize"
+)
+
+// defaultMaxFileSize matches the 1 MiB size limit of Kubernetes ConfigMaps and Secrets.
+const defaultMaxFileSize = 1 << 20
+
+// Options configures Kubernetes mount source behavior.
+type Options struct {
+	// Prefix filters files whose names start with prefix (stripped before normalization).
+	Prefix string
+
+	// SecretDir is an optional Secret mount, read like the main directory.
+	// Its keys override keys from the main directory and are marked secret.
+	SecretDir string
+
+	// Required: if true, missing directories cause an error. Default: false (treated as empty).
+	Required bool
+
+	// MaxFileSize skips files larger than this many bytes. Default: 1 MiB.
+	MaxFileSize int64
+}
+
+type k8sSource struct {
+	dir  string
+	opts Options
+}
+
+// New creates a configuration source that reads one key per file from dir.
+func New(dir string, opts Options) rigging.Source {
+	return &k8sSource{
+		dir:  dir,
+		opts: opts,
+	}
+}
+
+// Load reads the mounted directories and returns configuration keyed by normalized file name.
+func (s *k8sSource) Load(ctx context.Context) (map[string]any, error) {
+	data, _, _, err := s.LoadWithSecrets(ctx)
+	return data, err
+}
+
+// LoadWithKeys reads the mounted directories and maps each key to its file as "file:<path>".
+func (s *k8sSource) LoadWithKeys(ctx context.Context) (map[string]any, map[string]string, error) {
+	data, originalKeys, _, err := s.LoadWithSecrets(ctx)
+	return data, originalKeys, err
+}
+
+// LoadWithSecrets behaves like LoadWithKeys and additionally reports the keys read from SecretDir.
+func (s *k8sSource) LoadWithSecrets(ctx context.Context) (map[string]any, map[string]string, map[string]bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	data := make(map[string]any)
+	originalKeys := make(map[string]string)
+	secrets := make(map[string]bool)
+
+	if err := s.readDir(s.dir, false, data, originalKeys, secrets); err != nil {
+		return nil, nil, nil, err
+	}
+	if s.opts.SecretDir != "" {
+		if err := s.readDir(s.opts.SecretDir, true, data, originalKeys, secrets); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return data, originalKeys, secrets, nil
+}
+
+// readDir adds one key per eligible file in dir.
+func (s *k8sSource) readDir(dir string, secret bool, data map[string]any, originalKeys map[string]string, secrets map[string]bool) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) && !s.opts.Required {
+			return nil
+		}
+		return fmt.Errorf("read mount directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		// Skip hidden entries, including the "..data" link and timestamped directories of atomic updates
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		if s.opts.Prefix != "" {
+			if !strings.HasPrefix(name, s.opts.Prefix) {
+				continue
+			}
+			name = name[len(s.opts.Prefix):]
+		}
+		if name == "" {
+			continue
+		}
+
+		// Stat follows the symlinks that mounted keys are exposed through
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue // Dangling link, e.g. during an update
+			}
+			return fmt.Errorf("stat %s: %w", path, err)
+		}
+		if !info.Mode().IsRegular() || info.Size() > s.maxFileSize() {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", path, err)
+		}
+		// Binary content such as keystores cannot be bound to config fields
+		if bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content) {
+			continue
+		}
+
+		// Normalize: DATABASE__HOST → database.host
+		key := normalize.ToLowerDotPath(name)
+		data[key] = strings.TrimRight(string(content), "\r\n")
+		originalKeys[key] = "file:" + path
+		if secret {
+			secrets[key] = true
+		}
+	}
+
+	return nil
+}
+
+// maxFileSize returns the configured size limit, or the default.
+func (s *k8sSource) maxFileSize() int64 {
+	if s.opts.MaxFileSize > 0 {
+		return s.opts.MaxFileSize
+	}
+	return defaultMaxFileSize
+}
+
+// Watch returns ErrWatchNotSupported. Wrap the source with rigging.PollSource to pick up
+// updates that Kubernetes applies to the mounted files.
+func (s *k8sSource) Watch(ctx context.Context) (<-chan rigging.ChangeEvent, error) {
+	return nil, rigging.ErrWatchNotSupported
+}
+
+// Name returns a human-readable identifier for this source.
+func (s *k8sSource) Name() string {
+	return "k8s:" + s.dir
+}
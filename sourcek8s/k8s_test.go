@@ -0,0 +1,173 @@
+package sourcek8s
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Azhovan/rigging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles creates the given files in dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+}
+
+func TestK8sSource_LoadWithKeys(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"host":            "db.example.com\n",
+		"database__port":  "5432",
+		"MAX_CONNECTIONS": "10\r\n",
+		"log.level":       "debug",
+		".hidden":         "ignored",
+	})
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0o700))
+
+	data, originalKeys, err := New(dir, Options{}).(rigging.SourceWithKeys).LoadWithKeys(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"host":           "db.example.com",
+		"database.port":  "5432",
+		"maxconnections": "10",
+		"log.level":      "debug",
+	}, data)
+	assert.Equal(t, "file:"+filepath.Join(dir, "database__port"), originalKeys["database.port"])
+	assert.Equal(t, "file:"+filepath.Join(dir, "MAX_CONNECTIONS"), originalKeys["maxconnections"])
+}
+
+func TestK8sSource_Prefix(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"APP_host":  "localhost",
+		"APP_":      "empty key",
+		"OTHER_key": "ignored",
+	})
+
+	data, err := New(dir, Options{Prefix: "APP_"}).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"host": "localhost"}, data)
+}
+
+func TestK8sSource_AtomicMountLayout(t *testing.T) {
+	// Kubernetes exposes each key as a link through "..data" to a timestamped directory
+	dir := t.TempDir()
+	versioned := filepath.Join(dir, "..2024_01_01_00_00_00.000000001")
+	require.NoError(t, os.Mkdir(versioned, 0o700))
+	writeFiles(t, versioned, map[string]string{"host": "linked"})
+	require.NoError(t, os.Symlink(filepath.Base(versioned), filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "host"), filepath.Join(dir, "host")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "missing"), filepath.Join(dir, "dangling")))
+
+	data, err := New(dir, Options{}).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"host": "linked"}, data)
+}
+
+func TestK8sSource_SkipsLargeAndBinaryFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"small":    "ok",
+		"large":    strings.Repeat("x", 64),
+		"keystore": "\x00\x01binary",
+		"latin1":   "caf\xe9",
+	})
+
+	data, err := New(dir, Options{MaxFileSize: 32}).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"small": "ok"}, data)
+}
+
+func TestK8sSource_SecretDir(t *testing.T) {
+	dir := t.TempDir()
+	secretDir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"host": "localhost", "password": "from-configmap"})
+	writeFiles(t, secretDir, map[string]string{"password": "hunter2\n", "api__token": "tok_123"})
+
+	data, originalKeys, secrets, err := New(dir, Options{SecretDir: secretDir}).(rigging.SourceWithSecrets).LoadWithSecrets(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"host": "localhost", "password": "hunter2", "api.token": "tok_123"}, data)
+	assert.Equal(t, map[string]bool{"password": true, "api.token": true}, secrets)
+	assert.Equal(t, "file:"+filepath.Join(secretDir, "password"), originalKeys["password"])
+}
+
+func TestK8sSource_MissingDirectory(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "optional", opts: Options{}},
+		{name: "optional secret dir", opts: Options{SecretDir: missing}},
+		{name: "required", opts: Options{Required: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := New(missing, tt.opts).Load(context.Background())
+			if tt.wantErr {
+				assert.ErrorContains(t, err, missing)
+				return
+			}
+			require.NoError(t, err)
+			assert.Empty(t, data)
+		})
+	}
+}
+
+func TestK8sSource_Loader(t *testing.T) {
+	type Config struct {
+		Host     string `conf:"required"`
+		Password string
+	}
+
+	dir := t.TempDir()
+	secretDir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"host": "db.internal"})
+	writeFiles(t, secretDir, map[string]string{"password": "hunter2"})
+
+	cfg, err := rigging.NewLoader[Config]().
+		WithSource(New(dir, Options{SecretDir: secretDir})).
+		Strict(true).
+		Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", cfg.Password)
+
+	prov, ok := rigging.GetProvenance(cfg)
+	require.True(t, ok)
+	for _, field := range prov.Fields {
+		switch field.FieldPath {
+		case "Host":
+			assert.Equal(t, "file:"+filepath.Join(dir, "host"), field.SourceName)
+			assert.False(t, field.Secret)
+		case "Password":
+			assert.Equal(t, "file:"+filepath.Join(secretDir, "password"), field.SourceName)
+			assert.True(t, field.Secret, "values from SecretDir are secret without a secret tag")
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, rigging.DumpEffective(&buf, cfg))
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.Contains(t, buf.String(), "db.internal")
+}
+
+func TestK8sSource_WatchAndName(t *testing.T) {
+	source := New("/etc/config", Options{})
+
+	_, err := source.Watch(context.Background())
+	assert.ErrorIs(t, err, rigging.ErrWatchNotSupported)
+	assert.Equal(t, "k8s:/etc/config", source.Name())
+}
@@ -0,0 +1,10 @@
+// Package sourcereader loads configuration from a JSON, YAML, or TOML document read from an io.Reader.
+//
+// The reader is consumed on the first Load; later calls return the cached result,
+// so the source can be reused across reloads.
+//
+// Example:
+//
+//	source := sourcereader.New(os.Stdin, "yaml", sourcereader.Options{Name: "stdin"})
+//	loader := rigging.NewLoader[Config]().WithSource(source)
+package sourcereader
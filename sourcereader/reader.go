@@ -0,0 +1,107 @@
+package sourcereader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/Azhovan/rigging"
+	"github.com/Azhovan/rigging/internal/document"
+)
+
+// Options configures reader source behavior.
+type Options struct {
+	// Name identifies the source in provenance and errors. Default: "reader:<format>".
+	Name string
+}
+
+type readerSource struct {
+	r      io.Reader
+	format string
+	opts   Options
+
+	once         sync.Once
+	data         map[string]any
+	originalKeys map[string]string
+	err          error
+}
+
+// New creates a configuration source that parses r as format ("yaml", "json", or "toml").
+// The reader is read once; if it implements io.Closer it is closed after reading.
+func New(r io.Reader, format string, opts Options) rigging.Source {
+	return &readerSource{
+		r:      r,
+		format: format,
+		opts:   opts,
+	}
+}
+
+// Load reads and parses the stream on first call, returning flattened configuration.
+func (s *readerSource) Load(ctx context.Context) (map[string]any, error) {
+	result, _, err := s.LoadWithKeys(ctx)
+	return result, err
+}
+
+// LoadWithKeys reads and parses the stream on first call, returning flattened configuration with original keys.
+// Later calls return a copy of the cached result (or the cached error).
+func (s *readerSource) LoadWithKeys(ctx context.Context) (map[string]any, map[string]string, error) {
+	s.once.Do(func() {
+		s.data, s.originalKeys, s.err = s.read()
+	})
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+
+	data := make(map[string]any, len(s.data))
+	for key, value := range s.data {
+		data[key] = value
+	}
+	originalKeys := make(map[string]string, len(s.originalKeys))
+	for key, original := range s.originalKeys {
+		originalKeys[key] = original
+	}
+	return data, originalKeys, nil
+}
+
+// read consumes the reader and flattens the parsed document.
+func (s *readerSource) read() (map[string]any, map[string]string, error) {
+	if s.r == nil {
+		return nil, nil, fmt.Errorf("read %s: nil reader", s.Name())
+	}
+	if closer, ok := s.r.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	body, err := io.ReadAll(s.r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read %s: %w", s.Name(), err)
+	}
+
+	raw, err := document.Parse(s.format, body)
+	if errors.Is(err, document.ErrUnsupportedFormat) {
+		return nil, nil, fmt.Errorf("unsupported format %q for %s (supported: yaml, json, toml)", s.format, s.Name())
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse %s from %s: %w", document.Name(s.format), s.Name(), err)
+	}
+
+	// Flatten nested structures to dot-separated keys
+	flattened, originalKeys := document.Flatten(raw)
+
+	return flattened, originalKeys, nil
+}
+
+// Watch returns ErrWatchNotSupported (a consumed stream cannot change).
+func (s *readerSource) Watch(ctx context.Context) (<-chan rigging.ChangeEvent, error) {
+	return nil, rigging.ErrWatchNotSupported
+}
+
+// Name returns a human-readable identifier for this source.
+func (s *readerSource) Name() string {
+	if s.opts.Name != "" {
+		return s.opts.Name
+	}
+	return "reader:" + s.format
+}
@@ -0,0 +1,155 @@
+package sourcereader
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Azhovan/rigging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderSource_Load_Formats(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		body     string
+		expected map[string]any
+	}{
+		{
+			name:     "json",
+			format:   "json",
+			body:     `{"database": {"host": "db.example.com", "port": 5432}}`,
+			expected: map[string]any{"database.host": "db.example.com", "database.port": float64(5432)},
+		},
+		{
+			name:     "yaml",
+			format:   "yaml",
+			body:     "database:\n  host: localhost\n",
+			expected: map[string]any{"database.host": "localhost"},
+		},
+		{
+			name:     "yml alias",
+			format:   "yml",
+			body:     "key: value",
+			expected: map[string]any{"key": "value"},
+		},
+		{
+			name:     "toml",
+			format:   "toml",
+			body:     "[database]\nhost = \"localhost\"\n",
+			expected: map[string]any{"database.host": "localhost"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := New(strings.NewReader(tt.body), tt.format, Options{})
+
+			data, err := source.Load(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, data)
+		})
+	}
+}
+
+func TestReaderSource_Load_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		reader io.Reader
+		format string
+		errMsg string
+	}{
+		{name: "unsupported format", reader: strings.NewReader("a=b"), format: "ini", errMsg: "unsupported format"},
+		{name: "invalid json", reader: strings.NewReader("{"), format: "json", errMsg: "parse JSON"},
+		{name: "read error", reader: io.MultiReader(strings.NewReader("a: 1"), errReader{}), format: "yaml", errMsg: "boom"},
+		{name: "nil reader", reader: nil, format: "yaml", errMsg: "nil reader"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.reader, tt.format, Options{}).Load(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestReaderSource_Load_CachesResult(t *testing.T) {
+	rc := &closeTracker{Reader: strings.NewReader("server:\n  port: 8080\n")}
+	source := New(rc, "yaml", Options{})
+
+	first, err := source.Load(context.Background())
+	require.NoError(t, err)
+	assert.True(t, rc.closed, "reader should be closed after the first read")
+
+	// Mutating a returned map must not affect later loads
+	first["server.port"] = 1
+
+	second, err := source.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"server.port": 8080}, second)
+}
+
+func TestReaderSource_LoadWithKeys(t *testing.T) {
+	source := New(strings.NewReader(`{"Database": {"Host": "localhost"}}`), "json", Options{})
+
+	withKeys, ok := source.(rigging.SourceWithKeys)
+	require.True(t, ok, "reader source should implement SourceWithKeys")
+
+	data, originalKeys, err := withKeys.LoadWithKeys(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"Database.Host": "localhost"}, data)
+	assert.Equal(t, map[string]string{"Database.Host": "Database.Host"}, originalKeys)
+}
+
+func TestReaderSource_Name(t *testing.T) {
+	assert.Equal(t, "reader:json", New(strings.NewReader("{}"), "json", Options{}).Name())
+	assert.Equal(t, "stdin", New(strings.NewReader("{}"), "json", Options{Name: "stdin"}).Name())
+}
+
+func TestReaderSource_Watch(t *testing.T) {
+	_, err := New(strings.NewReader("{}"), "json", Options{}).Watch(context.Background())
+	assert.ErrorIs(t, err, rigging.ErrWatchNotSupported)
+}
+
+func TestReaderSource_WithLoader(t *testing.T) {
+	type Config struct {
+		Server struct {
+			Host string
+			Port int
+		}
+	}
+
+	cfg, err := rigging.NewLoader[Config]().
+		WithSource(New(strings.NewReader("server:\n  host: example.com\n  port: 9090\n"), "yaml", Options{Name: "stdin"})).
+		Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", cfg.Server.Host)
+	assert.Equal(t, 9090, cfg.Server.Port)
+
+	prov, ok := rigging.GetProvenance(cfg)
+	require.True(t, ok)
+	for _, field := range prov.Fields {
+		assert.Equal(t, "stdin", field.SourceName)
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("boom")
+}
+
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
@@ -0,0 +1,21 @@
+// Package sourcessm loads configuration from AWS Systems Manager Parameter Store.
+//
+// All parameters under a path prefix are fetched, following pagination. The prefix is
+// stripped and the remaining path segments become the key path, so with path "/myapp"
+// the parameter "/myapp/database/host" provides "database.host". SecureString parameters
+// are marked secret, so their values are redacted in provenance-aware output such as
+// DumpEffective even if the field has no secret tag.
+//
+// The package does not depend on the AWS SDK. Options.Client is a small interface that a
+// few lines of code adapt the SDK's ssm.Client to (see docs/configuration-sources.md), so
+// applications that don't use SSM don't pull in the SDK.
+//
+// Example:
+//
+//	source := sourcessm.New("/myapp", sourcessm.Options{
+//		Client:    ssmAdapter{client: ssm.NewFromConfig(awsCfg)},
+//		Recursive: true,
+//		Decrypt:   true,
+//	})
+//	loader := rigging.NewLoader[Config]().WithSource(source)
+package sourcessm
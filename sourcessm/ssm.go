@@ -0,0 +1,154 @@
+package sourcessm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Azhovan/rigging"
+)
+
+// secureStringType is the parameter type whose values are encrypted with KMS.
+const secureStringType = "SecureString"
+
+// Parameter is a parameter returned by GetParametersByPath.
+type Parameter struct {
+	Name  string // Full name, e.g. "/myapp/database/host"
+	Value string
+	Type  string // "String", "StringList", or "SecureString"
+}
+
+// GetParametersByPathInput requests one page of parameters under Path.
+type GetParametersByPathInput struct {
+	Path           string
+	Recursive      bool
+	WithDecryption bool
+	NextToken      string // Empty for the first page
+}
+
+// GetParametersByPathOutput is one page of parameters.
+type GetParametersByPathOutput struct {
+	Parameters []Parameter
+	NextToken  string // Empty on the last page
+}
+
+// Client fetches parameters by path, mirroring the SSM GetParametersByPath API.
+type Client interface {
+	GetParametersByPath(ctx context.Context, input GetParametersByPathInput) (GetParametersByPathOutput, error)
+}
+
+// Options configures SSM source behavior.
+type Options struct {
+	// Client performs the SSM API calls. Required.
+	Client Client
+
+	// Recursive fetches parameters at any depth below the path. Default: false (direct children only).
+	Recursive bool
+
+	// Decrypt returns SecureString values in plain text. Without it, their values are
+	// still encrypted. Either way they are marked secret.
+	Decrypt bool
+}
+
+type ssmSource struct {
+	path string
+	opts Options
+}
+
+// New creates a configuration source for the parameters under path (e.g. "/myapp").
+func New(path string, opts Options) rigging.Source {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return &ssmSource{
+		path: path,
+		opts: opts,
+	}
+}
+
+// Load fetches the parameters and returns configuration keyed by key path.
+func (s *ssmSource) Load(ctx context.Context) (map[string]any, error) {
+	data, _, _, err := s.LoadWithSecrets(ctx)
+	return data, err
+}
+
+// LoadWithKeys fetches the parameters and maps each key to its parameter as "ssm:<name>".
+func (s *ssmSource) LoadWithKeys(ctx context.Context) (map[string]any, map[string]string, error) {
+	data, originalKeys, _, err := s.LoadWithSecrets(ctx)
+	return data, originalKeys, err
+}
+
+// LoadWithSecrets behaves like LoadWithKeys and additionally reports the SecureString keys.
+func (s *ssmSource) LoadWithSecrets(ctx context.Context) (map[string]any, map[string]string, map[string]bool, error) {
+	if s.opts.Client == nil {
+		return nil, nil, nil, errors.New("sourcessm: Options.Client is required")
+	}
+
+	data := make(map[string]any)
+	originalKeys := make(map[string]string)
+	secrets := make(map[string]bool)
+
+	input := GetParametersByPathInput{
+		Path:           s.path,
+		Recursive:      s.opts.Recursive,
+		WithDecryption: s.opts.Decrypt,
+	}
+	for {
+		// Stop between pages once the deadline has passed
+		if err := ctx.Err(); err != nil {
+			return nil, nil, nil, err
+		}
+
+		output, err := s.opts.Client.GetParametersByPath(ctx, input)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("get parameters by path %s: %w", s.path, err)
+		}
+
+		for _, param := range output.Parameters {
+			key := s.keyPath(param.Name)
+			if key == "" {
+				continue
+			}
+			data[key] = param.Value
+			originalKeys[key] = "ssm:" + param.Name
+			if param.Type == secureStringType {
+				secrets[key] = true
+			}
+		}
+
+		if output.NextToken == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return data, originalKeys, secrets, nil
+}
+
+// keyPath converts a parameter name to a key path: "/myapp/database/host" → "database.host".
+func (s *ssmSource) keyPath(name string) string {
+	prefix := strings.TrimSuffix(s.path, "/") + "/"
+	if !strings.HasPrefix(name, prefix) {
+		return ""
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(name[len(prefix):], "/") {
+		if segment != "" {
+			segments = append(segments, strings.ToLower(segment))
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
+// Watch returns ErrWatchNotSupported. Wrap the source with rigging.PollSource to pick up
+// parameter changes.
+func (s *ssmSource) Watch(ctx context.Context) (<-chan rigging.ChangeEvent, error) {
+	return nil, rigging.ErrWatchNotSupported
+}
+
+// Name returns a human-readable identifier for this source.
+func (s *ssmSource) Name() string {
+	return "ssm:" + s.path
+}
@@ -0,0 +1,178 @@
+package sourcessm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Azhovan/rigging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient serves pages of parameters and records the requests it received.
+type fakeClient struct {
+	pages  []GetParametersByPathOutput
+	err    error
+	inputs []GetParametersByPathInput
+}
+
+func (c *fakeClient) GetParametersByPath(ctx context.Context, input GetParametersByPathInput) (GetParametersByPathOutput, error) {
+	c.inputs = append(c.inputs, input)
+	if c.err != nil {
+		return GetParametersByPathOutput{}, c.err
+	}
+	page := 0
+	if input.NextToken != "" {
+		page = int(input.NextToken[0] - '0')
+	}
+	return c.pages[page], nil
+}
+
+func TestSSMSource_LoadWithSecrets(t *testing.T) {
+	client := &fakeClient{pages: []GetParametersByPathOutput{
+		{
+			Parameters: []Parameter{
+				{Name: "/myapp/database/host", Value: "db.internal", Type: "String"},
+				{Name: "/myapp/database/password", Value: "hunter2", Type: "SecureString"},
+			},
+			NextToken: "1",
+		},
+		{
+			Parameters: []Parameter{
+				{Name: "/myapp/Log/Level", Value: "debug", Type: "String"},
+				{Name: "/myapp/hosts", Value: "a,b", Type: "StringList"},
+				{Name: "/other/key", Value: "ignored", Type: "String"},
+			},
+		},
+	}}
+
+	source := New("/myapp", Options{Client: client, Recursive: true, Decrypt: true})
+	data, originalKeys, secrets, err := source.(rigging.SourceWithSecrets).LoadWithSecrets(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"database.host":     "db.internal",
+		"database.password": "hunter2",
+		"log.level":         "debug",
+		"hosts":             "a,b",
+	}, data)
+	assert.Equal(t, "ssm:/myapp/database/host", originalKeys["database.host"])
+	assert.Equal(t, "ssm:/myapp/Log/Level", originalKeys["log.level"])
+	assert.Equal(t, map[string]bool{"database.password": true}, secrets)
+
+	require.Len(t, client.inputs, 2, "follows NextToken to the last page")
+	assert.Equal(t, GetParametersByPathInput{Path: "/myapp", Recursive: true, WithDecryption: true}, client.inputs[0])
+	assert.Equal(t, "1", client.inputs[1].NextToken)
+}
+
+func TestSSMSource_PathWithoutLeadingSlash(t *testing.T) {
+	client := &fakeClient{pages: []GetParametersByPathOutput{{
+		Parameters: []Parameter{{Name: "/myapp/port", Value: "8080", Type: "String"}},
+	}}}
+
+	data, err := New("myapp/", Options{Client: client}).Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"port": "8080"}, data)
+}
+
+func TestSSMSource_Errors(t *testing.T) {
+	t.Run("missing client", func(t *testing.T) {
+		_, err := New("/myapp", Options{}).Load(context.Background())
+		assert.ErrorContains(t, err, "Client is required")
+	})
+
+	t.Run("client error", func(t *testing.T) {
+		apiErr := errors.New("access denied")
+		_, err := New("/myapp", Options{Client: &fakeClient{err: apiErr}}).Load(context.Background())
+		assert.ErrorIs(t, err, apiErr)
+		assert.ErrorContains(t, err, "/myapp")
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		<-ctx.Done()
+
+		client := &fakeClient{pages: []GetParametersByPathOutput{{}}}
+		_, err := New("/myapp", Options{Client: client}).Load(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Empty(t, client.inputs, "no request after the deadline")
+	})
+}
+
+func TestSSMSource_Loader(t *testing.T) {
+	type Config struct {
+		Database struct {
+			Host     string `conf:"required"`
+			Password string
+		}
+	}
+
+	client := &fakeClient{pages: []GetParametersByPathOutput{{
+		Parameters: []Parameter{
+			{Name: "/myapp/database/host", Value: "db.internal", Type: "String"},
+			{Name: "/myapp/database/password", Value: "hunter2", Type: "SecureString"},
+		},
+	}}}
+
+	cfg, err := rigging.NewLoader[Config]().
+		WithSource(New("/myapp", Options{Client: client, Recursive: true, Decrypt: true})).
+		Strict(true).
+		Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", cfg.Database.Password)
+
+	prov, ok := rigging.GetProvenance(cfg)
+	require.True(t, ok)
+	for _, field := range prov.Fields {
+		switch field.FieldPath {
+		case "Database.Host":
+			assert.Equal(t, "ssm:/myapp/database/host", field.SourceName)
+			assert.False(t, field.Secret)
+		case "Database.Password":
+			assert.Equal(t, "ssm:/myapp/database/password", field.SourceName)
+			assert.True(t, field.Secret, "SecureString values are secret without a secret tag")
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, rigging.DumpEffective(&buf, cfg))
+	assert.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestSSMSource_LoaderWrapped(t *testing.T) {
+	type Config struct {
+		Token string
+	}
+
+	client := &fakeClient{pages: []GetParametersByPathOutput{{
+		Parameters: []Parameter{
+			{Name: "/app/token", Value: "s3cr3t", Type: "SecureString"},
+		},
+	}}}
+
+	source := rigging.RetrySource(New("/app", Options{Client: client, Decrypt: true}), rigging.RetryOptions{})
+	cfg, err := rigging.NewLoader[Config]().WithSource(source).Load(context.Background())
+	require.NoError(t, err)
+
+	prov, ok := rigging.GetProvenance(cfg)
+	require.True(t, ok)
+	require.Len(t, prov.Fields, 1)
+	assert.Equal(t, "ssm:/app/token", prov.Fields[0].SourceName, "provenance keeps the parameter name through wrappers")
+	assert.True(t, prov.Fields[0].Secret)
+
+	var buf bytes.Buffer
+	require.NoError(t, rigging.DumpEffective(&buf, cfg))
+	assert.NotContains(t, buf.String(), "s3cr3t")
+}
+
+func TestSSMSource_WatchAndName(t *testing.T) {
+	source := New("/myapp", Options{})
+
+	_, err := source.Watch(context.Background())
+	assert.ErrorIs(t, err, rigging.ErrWatchNotSupported)
+	assert.Equal(t, "ssm:/myapp", source.Name())
+}
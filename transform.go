@@ -0,0 +1,112 @@
+package rigging
+
+import (
+	"context"
+	"sort"
+)
+
+// TransformFunc rewrites a single source entry. It returns the new key and value,
+// and false to drop the entry.
+type TransformFunc func(key string, val any) (string, any, bool)
+
+type transformSource struct {
+	inner Source
+	fn    TransformFunc
+}
+
+// TransformSource wraps a source and rewrites its entries with fn before they are merged,
+// e.g. to strip a legacy prefix or rename keys. Returning false from fn drops the entry.
+// Provenance keeps the original key of each entry as reported by the inner source
+// (or the pre-transform key if the inner source doesn't track original keys).
+// Lines and secret markings from the inner source follow each entry to its new key.
+// When several entries map to the same key, the one with the greatest original key wins.
+// Watch and Name are forwarded to the inner source.
+func TransformSource(inner Source, fn TransformFunc) Source {
+	return &transformSource{inner: inner, fn: fn}
+}
+
+// Load returns the inner source's data with fn applied to every entry.
+func (t *transformSource) Load(ctx context.Context) (map[string]any, error) {
+	loaded, err := t.loadAll(ctx)
+	return loaded.data, err
+}
+
+// LoadWithKeys returns the transformed data and maps each new key to its original key.
+func (t *transformSource) LoadWithKeys(ctx context.Context) (map[string]any, map[string]string, error) {
+	loaded, err := t.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, err
+}
+
+// LoadWithPositions returns the transformed data and the inner source's line of each new key.
+func (t *transformSource) LoadWithPositions(ctx context.Context) (map[string]any, map[string]string, map[string]int, error) {
+	loaded, err := t.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, loaded.lines, err
+}
+
+// LoadWithSecrets returns the transformed data and the new keys whose values the inner source marked secret.
+func (t *transformSource) LoadWithSecrets(ctx context.Context) (map[string]any, map[string]string, map[string]bool, error) {
+	loaded, err := t.loadAll(ctx)
+	return loaded.data, loaded.originalKeys, loaded.secrets, err
+}
+
+// loadAll loads the inner source and applies fn to every entry.
+func (t *transformSource) loadAll(ctx context.Context) (loadedSource, error) {
+	loaded, err := loadFrom(ctx, t.inner)
+	if err != nil {
+		return loadedSource{}, err
+	}
+
+	// Apply in key order so collisions resolve deterministically
+	keys := make([]string, 0, len(loaded.data))
+	for key := range loaded.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := loadedSource{
+		data:         make(map[string]any, len(loaded.data)),
+		originalKeys: make(map[string]string, len(loaded.data)),
+	}
+	for _, key := range keys {
+		newKey, newVal, keep := t.fn(key, loaded.data[key])
+		if !keep {
+			continue
+		}
+
+		original := key
+		if orig, ok := loaded.originalKeys[key]; ok {
+			original = orig
+		}
+
+		result.data[newKey] = newVal
+		result.originalKeys[newKey] = original
+
+		// Positions and secret markings follow the entry to its new key
+		delete(result.lines, newKey)
+		if line, ok := loaded.lines[key]; ok {
+			if result.lines == nil {
+				result.lines = make(map[string]int)
+			}
+			result.lines[newKey] = line
+		}
+		delete(result.secrets, newKey)
+		if loaded.secrets[key] {
+			if result.secrets == nil {
+				result.secrets = make(map[string]bool)
+			}
+			result.secrets[newKey] = true
+		}
+	}
+
+	return result, nil
+}
+
+// Watch forwards to the inner source.
+func (t *transformSource) Watch(ctx context.Context) (<-chan ChangeEvent, error) {
+	return t.inner.Watch(ctx)
+}
+
+// Name forwards to the inner source.
+func (t *transformSource) Name() string {
+	return t.inner.Name()
+}
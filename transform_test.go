@@ -0,0 +1,123 @@
+package rigging
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTransformSource_RewritesAndDropsKeys(t *testing.T) {
+	inner := &mockSource{name: "legacy", data: map[string]any{
+		"legacy.host":  "localhost",
+		"legacy.port":  "8080",
+		"internal.tmp": "x",
+	}}
+
+	src := TransformSource(inner, func(key string, val any) (string, any, bool) {
+		if !strings.HasPrefix(key, "legacy.") {
+			return "", nil, false
+		}
+		return strings.TrimPrefix(key, "legacy."), val, true
+	})
+
+	data, err := src.Load(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]any{"host": "localhost", "port": "8080"}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Load() = %v, want %v", data, want)
+	}
+	if src.Name() != "legacy" {
+		t.Errorf("Name() = %q, want %q", src.Name(), "legacy")
+	}
+}
+
+func TestTransformSource_RewritesValues(t *testing.T) {
+	inner := &mockSource{data: map[string]any{"mode": "PROD"}}
+	src := TransformSource(inner, func(key string, val any) (string, any, bool) {
+		if s, ok := val.(string); ok {
+			return key, strings.ToLower(s), true
+		}
+		return key, val, true
+	})
+
+	data, _ := src.Load(context.Background())
+	if data["mode"] != "prod" {
+		t.Errorf("mode = %v, want prod", data["mode"])
+	}
+}
+
+func TestTransformSource_PreservesOriginalKeys(t *testing.T) {
+	type Config struct {
+		Database struct {
+			Password string `conf:"secret"`
+		}
+	}
+
+	inner := &mockSourceWithKeys{
+		name:         "env:LEGACY_",
+		data:         map[string]any{"db_password": "s3cret"},
+		originalKeys: map[string]string{"db_password": "LEGACY_DB_PASSWORD"},
+	}
+	src := TransformSource(inner, func(key string, val any) (string, any, bool) {
+		return strings.Replace(key, "db_", "database.", 1), val, true
+	})
+
+	_, originalKeys, err := src.(SourceWithKeys).LoadWithKeys(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if originalKeys["database.password"] != "LEGACY_DB_PASSWORD" {
+		t.Errorf("original key = %q, want LEGACY_DB_PASSWORD", originalKeys["database.password"])
+	}
+
+	cfg, err := NewLoader[Config]().WithSource(src).Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	prov, _ := GetProvenance(cfg)
+	if len(prov.Fields) != 1 || prov.Fields[0].SourceName != "env:LEGACY_DB_PASSWORD" {
+		t.Errorf("unexpected provenance: %+v", prov.Fields)
+	}
+}
+
+func TestTransformSource_PropagatesErrors(t *testing.T) {
+	innerErr := errors.New("unavailable")
+	src := TransformSource(&mockSource{err: innerErr}, func(key string, val any) (string, any, bool) {
+		return key, val, true
+	})
+
+	if _, err := src.Load(context.Background()); !errors.Is(err, innerErr) {
+		t.Errorf("expected inner error, got %v", err)
+	}
+}
+
+func TestTransformSource_ForwardsPositionsAndSecrets(t *testing.T) {
+	testWrapperForwards(t, func(inner Source) Source {
+		return TransformSource(inner, func(key string, val any) (string, any, bool) {
+			return key, val, true
+		})
+	})
+}
+
+func TestTransformSource_RenamedKeysKeepSecrets(t *testing.T) {
+	inner := &mockSourceWithSecrets{
+		mockSourceWithKeys: mockSourceWithKeys{name: "vault", data: map[string]any{"legacy.token": "s3cr3t"}},
+		secrets:            map[string]bool{"legacy.token": true},
+	}
+	src := TransformSource(inner, func(key string, val any) (string, any, bool) {
+		return strings.TrimPrefix(key, "legacy."), val, true
+	})
+
+	_, _, secrets, err := src.(SourceWithSecrets).LoadWithSecrets(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(secrets, map[string]bool{"token": true}) {
+		t.Errorf("secrets = %v, want token", secrets)
+	}
+}
@@ -25,9 +25,50 @@ type SourceWithKeys interface {
 	Source
 	// LoadWithKeys returns configuration with original keys mapped to normalized keys.
 	// The returned map has normalized keys, and originalKeys maps normalized -> original.
+	// An original key of the form "<scheme>:<location>" (e.g. "ssm:/myapp/database/host")
+	// is recorded in provenance as is.
 	LoadWithKeys(ctx context.Context) (data map[string]any, originalKeys map[string]string, err error)
 }
 
+// SourceWithPositions is an optional interface that sources can implement to report
+// where each key is defined, recorded as FieldProvenance.Line.
+type SourceWithPositions interface {
+	SourceWithKeys
+	// LoadWithPositions behaves like LoadWithKeys and additionally returns the 1-based
+	// line of each key in data. Keys without a known line may be omitted.
+	LoadWithPositions(ctx context.Context) (data map[string]any, originalKeys map[string]string, lines map[string]int, err error)
+}
+
+// SourceWithSecrets is an optional interface that sources can implement to mark keys
+// whose values are secret regardless of the field's secret tag (e.g. files from a
+// Kubernetes Secret mount). Fields bound from those keys are recorded with
+// FieldProvenance.Secret set, so dumps, snapshots, and Lookup redact them.
+type SourceWithSecrets interface {
+	SourceWithKeys
+	// LoadWithSecrets behaves like LoadWithKeys and additionally returns the keys in data
+	// whose values are secret.
+	LoadWithSecrets(ctx context.Context) (data map[string]any, originalKeys map[string]string, secrets map[string]bool, err error)
+}
+
+// SourceOption configures how a source is registered with a Loader.
+type SourceOption func(*sourceConfig)
+
+// sourceConfig holds per-source settings.
+type sourceConfig struct {
+	tag      string // Layer label recorded in provenance
+	priority int    // Higher priority overrides lower (see WithSourceAt)
+	lenient  bool   // Keys only this kind of source provides are exempt from strict mode (see WithLenientSource)
+	override bool   // A DerivedSource may replace keys set by other sources (see WithDerivedOverride)
+}
+
+// WithTag labels a source with a layer name (e.g., "base", "secrets").
+// The tag is recorded in FieldProvenance.Layer for fields the source provides.
+func WithTag(tag string) SourceOption {
+	return func(cfg *sourceConfig) {
+		cfg.tag = tag
+	}
+}
+
 // ChangeEvent notifies of configuration changes.
 type ChangeEvent struct {
 	At    time.Time
@@ -38,6 +79,8 @@ type ChangeEvent struct {
 var ErrWatchNotSupported = errors.New("rigging: watch not supported by this source")
 
 // Optional distinguishes "not set" from "zero value".
+// With the required tag, some source or a default must supply a value, which may be the zero value;
+// without it, an Optional may stay unset.
 type Optional[T any] struct {
 	Value T
 	Set   bool
@@ -56,6 +99,29 @@ func (o Optional[T]) OrDefault(defaultVal T) T {
 	return defaultVal
 }
 
+// OrElse returns the wrapped value, or the result of fn if not set.
+// fn is only called when needed, for defaults that are expensive to compute.
+func (o Optional[T]) OrElse(fn func() T) T {
+	if o.Set {
+		return o.Value
+	}
+	return fn()
+}
+
+// IsSet reports whether the value was set.
+func (o Optional[T]) IsSet() bool {
+	return o.Set
+}
+
+// MapOptional applies fn to the wrapped value, preserving whether it was set.
+// fn is not called for an unset Optional.
+func MapOptional[T, U any](o Optional[T], fn func(T) U) Optional[U] {
+	if !o.Set {
+		return Optional[U]{}
+	}
+	return Optional[U]{Value: fn(o.Value), Set: true}
+}
+
 // Validator performs custom validation after tag-based validation.
 // Use for cross-field, semantic, or external validation.
 type Validator[T any] interface {
@@ -70,10 +136,42 @@ func (f ValidatorFunc[T]) Validate(ctx context.Context, cfg *T) error {
 	return f(ctx, cfg)
 }
 
+// ValidationMode controls whether Load reports every field error or only the first one.
+type ValidationMode int
+
+const (
+	// CollectAll reports every binding and validation error at once (default).
+	CollectAll ValidationMode = iota
+
+	// FailFast returns the first field error without running the remaining checks.
+	FailFast
+)
+
+// MissingRefPolicy controls how WithInterpolation handles a ${key} reference to a key
+// that no layer provides.
+type MissingRefPolicy int
+
+const (
+	// MissingRefError fails Load with a config_schema error for the referencing key.
+	MissingRefError MissingRefPolicy = iota
+
+	// MissingRefEmpty replaces the reference with an empty string.
+	MissingRefEmpty
+
+	// MissingRefKeep leaves the reference in the value as written.
+	MissingRefKeep
+)
+
 // Snapshot represents a configuration version emitted by Watch().
 type Snapshot[T any] struct {
 	Config   *T
 	Version  int64 // Increments on reload (starts at 1)
 	LoadedAt time.Time
-	Source   string // What triggered the load
+	Source   string      // What triggered the load
+	Diff     *ConfigDiff // Changes since the previous snapshot (nil unless WithReloadDiff is enabled)
+
+	// ChangedKeys lists the sorted key paths whose values differ from the previous snapshot
+	// (added, removed, or modified). Values are not included, so it is safe for secrets.
+	// It is nil for the initial snapshot.
+	ChangedKeys []string
 }
@@ -0,0 +1,90 @@
+package rigging
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestOptional_OrElse(t *testing.T) {
+	calls := 0
+	fallback := func() int {
+		calls++
+		return 3
+	}
+
+	if got := (Optional[int]{Value: 5, Set: true}).OrElse(fallback); got != 5 {
+		t.Errorf("OrElse() on set value = %d, want 5", got)
+	}
+	if calls != 0 {
+		t.Errorf("fallback called %d times for a set value", calls)
+	}
+
+	if got := (Optional[int]{}).OrElse(fallback); got != 3 {
+		t.Errorf("OrElse() on unset value = %d, want 3", got)
+	}
+	if calls != 1 {
+		t.Errorf("fallback called %d times, want 1", calls)
+	}
+}
+
+func TestOptional_IsSet(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Optional[string]
+		want bool
+	}{
+		{"unset", Optional[string]{}, false},
+		{"set to zero value", Optional[string]{Set: true}, true},
+		{"set", Optional[string]{Value: "x", Set: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.opt.IsSet(); got != tt.want {
+				t.Errorf("IsSet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapOptional(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Optional[int]
+		want Optional[string]
+	}{
+		{"unset", Optional[int]{Value: 7}, Optional[string]{}},
+		{"set to zero value", Optional[int]{Set: true}, Optional[string]{Value: "0", Set: true}},
+		{"set", Optional[int]{Value: 42, Set: true}, Optional[string]{Value: "42", Set: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MapOptional(tt.opt, strconv.Itoa); got != tt.want {
+				t.Errorf("MapOptional() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("fn not called when unset", func(t *testing.T) {
+		MapOptional(Optional[int]{}, func(int) int {
+			t.Error("fn called for unset Optional")
+			return 0
+		})
+	})
+}
+
+func TestOptional_HelpersDoNotAllocate(t *testing.T) {
+	opt := Optional[int]{Value: 1, Set: true}
+	double := func(v int) int { return v * 2 }
+	zero := func() int { return 0 }
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = opt.IsSet()
+		_ = opt.OrElse(zero)
+		_ = MapOptional(opt, double)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
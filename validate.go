@@ -5,10 +5,11 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // validateField validates a single field value against tag-based constraints.
-// It checks required, min, max, and oneof constraints based on the field's type.
+// It checks required, min, max, gt, lt, and oneof constraints based on the field's type.
 // Returns a slice of FieldError for any validation failures.
 func validateField(fieldValue reflect.Value, fieldPath string, tags tagConfig) []FieldError {
 	var errors []FieldError
@@ -26,6 +27,11 @@ func validateField(fieldValue reflect.Value, fieldPath string, tags tagConfig) [
 		}
 	}
 
+	// Check required map keys (an absent or empty map is missing all of them)
+	if len(tags.reqKeys) > 0 && fieldValue.Kind() == reflect.Map {
+		errors = append(errors, validateRequiredKeys(fieldValue, fieldPath, tags)...)
+	}
+
 	// Skip other validations if value is zero (for non-required fields)
 	if isZeroValue(fieldValue) {
 		return errors
@@ -33,7 +39,13 @@ func validateField(fieldValue reflect.Value, fieldPath string, tags tagConfig) [
 
 	// Validate min/max constraints based on type
 	switch fieldValue.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	case reflect.Int64:
+		if fieldValue.Type() == reflect.TypeOf(time.Duration(0)) {
+			errors = append(errors, validateDurationMinMax(fieldValue, fieldPath, tags)...)
+		} else {
+			errors = append(errors, validateIntMinMax(fieldValue, fieldPath, tags)...)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
 		errors = append(errors, validateIntMinMax(fieldValue, fieldPath, tags)...)
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		errors = append(errors, validateUintMinMax(fieldValue, fieldPath, tags)...)
@@ -41,6 +53,12 @@ func validateField(fieldValue reflect.Value, fieldPath string, tags tagConfig) [
 		errors = append(errors, validateFloatMinMax(fieldValue, fieldPath, tags)...)
 	case reflect.String:
 		errors = append(errors, validateStringMinMax(fieldValue, fieldPath, tags)...)
+	case reflect.Map:
+		errors = append(errors, validateMapMinMax(fieldValue, fieldPath, tags)...)
+	case reflect.Struct:
+		if fieldValue.Type() == reflect.TypeOf(time.Time{}) {
+			errors = append(errors, validateTimeMinMax(fieldValue, fieldPath, tags)...)
+		}
 	}
 
 	// Validate oneof constraint
@@ -48,18 +66,86 @@ func validateField(fieldValue reflect.Value, fieldPath string, tags tagConfig) [
 		errors = append(errors, validateOneof(fieldValue, fieldPath, tags)...)
 	}
 
+	// Enum-like types that know their valid values
+	errors = append(errors, validateValidMethod(fieldValue, fieldPath, tags)...)
+
 	return errors
 }
 
+// validChecker is implemented by enum-like types that report whether a value is one of theirs.
+type validChecker interface {
+	Valid() bool
+}
+
+// validateValidMethod reports ErrCodeOneOf when the field's type implements Valid() bool
+// (on the value or pointer receiver) and the value is not valid.
+func validateValidMethod(fieldValue reflect.Value, fieldPath string, tags tagConfig) []FieldError {
+	var checker validChecker
+	if c, ok := fieldValue.Interface().(validChecker); ok {
+		checker = c
+	} else if fieldValue.CanAddr() {
+		if c, ok := fieldValue.Addr().Interface().(validChecker); ok {
+			checker = c
+		}
+	}
+	if checker == nil || checker.Valid() {
+		return nil
+	}
+
+	// fmt uses the type's String method, if any, so enums are shown by name
+	return []FieldError{{
+		FieldPath: fieldPath,
+		Code:      ErrCodeOneOf,
+		Message:   fmt.Sprintf("value %s is not a valid %s", displayValue(tags, fmt.Sprintf("%q", fmt.Sprint(fieldValue.Interface()))), fieldValue.Type()),
+	}}
+}
+
+// displayValue formats a field value for an error message.
+// Values of secret fields are replaced with "***redacted***".
+func displayValue(tags tagConfig, value any) string {
+	if tags.secret {
+		return "***redacted***"
+	}
+	return fmt.Sprint(value)
+}
+
 // validateStruct walks a struct and validates all fields according to their tags.
+// bound holds the fields that were bound from a source or default, by field path; such fields satisfy
+// `required` even when their value is the zero value (e.g. port 0). With a nil bound,
+// required fields must be non-zero.
 // It recursively validates nested structs.
-// Returns a slice of all FieldError encountered.
-func validateStruct(cfg reflect.Value) []FieldError {
-	return validateStructRecursive(cfg, "")
+// Returns a slice of all FieldError encountered, or only those of the first invalid field with FailFast.
+func validateStruct(cfg reflect.Value, bound map[string]boundField, mode ValidationMode) []FieldError {
+	return validateStructRecursive(cfg, "", bound, mode)
+}
+
+// boundField describes a field that was bound from a source or default.
+type boundField struct {
+	secret bool // Source marked the value as secret (see SourceWithSecrets)
+}
+
+// boundFields returns the fields recorded in prov by field path, for use as validateStruct's bound.
+func boundFields(prov []FieldProvenance) map[string]boundField {
+	bound := make(map[string]boundField, len(prov))
+	for _, field := range prov {
+		bound[field.FieldPath] = boundField{secret: field.Secret}
+	}
+	return bound
 }
 
 // validateStructRecursive is the internal recursive implementation of validateStruct.
-func validateStructRecursive(cfg reflect.Value, parentFieldPath string) []FieldError {
+// Groups (group, required-group) are scoped to a single struct, including its embedded structs.
+func validateStructRecursive(cfg reflect.Value, parentFieldPath string, bound map[string]boundField, mode ValidationMode) []FieldError {
+	groups := &fieldGroups{}
+	fieldErrors := validateStructFields(cfg, parentFieldPath, groups, bound, mode)
+	if mode == FailFast && len(fieldErrors) > 0 {
+		return fieldErrors
+	}
+	return append(fieldErrors, groups.validate()...)
+}
+
+// validateStructFields validates the fields of a struct and records group membership in groups.
+func validateStructFields(cfg reflect.Value, parentFieldPath string, groups *fieldGroups, bound map[string]boundField, mode ValidationMode) []FieldError {
 	var fieldErrors []FieldError
 
 	// Dereference pointer if needed
@@ -79,9 +165,24 @@ func validateStructRecursive(cfg reflect.Value, parentFieldPath string) []FieldE
 
 	// Walk through all fields
 	for i := 0; i < cfg.NumField(); i++ {
+		if mode == FailFast && len(fieldErrors) > 0 {
+			break
+		}
+
 		field := cfgType.Field(i)
 		fieldValue := cfg.Field(i)
 
+		// Parse struct tag
+		tag := field.Tag.Get("conf")
+		tagCfg := parseTag(tag)
+
+		// Embedded structs are validated with promoted field paths and share the parent's groups
+		if isPromotedStruct(field, tagCfg) {
+			nestedErrors := validateStructFields(fieldValue, parentFieldPath, groups, bound, mode)
+			fieldErrors = append(fieldErrors, nestedErrors...)
+			continue
+		}
+
 		// Skip unexported fields
 		if !field.IsExported() {
 			continue
@@ -93,22 +194,54 @@ func validateStructRecursive(cfg reflect.Value, parentFieldPath string) []FieldE
 			fieldPath = parentFieldPath + "." + field.Name
 		}
 
-		// Parse struct tag
-		tag := field.Tag.Get("conf")
-		tagCfg := parseTag(tag)
+		// Record group membership
+		groups.add(tagCfg, fieldPath, !isZeroValue(fieldValue))
 
-		// Handle Optional[T] types - validate the inner value if set
+		// A field bound from a source or default satisfies required, even with a zero value.
+		// Values a source marked as secret are redacted like secret-tagged ones.
+		if b, ok := bound[fieldPath]; ok {
+			tagCfg.required = false
+			tagCfg.secret = tagCfg.secret || b.secret
+		}
+
+		// Handle Optional[T] types - validate the inner value if set.
+		// For an Optional, required means some source (or a default) supplied a value;
+		// a supplied zero value such as 0 or "" satisfies it.
 		if isOptionalType(fieldValue.Type()) {
 			setField := fieldValue.Field(1) // Set field
-			if setField.Bool() {
-				valueField := fieldValue.Field(0) // Value field
-				// Validate the inner value
+			if !setField.Bool() {
+				if tagCfg.required {
+					fieldErrors = append(fieldErrors, FieldError{
+						FieldPath: fieldPath,
+						Code:      ErrCodeRequired,
+						Message:   "field is required but not provided",
+					})
+				}
+				continue
+			}
+
+			valueField := fieldValue.Field(0) // Value field
+			// Validate the inner value (recursively for Optional[Struct])
+			if isOptionalStruct(fieldValue.Type()) {
+				fieldErrors = append(fieldErrors, validateStructRecursive(valueField, fieldPath, bound, mode)...)
+			} else {
+				tagCfg.required = false // Presence was checked above
 				errors := validateField(valueField, fieldPath, tagCfg)
 				fieldErrors = append(fieldErrors, errors...)
 			}
 			continue
 		}
 
+		// Handle *Struct - validate the struct if allocated, otherwise only check `required`
+		if isStructPointer(fieldValue.Type()) {
+			if fieldValue.IsNil() {
+				fieldErrors = append(fieldErrors, validateField(fieldValue, fieldPath, tagCfg)...)
+			} else {
+				fieldErrors = append(fieldErrors, validateStructRecursive(fieldValue.Elem(), fieldPath, bound, mode)...)
+			}
+			continue
+		}
+
 		// Handle nested structs recursively
 		if fieldValue.Kind() == reflect.Struct {
 			// Skip time.Time and time.Duration (they're structs but should be treated as primitives)
@@ -120,7 +253,7 @@ func validateStructRecursive(cfg reflect.Value, parentFieldPath string) []FieldE
 			}
 
 			// Recursively validate nested struct
-			nestedErrors := validateStructRecursive(fieldValue, fieldPath)
+			nestedErrors := validateStructRecursive(fieldValue, fieldPath, bound, mode)
 			fieldErrors = append(fieldErrors, nestedErrors...)
 			continue
 		}
@@ -133,6 +266,78 @@ func validateStructRecursive(cfg reflect.Value, parentFieldPath string) []FieldE
 	return fieldErrors
 }
 
+// fieldGroup tracks the fields of one group directive within a struct.
+type fieldGroup struct {
+	name    string
+	members []string // Field paths in the group
+	set     []string // Field paths with a non-zero value
+}
+
+// fieldGroups collects exclusive and required groups in declaration order.
+type fieldGroups struct {
+	exclusive []*fieldGroup
+	required  []*fieldGroup
+}
+
+// add records a field in the groups named by its tags.
+func (g *fieldGroups) add(tags tagConfig, fieldPath string, isSet bool) {
+	if tags.group != "" {
+		g.exclusive = addGroupMember(g.exclusive, tags.group, fieldPath, isSet)
+	}
+	if tags.reqGroup != "" {
+		g.required = addGroupMember(g.required, tags.reqGroup, fieldPath, isSet)
+	}
+}
+
+// validate reports exclusive groups with more than one field set
+// and required groups with no field set.
+func (g *fieldGroups) validate() []FieldError {
+	var errors []FieldError
+
+	for _, group := range g.exclusive {
+		if len(group.set) > 1 {
+			errors = append(errors, FieldError{
+				FieldPath: group.set[0],
+				Code:      ErrCodeExclusiveGroup,
+				Message:   fmt.Sprintf("only one of %s may be set (group %q), got %s", strings.Join(group.members, ", "), group.name, strings.Join(group.set, ", ")),
+			})
+		}
+	}
+
+	for _, group := range g.required {
+		if len(group.set) == 0 {
+			errors = append(errors, FieldError{
+				FieldPath: group.members[0],
+				Code:      ErrCodeRequiredGroup,
+				Message:   fmt.Sprintf("one of %s must be set (group %q)", strings.Join(group.members, ", "), group.name),
+			})
+		}
+	}
+
+	return errors
+}
+
+// addGroupMember adds a field to the named group, creating the group if needed.
+func addGroupMember(groups []*fieldGroup, name, fieldPath string, isSet bool) []*fieldGroup {
+	var group *fieldGroup
+	for _, g := range groups {
+		if g.name == name {
+			group = g
+			break
+		}
+	}
+	if group == nil {
+		group = &fieldGroup{name: name}
+		groups = append(groups, group)
+	}
+
+	group.members = append(group.members, fieldPath)
+	if isSet {
+		group.set = append(group.set, fieldPath)
+	}
+	return groups
+}
+
 // isZeroValue checks if a reflect.Value is the zero value for its type.
 func isZeroValue(v reflect.Value) bool {
 	switch v.Kind() {
@@ -156,7 +361,7 @@ func isZeroValue(v reflect.Value) bool {
 	}
 }
 
-// validateIntMinMax validates min/max constraints for signed integer types.
+// validateIntMinMax validates min/max and gt/lt constraints for signed integer types.
 func validateIntMinMax(fieldValue reflect.Value, fieldPath string, tags tagConfig) []FieldError {
 	var errors []FieldError
 	value := fieldValue.Int()
@@ -167,7 +372,7 @@ func validateIntMinMax(fieldValue reflect.Value, fieldPath string, tags tagConfi
 			errors = append(errors, FieldError{
 				FieldPath: fieldPath,
 				Code:      ErrCodeMin,
-				Message:   fmt.Sprintf("value %d is below minimum %d", value, minVal),
+				Message:   fmt.Sprintf("value %s is below minimum %d", displayValue(tags, value), minVal),
 			})
 		}
 	}
@@ -178,7 +383,29 @@ func validateIntMinMax(fieldValue reflect.Value, fieldPath string, tags tagConfi
 			errors = append(errors, FieldError{
 				FieldPath: fieldPath,
 				Code:      ErrCodeMax,
-				Message:   fmt.Sprintf("value %d exceeds maximum %d", value, maxVal),
+				Message:   fmt.Sprintf("value %s exceeds maximum %d", displayValue(tags, value), maxVal),
+			})
+		}
+	}
+
+	if tags.gt != "" {
+		gtVal, err := strconv.ParseInt(tags.gt, 10, 64)
+		if err == nil && value <= gtVal {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeGt,
+				Message:   fmt.Sprintf("value %s must be greater than %d", displayValue(tags, value), gtVal),
+			})
+		}
+	}
+
+	if tags.lt != "" {
+		ltVal, err := strconv.ParseInt(tags.lt, 10, 64)
+		if err == nil && value >= ltVal {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeLt,
+				Message:   fmt.Sprintf("value %s must be less than %d", displayValue(tags, value), ltVal),
 			})
 		}
 	}
@@ -186,7 +413,122 @@ func validateIntMinMax(fieldValue reflect.Value, fieldPath string, tags tagConfi
 	return errors
 }
 
-// validateUintMinMax validates min/max constraints for unsigned integer types.
+// validateDurationMinMax validates min/max and gt/lt constraints for time.Duration fields.
+// Bounds are durations such as "1s" or "5m"; bare integers are nanoseconds.
+func validateDurationMinMax(fieldValue reflect.Value, fieldPath string, tags tagConfig) []FieldError {
+	var errors []FieldError
+	value := time.Duration(fieldValue.Int())
+
+	if tags.min != "" {
+		minVal, err := parseDurationBound(tags.min)
+		if err == nil && value < minVal {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeMin,
+				Message:   fmt.Sprintf("duration %s is below minimum %s", displayValue(tags, value), minVal),
+			})
+		}
+	}
+
+	if tags.max != "" {
+		maxVal, err := parseDurationBound(tags.max)
+		if err == nil && value > maxVal {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeMax,
+				Message:   fmt.Sprintf("duration %s exceeds maximum %s", displayValue(tags, value), maxVal),
+			})
+		}
+	}
+
+	if tags.gt != "" {
+		gtVal, err := parseDurationBound(tags.gt)
+		if err == nil && value <= gtVal {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeGt,
+				Message:   fmt.Sprintf("duration %s must be greater than %s", displayValue(tags, value), gtVal),
+			})
+		}
+	}
+
+	if tags.lt != "" {
+		ltVal, err := parseDurationBound(tags.lt)
+		if err == nil && value >= ltVal {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeLt,
+				Message:   fmt.Sprintf("duration %s must be less than %s", displayValue(tags, value), ltVal),
+			})
+		}
+	}
+
+	return errors
+}
+
+// parseDurationBound parses a min/max bound for a duration field.
+func parseDurationBound(s string) (time.Duration, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	return time.Duration(n), err
+}
+
+// validateTimeMinMax validates min/max constraints for time.Time fields.
+// Bounds use the formats accepted for time.Time values (e.g. RFC3339 or "2006-01-02"),
+// or are relative to the current time: "now", "now+1h", "now-24h".
+func validateTimeMinMax(fieldValue reflect.Value, fieldPath string, tags tagConfig) []FieldError {
+	var errors []FieldError
+	value := fieldValue.Interface().(time.Time)
+
+	if tags.min != "" {
+		minVal, err := parseTimeBound(tags.min)
+		if err == nil && value.Before(minVal) {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeMin,
+				Message:   fmt.Sprintf("time %s is before minimum %s", displayValue(tags, value.Format(time.RFC3339)), minVal.Format(time.RFC3339)),
+			})
+		}
+	}
+
+	if tags.max != "" {
+		maxVal, err := parseTimeBound(tags.max)
+		if err == nil && value.After(maxVal) {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeMax,
+				Message:   fmt.Sprintf("time %s is after maximum %s", displayValue(tags, value.Format(time.RFC3339)), maxVal.Format(time.RFC3339)),
+			})
+		}
+	}
+
+	return errors
+}
+
+// parseTimeBound parses a min/max bound for a time.Time field.
+func parseTimeBound(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, "now"); ok {
+		if rest == "" {
+			return time.Now(), nil
+		}
+		offset, err := time.ParseDuration(rest)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Now().Add(offset), nil
+	}
+
+	t, err := convertValue(s, reflect.TypeOf(time.Time{}))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.(time.Time), nil
+}
+
+// validateUintMinMax validates min/max and gt/lt constraints for unsigned integer types.
 func validateUintMinMax(fieldValue reflect.Value, fieldPath string, tags tagConfig) []FieldError {
 	var errors []FieldError
 	value := fieldValue.Uint()
@@ -197,7 +539,7 @@ func validateUintMinMax(fieldValue reflect.Value, fieldPath string, tags tagConf
 			errors = append(errors, FieldError{
 				FieldPath: fieldPath,
 				Code:      ErrCodeMin,
-				Message:   fmt.Sprintf("value %d is below minimum %d", value, minVal),
+				Message:   fmt.Sprintf("value %s is below minimum %d", displayValue(tags, value), minVal),
 			})
 		}
 	}
@@ -208,7 +550,29 @@ func validateUintMinMax(fieldValue reflect.Value, fieldPath string, tags tagConf
 			errors = append(errors, FieldError{
 				FieldPath: fieldPath,
 				Code:      ErrCodeMax,
-				Message:   fmt.Sprintf("value %d exceeds maximum %d", value, maxVal),
+				Message:   fmt.Sprintf("value %s exceeds maximum %d", displayValue(tags, value), maxVal),
+			})
+		}
+	}
+
+	if tags.gt != "" {
+		gtVal, err := strconv.ParseUint(tags.gt, 10, 64)
+		if err == nil && value <= gtVal {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeGt,
+				Message:   fmt.Sprintf("value %s must be greater than %d", displayValue(tags, value), gtVal),
+			})
+		}
+	}
+
+	if tags.lt != "" {
+		ltVal, err := strconv.ParseUint(tags.lt, 10, 64)
+		if err == nil && value >= ltVal {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeLt,
+				Message:   fmt.Sprintf("value %s must be less than %d", displayValue(tags, value), ltVal),
 			})
 		}
 	}
@@ -216,7 +580,7 @@ func validateUintMinMax(fieldValue reflect.Value, fieldPath string, tags tagConf
 	return errors
 }
 
-// validateFloatMinMax validates min/max constraints for floating-point types.
+// validateFloatMinMax validates min/max and gt/lt constraints for floating-point types.
 func validateFloatMinMax(fieldValue reflect.Value, fieldPath string, tags tagConfig) []FieldError {
 	var errors []FieldError
 	value := fieldValue.Float()
@@ -227,7 +591,7 @@ func validateFloatMinMax(fieldValue reflect.Value, fieldPath string, tags tagCon
 			errors = append(errors, FieldError{
 				FieldPath: fieldPath,
 				Code:      ErrCodeMin,
-				Message:   fmt.Sprintf("value %g is below minimum %g", value, minVal),
+				Message:   fmt.Sprintf("value %s is below minimum %g", displayValue(tags, value), minVal),
 			})
 		}
 	}
@@ -238,7 +602,29 @@ func validateFloatMinMax(fieldValue reflect.Value, fieldPath string, tags tagCon
 			errors = append(errors, FieldError{
 				FieldPath: fieldPath,
 				Code:      ErrCodeMax,
-				Message:   fmt.Sprintf("value %g exceeds maximum %g", value, maxVal),
+				Message:   fmt.Sprintf("value %s exceeds maximum %g", displayValue(tags, value), maxVal),
+			})
+		}
+	}
+
+	if tags.gt != "" {
+		gtVal, err := strconv.ParseFloat(tags.gt, 64)
+		if err == nil && value <= gtVal {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeGt,
+				Message:   fmt.Sprintf("value %s must be greater than %g", displayValue(tags, value), gtVal),
+			})
+		}
+	}
+
+	if tags.lt != "" {
+		ltVal, err := strconv.ParseFloat(tags.lt, 64)
+		if err == nil && value >= ltVal {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeLt,
+				Message:   fmt.Sprintf("value %s must be less than %g", displayValue(tags, value), ltVal),
 			})
 		}
 	}
@@ -277,13 +663,72 @@ func validateStringMinMax(fieldValue reflect.Value, fieldPath string, tags tagCo
 	return errors
 }
 
+// validateMapMinMax validates min/max constraints for the number of map entries.
+func validateMapMinMax(fieldValue reflect.Value, fieldPath string, tags tagConfig) []FieldError {
+	var errors []FieldError
+	length := fieldValue.Len()
+
+	if tags.min != "" {
+		minLen, err := strconv.Atoi(tags.min)
+		if err == nil && length < minLen {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeMin,
+				Message:   fmt.Sprintf("map has %d keys, below minimum %d", length, minLen),
+			})
+		}
+	}
+
+	if tags.max != "" {
+		maxLen, err := strconv.Atoi(tags.max)
+		if err == nil && length > maxLen {
+			errors = append(errors, FieldError{
+				FieldPath: fieldPath,
+				Code:      ErrCodeMax,
+				Message:   fmt.Sprintf("map has %d keys, exceeds maximum %d", length, maxLen),
+			})
+		}
+	}
+
+	return errors
+}
+
+// validateRequiredKeys validates that all keys listed in requiredkeys are present in a map.
+func validateRequiredKeys(fieldValue reflect.Value, fieldPath string, tags tagConfig) []FieldError {
+	var missing []string
+	for _, key := range tags.reqKeys {
+		if fieldValue.IsNil() || !fieldValue.MapIndex(reflect.ValueOf(key).Convert(fieldValue.Type().Key())).IsValid() {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	return []FieldError{{
+		FieldPath: fieldPath,
+		Code:      ErrCodeRequiredKeys,
+		Message:   fmt.Sprintf("missing required keys: %s", strings.Join(missing, ", ")),
+	}}
+}
+
 // validateOneof validates that a field value is one of the allowed options.
+// String slices are checked element-wise, with one error per invalid element (e.g. "Features[1]").
 func validateOneof(fieldValue reflect.Value, fieldPath string, tags tagConfig) []FieldError {
 	var errors []FieldError
 
 	// Convert field value to string for comparison
 	var valueStr string
 	switch fieldValue.Kind() {
+	case reflect.Slice:
+		if fieldValue.Type().Elem().Kind() != reflect.String {
+			return errors
+		}
+		for i := 0; i < fieldValue.Len(); i++ {
+			errors = append(errors, validateOneof(fieldValue.Index(i), fmt.Sprintf("%s[%d]", fieldPath, i), tags)...)
+		}
+		return errors
 	case reflect.String:
 		valueStr = fieldValue.String()
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -312,7 +757,7 @@ func validateOneof(fieldValue reflect.Value, fieldPath string, tags tagConfig) [
 		errors = append(errors, FieldError{
 			FieldPath: fieldPath,
 			Code:      ErrCodeOneOf,
-			Message:   fmt.Sprintf("value %q must be one of: %s", valueStr, strings.Join(tags.oneof, ", ")),
+			Message:   fmt.Sprintf("value %s must be one of: %s", displayValue(tags, strconv.Quote(valueStr)), strings.Join(tags.oneof, ", ")),
 		})
 	}
 
@@ -1,8 +1,12 @@
 package rigging
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateField_Required(t *testing.T) {
@@ -120,6 +124,178 @@ func TestValidateField_IntMinMax(t *testing.T) {
 	}
 }
 
+func TestValidateField_ExclusiveBounds(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		tag      string
+		wantCode string // Empty for no error
+	}{
+		// gt/lt are exclusive
+		{name: "int gt equal", value: 10, tag: "gt:10", wantCode: ErrCodeGt},
+		{name: "int gt above", value: 11, tag: "gt:10"},
+		{name: "int lt equal", value: 10, tag: "lt:10", wantCode: ErrCodeLt},
+		{name: "int lt below", value: 9, tag: "lt:10"},
+		{name: "uint gt equal", value: uint(10), tag: "gt:10", wantCode: ErrCodeGt},
+		{name: "uint lt equal", value: uint(10), tag: "lt:10", wantCode: ErrCodeLt},
+		{name: "uint lt below", value: uint(9), tag: "lt:10"},
+		{name: "float gt equal", value: 0.5, tag: "gt:0.5", wantCode: ErrCodeGt},
+		{name: "float gt above", value: 0.51, tag: "gt:0.5"},
+		{name: "float lt equal", value: 1.0, tag: "lt:1", wantCode: ErrCodeLt},
+		{name: "float lt below", value: 0.99, tag: "lt:1"},
+		{name: "duration gt equal", value: time.Second, tag: "gt:1s", wantCode: ErrCodeGt},
+		{name: "duration gt above", value: 1001 * time.Millisecond, tag: "gt:1s"},
+		{name: "duration lt equal", value: time.Minute, tag: "lt:1m", wantCode: ErrCodeLt},
+		{name: "duration lt below", value: 59 * time.Second, tag: "lt:1m"},
+
+		// gte/lte are inclusive aliases of min/max
+		{name: "int gte equal", value: 10, tag: "gte:10"},
+		{name: "int gte below", value: 9, tag: "gte:10", wantCode: ErrCodeMin},
+		{name: "int lte equal", value: 10, tag: "lte:10"},
+		{name: "int lte above", value: 11, tag: "lte:10", wantCode: ErrCodeMax},
+		{name: "float gte equal", value: 0.5, tag: "gte:0.5"},
+		{name: "duration lte equal", value: time.Minute, tag: "lte:1m"},
+		{name: "duration lte above", value: time.Minute + 1, tag: "lte:1m", wantCode: ErrCodeMax},
+
+		// min/max stay inclusive
+		{name: "int min equal", value: 10, tag: "min:10"},
+		{name: "int max equal", value: 10, tag: "max:10"},
+
+		// Combined with other directives
+		{name: "open interval inside", value: 0.5, tag: "gt:0,lt:1"},
+		{name: "open interval at upper bound", value: 1.0, tag: "gt:0,lt:1", wantCode: ErrCodeLt},
+		{name: "after oneof list", value: 5, tag: "oneof:5,10,gt:5", wantCode: ErrCodeGt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := validateField(reflect.ValueOf(tt.value), "TestField", parseTag(tt.tag))
+
+			if tt.wantCode == "" {
+				if len(errors) > 0 {
+					t.Errorf("expected no validation error, got: %v", errors)
+				}
+				return
+			}
+			if len(errors) != 1 {
+				t.Fatalf("expected 1 validation error, got: %v", errors)
+			}
+			if errors[0].Code != tt.wantCode {
+				t.Errorf("expected error code %q, got %q (%s)", tt.wantCode, errors[0].Code, errors[0].Message)
+			}
+		})
+	}
+}
+
+func TestValidateField_DurationMinMax(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       any
+		tags        tagConfig
+		wantCode    string
+		wantMessage string
+	}{
+		{
+			name:  "duration within range",
+			value: 5 * time.Second,
+			tags:  tagConfig{min: "1s", max: "30s"},
+		},
+		{
+			name:        "duration below minimum",
+			value:       500 * time.Millisecond,
+			tags:        tagConfig{min: "1s", max: "30s"},
+			wantCode:    ErrCodeMin,
+			wantMessage: "duration 500ms is below minimum 1s",
+		},
+		{
+			name:        "duration above maximum",
+			value:       time.Minute,
+			tags:        tagConfig{min: "1s", max: "30s"},
+			wantCode:    ErrCodeMax,
+			wantMessage: "duration 1m0s exceeds maximum 30s",
+		},
+		{
+			name:  "duration at maximum boundary",
+			value: 30 * time.Second,
+			tags:  tagConfig{max: "30s"},
+		},
+		{
+			name:     "integer bound is nanoseconds",
+			value:    2 * time.Second,
+			tags:     tagConfig{max: "1000000000"},
+			wantCode: ErrCodeMax,
+		},
+		{
+			name:  "duration bound on int64 field is not parsed",
+			value: int64(5),
+			tags:  tagConfig{min: "1s"},
+		},
+		{
+			name:     "int64 field keeps numeric bounds",
+			value:    int64(5),
+			tags:     tagConfig{min: "10"},
+			wantCode: ErrCodeMin,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := validateField(reflect.ValueOf(tt.value), "TestField", tt.tags)
+
+			if tt.wantCode == "" {
+				if len(errors) > 0 {
+					t.Errorf("expected no validation error, got: %v", errors)
+				}
+				return
+			}
+			if len(errors) != 1 || errors[0].Code != tt.wantCode {
+				t.Fatalf("expected single %s error, got: %v", tt.wantCode, errors)
+			}
+			if tt.wantMessage != "" && errors[0].Message != tt.wantMessage {
+				t.Errorf("message = %q, want %q", errors[0].Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestValidateField_TimeMinMax(t *testing.T) {
+	bound := "min:2024-01-01T00:00:00Z,max:2024-12-31"
+	tests := []struct {
+		name     string
+		value    time.Time
+		tags     tagConfig
+		wantCode string
+	}{
+		{name: "within range", value: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), tags: parseTag(bound)},
+		{name: "before minimum", value: time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC), tags: parseTag(bound), wantCode: ErrCodeMin},
+		{name: "after maximum", value: time.Date(2024, 12, 31, 0, 0, 1, 0, time.UTC), tags: parseTag(bound), wantCode: ErrCodeMax},
+		{name: "equal to minimum", value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), tags: parseTag(bound)},
+		{name: "equal to maximum", value: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), tags: parseTag(bound)},
+		{name: "minimum in another zone", value: time.Date(2024, 1, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600)), tags: parseTag(bound)},
+		{name: "past with min now", value: time.Now().Add(-time.Hour), tags: tagConfig{min: "now"}, wantCode: ErrCodeMin},
+		{name: "future with min now", value: time.Now().Add(time.Hour), tags: tagConfig{min: "now"}},
+		{name: "within relative window", value: time.Now().Add(-time.Hour), tags: tagConfig{min: "now-24h", max: "now+1h"}},
+		{name: "beyond relative max", value: time.Now().Add(2 * time.Hour), tags: tagConfig{max: "now+1h"}, wantCode: ErrCodeMax},
+		{name: "unparseable bound is ignored", value: time.Now(), tags: tagConfig{min: "tomorrow"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := validateField(reflect.ValueOf(tt.value), "NotBefore", tt.tags)
+
+			if tt.wantCode == "" {
+				if len(errors) > 0 {
+					t.Errorf("expected no validation error, got: %v", errors)
+				}
+				return
+			}
+			if len(errors) != 1 || errors[0].Code != tt.wantCode {
+				t.Fatalf("expected single %s error, got: %v", tt.wantCode, errors)
+			}
+		})
+	}
+}
+
 func TestValidateField_FloatMinMax(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -279,6 +455,68 @@ func TestValidateField_Oneof(t *testing.T) {
 	}
 }
 
+func TestValidateField_OneofSlice(t *testing.T) {
+	type Feature string
+	tags := tagConfig{oneof: []string{"beta", "metrics", "tracing"}}
+
+	tests := []struct {
+		name  string
+		value any
+		tags  tagConfig
+		want  []FieldError
+	}{
+		{
+			name:  "all elements allowed",
+			value: []string{"metrics", "beta"},
+			tags:  tags,
+		},
+		{
+			name:  "empty slice",
+			value: []string{},
+			tags:  tags,
+		},
+		{
+			name:  "mix of valid and invalid elements",
+			value: []string{"metrics", "logging", "beta", "Tracing"},
+			tags:  tags,
+			want: []FieldError{
+				{FieldPath: "Features[1]", Code: ErrCodeOneOf, Message: `value "logging" must be one of: beta, metrics, tracing`},
+				{FieldPath: "Features[3]", Code: ErrCodeOneOf, Message: `value "Tracing" must be one of: beta, metrics, tracing`},
+			},
+		},
+		{
+			name:  "named string elements",
+			value: []Feature{"beta", "alpha"},
+			tags:  tags,
+			want: []FieldError{
+				{FieldPath: "Features[1]", Code: ErrCodeOneOf, Message: `value "alpha" must be one of: beta, metrics, tracing`},
+			},
+		},
+		{
+			name:  "required empty slice",
+			value: []string{},
+			tags:  tagConfig{required: true, oneof: tags.oneof},
+			want: []FieldError{
+				{FieldPath: "Features", Code: ErrCodeRequired, Message: "field is required but not provided"},
+			},
+		},
+		{
+			name:  "non-string elements are not checked",
+			value: []int{1, 2},
+			tags:  tagConfig{oneof: []string{"1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := validateField(reflect.ValueOf(tt.value), "Features", tt.tags)
+			if !reflect.DeepEqual(errors, tt.want) {
+				t.Errorf("validateField() = %+v, want %+v", errors, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidateStruct(t *testing.T) {
 	type Config struct {
 		Name     string `conf:"required"`
@@ -342,7 +580,7 @@ func TestValidateStruct(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfgValue := reflect.ValueOf(tt.config)
-			errors := validateStruct(cfgValue)
+			errors := validateStruct(cfgValue, nil, CollectAll)
 
 			if len(errors) != tt.wantErrors {
 				t.Errorf("expected %d validation errors, got %d: %v", tt.wantErrors, len(errors), errors)
@@ -405,7 +643,7 @@ func TestValidateStruct_NestedStructs(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfgValue := reflect.ValueOf(tt.config)
-			errors := validateStruct(cfgValue)
+			errors := validateStruct(cfgValue, nil, CollectAll)
 
 			if len(errors) != tt.wantErrors {
 				t.Errorf("expected %d validation errors, got %d: %v", tt.wantErrors, len(errors), errors)
@@ -454,7 +692,7 @@ func TestValidateStruct_OptionalFields(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfgValue := reflect.ValueOf(tt.config)
-			errors := validateStruct(cfgValue)
+			errors := validateStruct(cfgValue, nil, CollectAll)
 
 			if len(errors) != tt.wantErrors {
 				t.Errorf("expected %d validation errors, got %d: %v", tt.wantErrors, len(errors), errors)
@@ -463,6 +701,252 @@ func TestValidateStruct_OptionalFields(t *testing.T) {
 	}
 }
 
+func TestLoad_OptionalRequired(t *testing.T) {
+	type Config struct {
+		Required    Optional[int] `conf:"required"`
+		WithDefault Optional[int] `conf:"required,default:3"`
+		Plain       Optional[int]
+	}
+
+	tests := []struct {
+		name         string
+		data         map[string]any
+		wantRequired Optional[int]
+		wantPlain    Optional[int]
+		wantErr      bool
+	}{
+		{
+			name:         "required with value",
+			data:         map[string]any{"required": "5"},
+			wantRequired: Optional[int]{Value: 5, Set: true},
+		},
+		{
+			name:         "required with zero value",
+			data:         map[string]any{"required": "0"},
+			wantRequired: Optional[int]{Value: 0, Set: true},
+		},
+		{
+			name:    "required without value",
+			data:    map[string]any{"plain": "7"},
+			wantErr: true,
+		},
+		{
+			name:         "not required with value",
+			data:         map[string]any{"required": "1", "plain": "7"},
+			wantRequired: Optional[int]{Value: 1, Set: true},
+			wantPlain:    Optional[int]{Value: 7, Set: true},
+		},
+		{
+			name:         "not required without value stays unset",
+			data:         map[string]any{"required": "1"},
+			wantRequired: Optional[int]{Value: 1, Set: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewLoader[Config]().
+				WithSource(&mockSource{data: tt.data}).
+				Load(context.Background())
+
+			if tt.wantErr {
+				valErr, ok := err.(*ValidationError)
+				if !ok {
+					t.Fatalf("expected ValidationError, got %v", err)
+				}
+				if len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].FieldPath != "Required" || valErr.FieldErrors[0].Code != ErrCodeRequired {
+					t.Errorf("expected a single required error for Required, got %+v", valErr.FieldErrors)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+
+			if cfg.Required != tt.wantRequired {
+				t.Errorf("Required = %+v, want %+v", cfg.Required, tt.wantRequired)
+			}
+			if cfg.Plain != tt.wantPlain {
+				t.Errorf("Plain = %+v, want %+v", cfg.Plain, tt.wantPlain)
+			}
+			// A default satisfies required
+			if want := (Optional[int]{Value: 3, Set: true}); cfg.WithDefault != want {
+				t.Errorf("WithDefault = %+v, want %+v", cfg.WithDefault, want)
+			}
+		})
+	}
+}
+
+func TestValidateStruct_FailFast(t *testing.T) {
+	type Database struct {
+		Host string `conf:"required"`
+	}
+	type Config struct {
+		Port     int `conf:"min:1024"`
+		Database Database
+		Primary  string `conf:"group:target"`
+		Replica  string `conf:"group:target"`
+	}
+	cfg := Config{Port: 80, Primary: "a", Replica: "b"}
+
+	if errors := validateStruct(reflect.ValueOf(cfg), nil, CollectAll); len(errors) != 3 {
+		t.Fatalf("CollectAll errors = %+v, want 3", errors)
+	}
+
+	errors := validateStruct(reflect.ValueOf(cfg), nil, FailFast)
+	if len(errors) != 1 || errors[0].FieldPath != "Port" {
+		t.Errorf("FailFast errors = %+v, want only Port", errors)
+	}
+}
+
+func TestLoad_RequiredZeroValue(t *testing.T) {
+	type Config struct {
+		Port    int    `conf:"required"`
+		Debug   bool   `conf:"required"`
+		Name    string `conf:"required"`
+		Retries int    `conf:"required,default:0"`
+	}
+
+	tests := []struct {
+		name      string
+		data      map[string]any
+		wantError []string // Field paths with a required error
+	}{
+		{
+			name: "zero values supplied by a source",
+			data: map[string]any{"port": 0, "debug": "false", "name": ""},
+		},
+		{
+			name: "non-zero values",
+			data: map[string]any{"port": 8080, "debug": true, "name": "api"},
+		},
+		{
+			name:      "missing values",
+			data:      map[string]any{"debug": false},
+			wantError: []string{"Port", "Name"},
+		},
+		{
+			name:      "null values from YAML",
+			data:      map[string]any{"port": nil, "debug": nil, "name": nil, "retries": nil},
+			wantError: []string{"Port", "Debug", "Name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLoader[Config]().
+				WithSource(&mockSource{data: tt.data}).
+				Load(context.Background())
+
+			if len(tt.wantError) == 0 {
+				if err != nil {
+					t.Fatalf("Load failed: %v", err)
+				}
+				return
+			}
+
+			valErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			var got []string
+			for _, fe := range valErr.FieldErrors {
+				if fe.Code != ErrCodeRequired {
+					t.Errorf("unexpected error: %+v", fe)
+				}
+				got = append(got, fe.FieldPath)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantError, ",") {
+				t.Errorf("required errors for %v, want %v", got, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestValidateField_MapRequiredKeys(t *testing.T) {
+	tags := parseTag("requiredkeys:beta,search")
+
+	tests := []struct {
+		name        string
+		value       map[string]bool
+		wantError   bool
+		wantMissing string
+	}{
+		{
+			name:      "all required keys present",
+			value:     map[string]bool{"beta": true, "search": false, "extra": true},
+			wantError: false,
+		},
+		{
+			name:        "one required key missing",
+			value:       map[string]bool{"beta": true},
+			wantError:   true,
+			wantMissing: "search",
+		},
+		{
+			name:        "nil map is missing all keys",
+			value:       nil,
+			wantError:   true,
+			wantMissing: "beta, search",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := validateField(reflect.ValueOf(tt.value), "Features", tags)
+
+			if !tt.wantError {
+				if len(errors) > 0 {
+					t.Errorf("expected no validation error, got: %v", errors)
+				}
+				return
+			}
+
+			if len(errors) != 1 {
+				t.Fatalf("expected 1 validation error, got %d: %v", len(errors), errors)
+			}
+			if errors[0].Code != ErrCodeRequiredKeys {
+				t.Errorf("expected error code %q, got %q", ErrCodeRequiredKeys, errors[0].Code)
+			}
+			if !strings.Contains(errors[0].Message, tt.wantMissing) {
+				t.Errorf("expected message to name %q, got %q", tt.wantMissing, errors[0].Message)
+			}
+		})
+	}
+}
+
+func TestValidateField_MapMinMax(t *testing.T) {
+	tags := tagConfig{min: "2", max: "3"}
+
+	tests := []struct {
+		name      string
+		value     map[string]string
+		wantError string
+	}{
+		{"within range", map[string]string{"a": "1", "b": "2"}, ""},
+		{"below minimum", map[string]string{"a": "1"}, ErrCodeMin},
+		{"above maximum", map[string]string{"a": "1", "b": "2", "c": "3", "d": "4"}, ErrCodeMax},
+		{"empty map skipped when not required", map[string]string{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := validateField(reflect.ValueOf(tt.value), "Labels", tags)
+
+			if tt.wantError == "" {
+				if len(errors) > 0 {
+					t.Errorf("expected no validation error, got: %v", errors)
+				}
+				return
+			}
+
+			if len(errors) != 1 || errors[0].Code != tt.wantError {
+				t.Errorf("expected single %q error, got: %v", tt.wantError, errors)
+			}
+		})
+	}
+}
+
 func TestIsZeroValue(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -491,3 +975,215 @@ func TestIsZeroValue(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateStruct_Groups(t *testing.T) {
+	type Shared struct {
+		Token string `conf:"group:auth,required-group:auth"`
+	}
+	type Config struct {
+		Shared
+		FilePath      string           `conf:"group:auth,required-group:auth"`
+		InlineContent Optional[string] `conf:"group:auth,required-group:auth"`
+		Proxy         string           `conf:"group:proxy"`
+		ProxyURL      string           `conf:"group:proxy"`
+	}
+
+	tests := []struct {
+		name      string
+		config    Config
+		wantCode  string
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name:   "exactly one set",
+			config: Config{FilePath: "/etc/app/key"},
+		},
+		{
+			name:   "promoted field counts",
+			config: Config{Shared: Shared{Token: "t"}},
+		},
+		{
+			name:      "two set",
+			config:    Config{FilePath: "/etc/app/key", InlineContent: Optional[string]{Value: "", Set: true}},
+			wantCode:  ErrCodeExclusiveGroup,
+			wantField: "FilePath",
+			wantMsg:   `only one of Token, FilePath, InlineContent may be set (group "auth"), got FilePath, InlineContent`,
+		},
+		{
+			name:      "none set in required group",
+			config:    Config{},
+			wantCode:  ErrCodeRequiredGroup,
+			wantField: "Token",
+			wantMsg:   `one of Token, FilePath, InlineContent must be set (group "auth")`,
+		},
+		{
+			name:      "optional group conflict",
+			config:    Config{FilePath: "/etc/app/key", Proxy: "on", ProxyURL: "http://proxy"},
+			wantCode:  ErrCodeExclusiveGroup,
+			wantField: "Proxy",
+			wantMsg:   `only one of Proxy, ProxyURL may be set (group "proxy"), got Proxy, ProxyURL`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := validateStruct(reflect.ValueOf(tt.config), nil, CollectAll)
+
+			if tt.wantCode == "" {
+				if len(errors) > 0 {
+					t.Errorf("unexpected errors: %+v", errors)
+				}
+				return
+			}
+
+			if len(errors) != 1 {
+				t.Fatalf("expected 1 error, got %d: %+v", len(errors), errors)
+			}
+			if errors[0].Code != tt.wantCode || errors[0].FieldPath != tt.wantField || errors[0].Message != tt.wantMsg {
+				t.Errorf("got %+v, want code %q field %q message %q", errors[0], tt.wantCode, tt.wantField, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestValidateStruct_OptionalStruct(t *testing.T) {
+	type TLS struct {
+		Cert string `conf:"required"`
+	}
+	type Config struct {
+		TLS Optional[TLS]
+	}
+
+	if errors := validateStruct(reflect.ValueOf(Config{}), nil, CollectAll); len(errors) != 0 {
+		t.Errorf("unset Optional struct should not be validated, got %+v", errors)
+	}
+
+	errors := validateStruct(reflect.ValueOf(Config{TLS: Optional[TLS]{Set: true}}), nil, CollectAll)
+	if len(errors) != 1 || errors[0].FieldPath != "TLS.Cert" || errors[0].Code != ErrCodeRequired {
+		t.Errorf("expected required error for TLS.Cert, got %+v", errors)
+	}
+}
+
+// testLogLevel is an int-backed enum that parses from and prints as its name.
+type testLogLevel int
+
+const (
+	testLevelDebug testLogLevel = iota
+	testLevelInfo
+	testLevelWarn
+)
+
+var testLogLevelNames = []string{"debug", "info", "warn"}
+
+func (l testLogLevel) String() string {
+	if l.Valid() {
+		return testLogLevelNames[l]
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+func (l testLogLevel) Valid() bool {
+	return l >= testLevelDebug && l <= testLevelWarn
+}
+
+func (l *testLogLevel) UnmarshalText(text []byte) error {
+	for i, name := range testLogLevelNames {
+		if strings.EqualFold(string(text), name) {
+			*l = testLogLevel(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown log level %q", text)
+}
+
+// testRegion validates through a pointer receiver.
+type testRegion string
+
+func (r *testRegion) Valid() bool {
+	return *r == "eu" || *r == "us"
+}
+
+func TestLoad_ValidMethod(t *testing.T) {
+	type Config struct {
+		Level  testLogLevel `conf:"default:info"`
+		Region testRegion
+		Backup Optional[testLogLevel]
+	}
+
+	tests := []struct {
+		name      string
+		data      map[string]any
+		wantLevel testLogLevel
+		wantCode  string // Expected error code, empty for success
+		wantField string
+		wantMsg   string
+	}{
+		{
+			name:      "text value binds through UnmarshalText",
+			data:      map[string]any{"level": "WARN", "region": "eu", "backup": "debug"},
+			wantLevel: testLevelWarn,
+		},
+		{
+			name:      "default binds through UnmarshalText",
+			data:      map[string]any{},
+			wantLevel: testLevelInfo,
+		},
+		{
+			name:      "unknown name fails to bind",
+			data:      map[string]any{"level": "verbose"},
+			wantCode:  ErrCodeInvalidType,
+			wantField: "Level",
+			wantMsg:   "unknown log level",
+		},
+		{
+			name:      "numeric value outside the enum",
+			data:      map[string]any{"level": 7},
+			wantCode:  ErrCodeOneOf,
+			wantField: "Level",
+			wantMsg:   `value "level(7)" is not a valid rigging.testLogLevel`,
+		},
+		{
+			name:      "pointer receiver",
+			data:      map[string]any{"region": "mars"},
+			wantCode:  ErrCodeOneOf,
+			wantField: "Region",
+		},
+		{
+			name:      "optional inner value",
+			data:      map[string]any{"backup": 9},
+			wantCode:  ErrCodeOneOf,
+			wantField: "Backup",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewLoader[Config]().
+				WithSource(&mockSource{data: tt.data}).
+				Load(context.Background())
+
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("Load failed: %v", err)
+				}
+				if cfg.Level != tt.wantLevel {
+					t.Errorf("Level = %v, want %v", cfg.Level, tt.wantLevel)
+				}
+				return
+			}
+
+			valErr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("expected ValidationError, got %v", err)
+			}
+			if len(valErr.FieldErrors) != 1 {
+				t.Fatalf("expected 1 error, got %+v", valErr.FieldErrors)
+			}
+			fe := valErr.FieldErrors[0]
+			if fe.Code != tt.wantCode || fe.FieldPath != tt.wantField || !strings.Contains(fe.Message, tt.wantMsg) {
+				t.Errorf("error = %+v, want code %s for %s containing %q", fe, tt.wantCode, tt.wantField, tt.wantMsg)
+			}
+		})
+	}
+}
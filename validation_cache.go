@@ -0,0 +1,104 @@
+package rigging
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// KeyedValidator is a Validator that declares which configuration keys it reads.
+// With WithValidationCache(true), it is skipped when those keys are unchanged since its last run.
+type KeyedValidator[T any] interface {
+	Validator[T]
+
+	// DependsOn returns the key paths the validator reads (e.g., "database.host").
+	// A key also covers every key below it (e.g., "database" covers "database.host").
+	DependsOn() []string
+}
+
+// ValidatorWithKeys wraps a validator with the key paths it depends on, for use with the validation cache.
+// The validator must be a pure function of those keys, otherwise cached results may be stale.
+func ValidatorWithKeys[T any](v Validator[T], keys ...string) KeyedValidator[T] {
+	return &keyedValidator[T]{Validator: v, keys: keys}
+}
+
+type keyedValidator[T any] struct {
+	Validator[T]
+	keys []string
+}
+
+func (k *keyedValidator[T]) DependsOn() []string {
+	return k.keys
+}
+
+// validationCache remembers the last result of each keyed validator.
+type validationCache struct {
+	mu      sync.Mutex
+	entries map[int]validationCacheEntry // Keyed by validator index
+}
+
+type validationCacheEntry struct {
+	fingerprint string
+	result      validatorResult
+}
+
+func newValidationCache() *validationCache {
+	return &validationCache{entries: make(map[int]validationCacheEntry)}
+}
+
+// lookup returns the cached result for a validator if its inputs are unchanged.
+// Cached warnings are delivered again, as if the validator had run.
+func (c *validationCache) lookup(index int, fingerprint string) (validatorResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[index]
+	if !ok || entry.fingerprint != fingerprint {
+		return validatorResult{}, false
+	}
+	return entry.result, true
+}
+
+// store records the result of a validator run.
+func (c *validationCache) store(index int, fingerprint string, result validatorResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[index] = validationCacheEntry{fingerprint: fingerprint, result: result}
+}
+
+// validatorFingerprint builds a cache key from the values of the keys a validator depends on.
+// Values are read unredacted, so a changed secret also invalidates the cache.
+func validatorFingerprint(values map[string]any, keys []string) string {
+	var matched []string
+	for key := range values {
+		for _, dep := range keys {
+			dep = strings.ToLower(dep)
+			if key == dep || strings.HasPrefix(key, dep+".") {
+				matched = append(matched, key)
+				break
+			}
+		}
+	}
+	sort.Strings(matched)
+
+	var b strings.Builder
+	for _, key := range matched {
+		fmt.Fprintf(&b, "%s=%#v;", key, values[key])
+	}
+	return b.String()
+}
+
+// flattenValues flattens a config struct into key paths without redacting secrets.
+func flattenValues(v reflect.Value) map[string]any {
+	result := make(map[string]any)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		flattenStructFields(v, "", "", map[string]*FieldProvenance{}, result)
+	}
+	return result
+}
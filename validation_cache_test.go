@@ -0,0 +1,101 @@
+package rigging
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLoad_ValidationCache(t *testing.T) {
+	type Config struct {
+		Host     string
+		Database struct {
+			Port     int
+			Password string `conf:"secret"`
+		}
+	}
+
+	var calls atomic.Int32
+	expensive := ValidatorWithKeys[Config](ValidatorFunc[Config](func(ctx context.Context, cfg *Config) error {
+		calls.Add(1)
+		if cfg.Database.Port == 1 {
+			return &ValidationError{FieldErrors: []FieldError{{FieldPath: "Database.Port", Code: "unreachable"}}}
+		}
+		return nil
+	}), "database")
+
+	source := &mockSource{data: map[string]any{"host": "a", "database.port": 5432, "database.password": "p1"}}
+	loader := NewLoader[Config]().
+		WithSource(source).
+		WithValidator(expensive).
+		WithValidationCache(true)
+
+	load := func() error {
+		_, err := loader.Load(context.Background())
+		return err
+	}
+
+	if err := load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 call, got %d", calls.Load())
+	}
+
+	// Unrelated key changes: validator is skipped
+	source.data = map[string]any{"host": "b", "database.port": 5432, "database.password": "p1"}
+	if err := load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("validator should be cached when unrelated keys change, got %d calls", calls.Load())
+	}
+
+	// Dependent secret changes: validator runs again
+	source.data = map[string]any{"host": "b", "database.port": 5432, "database.password": "p2"}
+	if err := load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("validator should re-run when a dependent secret changes, got %d calls", calls.Load())
+	}
+
+	// Cached failures are replayed
+	source.data = map[string]any{"host": "b", "database.port": 1, "database.password": "p2"}
+	for i := 0; i < 2; i++ {
+		err := load()
+		valErr, ok := err.(*ValidationError)
+		if !ok || len(valErr.FieldErrors) != 1 || valErr.FieldErrors[0].Code != "unreachable" {
+			t.Fatalf("load %d: expected cached validation error, got %v", i, err)
+		}
+	}
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestLoad_ValidationCacheDisabled(t *testing.T) {
+	type Config struct {
+		Host string
+	}
+
+	var calls atomic.Int32
+	validator := ValidatorWithKeys[Config](ValidatorFunc[Config](func(ctx context.Context, cfg *Config) error {
+		calls.Add(1)
+		return nil
+	}), "host")
+
+	loader := NewLoader[Config]().
+		WithSource(&mockSource{data: map[string]any{"host": "a"}}).
+		WithValidator(validator)
+
+	for i := 0; i < 2; i++ {
+		if _, err := loader.Load(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("without cache the validator should run every load, got %d calls", calls.Load())
+	}
+}